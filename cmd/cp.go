@@ -0,0 +1,108 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/cp"
+	"github.com/okteto/okteto/pkg/errors"
+	k8Client "github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// Cp copies files and folders between your computer and the development container
+func Cp() *cobra.Command {
+	var devPath string
+	var devName string
+	var namespace string
+	var k8sContext string
+	cmd := &cobra.Command{
+		Use:   "cp <src> <dst>",
+		Short: "Copy files and folders between your computer and your development container",
+		Example: `  okteto cp ./config.json web:/app/config.json
+  okteto cp web:/app/logs ./logs`,
+		Args: utils.ExactArgsAccepted(2, "https://okteto.com/docs/reference/cli/index.html#cp"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if okteto.InDevContainer() {
+				return errors.ErrNotInDevContainer
+			}
+
+			dev, err := utils.LoadDev(devPath, devName, namespace, k8sContext)
+			if err != nil {
+				return err
+			}
+
+			local, remote, upload, err := cp.ParseArgs(dev.Name, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			client, cfg, err := k8Client.GetLocalWithContext(dev.Context)
+			if err != nil {
+				return err
+			}
+
+			p, err := pods.GetDevPod(ctx, dev, client, true)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return errors.UserError{
+						E:    fmt.Errorf("Development container not found in namespace %s", dev.Namespace),
+						Hint: "Run 'okteto up' to launch it or use 'okteto namespace' to select the correct namespace and try again",
+					}
+				}
+				return err
+			}
+			if p == nil {
+				return errors.UserError{
+					E:    fmt.Errorf("development mode is not enabled"),
+					Hint: "Run 'okteto up' to enable it and try again",
+				}
+			}
+			if dev.Container == "" {
+				dev.Container = p.Spec.Containers[0].Name
+			}
+
+			execFunc := func(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error {
+				return exec.Exec(ctx, client, cfg, dev.Namespace, p.Name, dev.Container, false, stdin, stdout, os.Stderr, command)
+			}
+
+			if upload {
+				err = cp.Upload(ctx, execFunc, local, remote)
+			} else {
+				err = cp.Download(ctx, execFunc, remote, local)
+			}
+			if err != nil {
+				return err
+			}
+
+			log.Success("Copied '%s' to '%s'", args[0], args[1])
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&devName, "dev", "", "", "name of the development environment, if the manifest defines several ones")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the cp command is executed")
+	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the cp command is executed")
+	return cmd
+}
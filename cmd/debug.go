@@ -0,0 +1,90 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/debug"
+	"github.com/okteto/okteto/pkg/errors"
+	k8Client "github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// Debug attaches an ephemeral debug container to the development container
+func Debug() *cobra.Command {
+	var devPath string
+	var namespace string
+	var k8sContext string
+	opts := &debug.Options{}
+	cmd := &cobra.Command{
+		Use:   "debug [name]",
+		Short: "Open a shell in a debug container attached to your development container",
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#debug"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			devName := ""
+			if len(args) > 0 {
+				devName = args[0]
+			}
+
+			if okteto.InDevContainer() {
+				return errors.ErrNotInDevContainer
+			}
+
+			dev, err := utils.LoadDev(devPath, devName, namespace, k8sContext)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			client, cfg, err := k8Client.GetLocalWithContext(dev.Context)
+			if err != nil {
+				return err
+			}
+
+			p, err := pods.GetDevPod(ctx, dev, client, true)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return errors.UserError{
+						E:    fmt.Errorf("Development container not found in namespace %s", dev.Namespace),
+						Hint: "Run 'okteto up' to launch it or use 'okteto namespace' to select the correct namespace and try again",
+					}
+				}
+				return err
+			}
+			if p == nil {
+				return errors.UserError{
+					E:    fmt.Errorf("development mode is not enabled"),
+					Hint: "Run 'okteto up' to enable it and try again",
+				}
+			}
+			if dev.Container == "" {
+				dev.Container = p.Spec.Containers[0].Name
+			}
+
+			opts.Timeout = dev.Timeout.Resources
+			return debug.Run(ctx, p, dev.Container, opts, client, cfg, os.Stdin, os.Stdout, os.Stderr)
+		},
+	}
+	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the debug command is executed")
+	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the debug command is executed")
+	cmd.Flags().StringVarP(&opts.Image, "image", "", debug.DefaultImage, "image of the debug container with your debugging tools")
+	return cmd
+}
@@ -32,17 +32,22 @@ func Doctor() *cobra.Command {
 	var namespace string
 	var k8sContext string
 	cmd := &cobra.Command{
-		Use:   "doctor",
+		Use:   "doctor [name]",
 		Short: "Generates a zip file with the okteto logs",
-		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#doctor"),
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#doctor"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			devName := ""
+			if len(args) > 0 {
+				devName = args[0]
+			}
+
 			log.Info("starting doctor command")
 
 			if okteto.InDevContainer() {
 				return errors.ErrNotInDevContainer
 			}
 
-			dev, err := utils.LoadDev(devPath, namespace, k8sContext)
+			dev, err := utils.LoadDev(devPath, devName, namespace, k8sContext)
 			if err != nil {
 				return err
 			}
@@ -20,6 +20,7 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/down"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	k8Client "github.com/okteto/okteto/pkg/k8s/client"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
@@ -37,14 +38,26 @@ func Down() *cobra.Command {
 	var namespace string
 	var k8sContext string
 	var rm bool
+	var cleanupForwards bool
+	var removeArtifacts bool
 
 	cmd := &cobra.Command{
-		Use:   "down",
+		Use:   "down [name]",
 		Short: "Deactivates your development container",
-		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#down"),
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#down"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			devName := ""
+			if len(args) > 0 {
+				devName = args[0]
+			}
+
 			ctx := context.Background()
-			dev, err := utils.LoadDev(devPath, namespace, k8sContext)
+			projectConfig, err := utils.LoadProjectConfig(devPath)
+			if err != nil {
+				return err
+			}
+
+			dev, err := utils.LoadDevFromSession(ctx, devPath, devName, projectConfig.GetNamespace(namespace), projectConfig.GetContext(k8sContext))
 			if err != nil {
 				return err
 			}
@@ -54,6 +67,24 @@ func Down() *cobra.Command {
 				return err
 			}
 
+			sessionStore, err := utils.LoadSessionStore(dev.Context, dev.Namespace)
+			if err != nil {
+				return err
+			}
+
+			if cleanupForwards {
+				if err := cleanupOrphanForwards(ctx, sessionStore, devPath, dev); err != nil {
+					analytics.TrackDown(false)
+					return err
+				}
+			}
+
+			removeEnvFile(ctx, sessionStore, devPath, dev)
+
+			if err := config.DeleteSession(ctx, sessionStore, devPath, dev.ManifestKey()); err != nil {
+				log.Infof("failed to delete the session: %s", err.Error())
+			}
+
 			log.Success("Development container deactivated")
 
 			if rm {
@@ -72,6 +103,17 @@ func Down() *cobra.Command {
 				analytics.TrackDownVolumes(true)
 			}
 
+			if removeArtifacts {
+				removed, err := down.RemoveManifestArtifacts(devPath)
+				for _, path := range removed {
+					log.Success("Removed '%s'", path)
+				}
+				if err != nil {
+					analytics.TrackDown(false)
+					return err
+				}
+			}
+
 			log.Println()
 
 			analytics.TrackDown(true)
@@ -83,6 +125,8 @@ func Down() *cobra.Command {
 	cmd.Flags().BoolVarP(&rm, "volumes", "v", false, "remove persistent volume")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the down command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the down command is executed")
+	cmd.Flags().BoolVarP(&cleanupForwards, "orphan-forwards-cleanup", "", false, "close the local forwards left behind by a previous 'okteto up'")
+	cmd.Flags().BoolVarP(&removeArtifacts, "remove-manifest-artifacts", "", false, "remove the okteto manifest and '.stignore' files generated by 'okteto init'. Files without the generated marker are kept")
 	return cmd
 }
 
@@ -120,6 +164,48 @@ func runDown(ctx context.Context, dev *model.Dev) error {
 	return nil
 }
 
+func cleanupOrphanForwards(ctx context.Context, store config.SessionStore, devPath string, dev *model.Dev) error {
+	session, err := config.GetSession(ctx, store, devPath, dev.ManifestKey())
+	if err != nil {
+		log.Infof("failed to load the session of '%s': %s", devPath, err)
+		return nil
+	}
+	if session != nil && (session.Name != dev.Name || session.Namespace != dev.Namespace) {
+		log.Infof("the session of '%s' belongs to '%s' in namespace '%s', skipping its forwards", devPath, session.Name, session.Namespace)
+		return nil
+	}
+
+	spinner := utils.NewSpinner("Closing orphan local forwards...")
+	spinner.Start()
+	defer spinner.Stop()
+
+	return down.CleanupOrphanForwards(session)
+}
+
+// removeEnvFile removes the dotenv file written by 'okteto up --write-env', if any
+func removeEnvFile(ctx context.Context, store config.SessionStore, devPath string, dev *model.Dev) {
+	session, err := config.GetSession(ctx, store, devPath, dev.ManifestKey())
+	if err != nil || session == nil || session.EnvFile == "" {
+		return
+	}
+
+	written, err := model.IsWriteEnvFile(session.EnvFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Infof("failed to read '%s': %s", session.EnvFile, err)
+		}
+		return
+	}
+	if !written {
+		log.Infof("'%s' wasn't written by 'okteto up --write-env', keeping it", session.EnvFile)
+		return
+	}
+
+	if err := os.Remove(session.EnvFile); err != nil && !os.IsNotExist(err) {
+		log.Infof("failed to remove '%s': %s", session.EnvFile, err)
+	}
+}
+
 func removeVolume(ctx context.Context, dev *model.Dev) error {
 	spinner := utils.NewSpinner("Removing persistent volume...")
 	spinner.Start()
@@ -14,11 +14,18 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/moby/term"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/status"
@@ -28,74 +35,147 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/pods"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/shell"
 	"github.com/okteto/okteto/pkg/ssh"
 
 	k8Client "github.com/okteto/okteto/pkg/k8s/client"
 
 	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
+var envKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // Exec executes a command on the CND container
 func Exec() *cobra.Command {
 	var devPath string
+	var devName string
 	var namespace string
 	var k8sContext string
+	var timeout time.Duration
+	var script string
+	var user string
+	var podName string
+	var envs []string
 
 	cmd := &cobra.Command{
 		Use:   "exec <command>",
 		Short: "Execute a command in your development container",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx, cancel := context.WithCancel(context.Background())
+			if err := validateExecEnv(envs); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			var cancel context.CancelFunc
+			if timeout > 0 {
+				if _, isTerm := term.GetFdInfo(os.Stdin); isTerm && script == "" {
+					return errors.UserError{
+						E:    fmt.Errorf("'--timeout' is only supported in non-interactive mode"),
+						Hint: "Redirect the standard input of 'okteto exec' or remove the '--timeout' flag",
+					}
+				}
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+			} else {
+				ctx, cancel = context.WithCancel(ctx)
+			}
 			defer cancel()
 
-			dev, err := utils.LoadDev(devPath, namespace, k8sContext)
+			projectConfig, err := utils.LoadProjectConfig(devPath)
+			if err != nil {
+				return err
+			}
+
+			dev, err := utils.LoadDev(devPath, devName, projectConfig.GetNamespace(namespace), projectConfig.GetContext(k8sContext))
 			if err != nil {
 				return err
 			}
+
+			var scriptContent []byte
+			if script != "" {
+				scriptContent, err = ioutil.ReadFile(script)
+				if err != nil {
+					return fmt.Errorf("failed to read the script '%s': %s", script, err)
+				}
+			}
+
 			t := time.NewTicker(1 * time.Second)
 			iter := 0
-			err = executeExec(ctx, dev, args)
-			for errors.IsTransient(err) {
+			err = executeExec(ctx, dev, args, scriptContent, user, podName, envs)
+			for errors.IsTransient(err) && ctx.Err() == nil {
 				if iter == 0 {
 					log.Yellow("Connection lost to your development container, reconnecting...")
 				}
 				iter++
 				iter = iter % 10
 				<-t.C
-				err = executeExec(ctx, dev, args)
+				err = executeExec(ctx, dev, args, scriptContent, user, podName, envs)
+			}
+
+			if ctx.Err() == context.DeadlineExceeded {
+				err = errors.ErrExecTimeout
 			}
 
 			analytics.TrackExec(err == nil)
 
-			if errors.IsNotFound(err) {
+			if errors.IsNotFound(err) && podName == "" {
 				return errors.UserError{
 					E:    fmt.Errorf("Development container not found in namespace %s", dev.Namespace),
 					Hint: "Run 'okteto up' to launch it or use 'okteto namespace' to select the correct namespace and try again",
 				}
 			}
 
+			if script != "" {
+				return getScriptError(err)
+			}
+
 			return err
 		},
-		Args: utils.MinimumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#exec"),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if script != "" {
+				return utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#exec")(cmd, args)
+			}
+			return utils.MinimumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#exec")(cmd, args)
+		},
 	}
 
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&devName, "dev", "", "", "name of the development environment, if the manifest defines several ones")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the exec command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the exec command is executed")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "", 0, "maximum time to wait for a non-interactive command to finish")
+	cmd.Flags().StringVarP(&script, "script", "", "", "path of a local shell script to run in your development container instead of a command")
+	cmd.Flags().StringVarP(&podName, "pod", "", "", "name of the pod where the command is executed, instead of the pod of your development container. The pod must be running in the namespace")
+	cmd.Flags().StringVarP(&user, "user", "u", "", "user name or uid to run the command as. It requires 'sudo' in your development container, or 'su' if the container runs as root (uids are only supported with 'sudo')")
+	cmd.Flags().StringArrayVarP(&envs, "env", "e", []string{}, "environment variable of the command, like 'DEBUG=1' (can be set more than once)")
 
 	return cmd
 }
 
-func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
-
-	wrapped := []string{"sh", "-c"}
-	wrapped = append(wrapped, args...)
+func executeExec(ctx context.Context, dev *model.Dev, args []string, script []byte, user, podName string, envs []string) error {
+	wrapped, stdin, tty := getExecCommand(args, script)
+	wrapped = getEnvCommand(wrapped, envs)
+	wrapped = getUserCommand(wrapped, user)
 
 	client, cfg, err := k8Client.GetLocalWithContext(dev.Context)
 	if err != nil {
 		return err
 	}
 
+	if podName != "" {
+		p, err := getRunningPod(ctx, podName, dev.Namespace, client)
+		if err != nil {
+			return err
+		}
+		container, err := getExecContainer(p, dev.Container)
+		if err != nil {
+			return err
+		}
+		return exec.Exec(ctx, client, cfg, dev.Namespace, p.Name, container, tty, stdin, os.Stdout, os.Stderr, wrapped)
+	}
+
 	p, err := pods.GetDevPod(ctx, dev, client, true)
 	if err != nil {
 		return err
@@ -134,8 +214,110 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 
 		dev.LoadRemote(ssh.GetPublicKey())
 
-		return ssh.Exec(ctx, dev.Interface, dev.RemotePort, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
+		return ssh.Exec(ctx, dev.Interface, dev.RemotePort, tty, stdin, os.Stdout, os.Stderr, wrapped)
 	}
 
-	return exec.Exec(ctx, client, cfg, dev.Namespace, p.Name, dev.Container, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
+	return exec.Exec(ctx, client, cfg, dev.Namespace, p.Name, dev.Container, tty, stdin, os.Stdout, os.Stderr, wrapped)
+}
+
+// getRunningPod returns the pod of the '--pod' flag, bypassing the selection of the pod of the development container
+func getRunningPod(ctx context.Context, name, namespace string, c kubernetes.Interface) (*apiv1.Pod, error) {
+	p, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.UserError{
+				E:    fmt.Errorf("pod '%s' not found in namespace '%s'", name, namespace),
+				Hint: "Run 'kubectl get pods' to list the pods of the namespace and try again",
+			}
+		}
+		return nil, fmt.Errorf("failed to get pod '%s': %s", name, err)
+	}
+
+	if p.Status.Phase != apiv1.PodRunning || p.GetDeletionTimestamp() != nil {
+		return nil, errors.UserError{
+			E:    fmt.Errorf("pod '%s' is not running", name),
+			Hint: fmt.Sprintf("Check the status of the pod with 'kubectl describe pod %s' and try again", name),
+		}
+	}
+
+	return p, nil
+}
+
+// getExecContainer returns the container of p where the command is executed, the first one if container is empty
+func getExecContainer(p *apiv1.Pod, container string) (string, error) {
+	if container == "" {
+		return p.Spec.Containers[0].Name, nil
+	}
+
+	for _, c := range p.Spec.Containers {
+		if c.Name == container {
+			return container, nil
+		}
+	}
+
+	return "", errors.UserError{
+		E:    fmt.Errorf("container '%s' not found in pod '%s'", container, p.Name),
+		Hint: "Set the 'container' field of your okteto manifest to a container of the pod and try again",
+	}
+}
+
+// getExecCommand returns the command to execute, its standard input and if it runs in a terminal.
+// Scripts are piped to 'sh -s' without a terminal, so their content doesn't need to be quoted
+func getExecCommand(args []string, script []byte) ([]string, io.Reader, bool) {
+	if script != nil {
+		return []string{"sh", "-s"}, bytes.NewReader(script), false
+	}
+
+	wrapped := []string{"sh", "-c"}
+	wrapped = append(wrapped, args...)
+	return wrapped, os.Stdin, true
+}
+
+// getScriptError returns the exit code of a failed script so 'okteto exec' exits with it
+func getScriptError(err error) error {
+	if code, ok := errors.GetExitCode(err); ok {
+		return errors.ExitCodeError{Code: code}
+	}
+	return err
+}
+
+// validateExecEnv checks that the values of '--env' are of the form KEY=VALUE
+func validateExecEnv(envs []string) error {
+	for _, e := range envs {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 || !envKeyRegex.MatchString(parts[0]) {
+			return fmt.Errorf("invalid value '%s' for '--env': must be 'KEY=VALUE'", e)
+		}
+	}
+	return nil
+}
+
+// getEnvCommand wraps command with 'env' to set the variables of '--env'.
+// It must be applied before getUserCommand, since 'sudo' resets the environment of the command
+func getEnvCommand(command, envs []string) []string {
+	if len(envs) == 0 {
+		return command
+	}
+
+	wrapped := append([]string{"env"}, envs...)
+	return append(wrapped, command...)
+}
+
+// getUserCommand wraps command to run it as user.
+// The kubernetes exec API and the SSH server of the development container can't switch users,
+// so the command runs with 'sudo', or with 'su' when 'sudo' isn't available
+func getUserCommand(command []string, user string) []string {
+	if user == "" {
+		return command
+	}
+
+	quoted := shell.Quote(command...)
+	sudoUser := user
+	suCommand := fmt.Sprintf("exec su -s /bin/sh -c %s %s", shell.Quote(quoted), shell.Quote(user))
+	if _, err := strconv.ParseUint(user, 10, 32); err == nil {
+		sudoUser = "#" + user
+		suCommand = fmt.Sprintf("echo %s >&2; exit 1", shell.Quote(fmt.Sprintf("'sudo' is required to run commands as the uid %s", user)))
+	}
+	script := fmt.Sprintf("if command -v sudo >/dev/null 2>&1; then exec sudo -u %s -- %s; else %s; fi", shell.Quote(sudoUser), quoted, suCommand)
+	return []string{"sh", "-c", script}
 }
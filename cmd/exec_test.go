@@ -0,0 +1,302 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_getExecCommandScript(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test requires sh")
+	}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "output")
+	script := filepath.Join(dir, "script.sh")
+	content := "set -e\n" +
+		"echo \"it's 'quoted'\" > " + output + "\n" +
+		"exit 3\n"
+	if err := ioutil.WriteFile(script, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	command, stdin, tty := getExecCommand(nil, b)
+	if tty {
+		t.Error("scripts must not run in a terminal")
+	}
+
+	c := osexec.Command(command[0], command[1:]...)
+	c.Stdin = stdin
+	err = getScriptError(c.Run())
+
+	eErr, ok := err.(errors.ExitCodeError)
+	if !ok {
+		t.Fatalf("expected an exit code error, got %v", err)
+	}
+	if eErr.Code != 3 {
+		t.Errorf("expected exit code 3, got %d", eErr.Code)
+	}
+
+	out, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("the script wasn't executed: %s", err)
+	}
+	if string(out) != "it's 'quoted'\n" {
+		t.Errorf("unexpected script output: %q", string(out))
+	}
+}
+
+func Test_getExecCommandArgs(t *testing.T) {
+	command, stdin, tty := getExecCommand([]string{"echo hello"}, nil)
+	if len(command) != 3 || command[0] != "sh" || command[1] != "-c" || command[2] != "echo hello" {
+		t.Errorf("unexpected command: %v", command)
+	}
+	if stdin != os.Stdin {
+		t.Error("commands must read from the standard input")
+	}
+	if !tty {
+		t.Error("commands must run in a terminal")
+	}
+}
+
+func Test_getUserCommand(t *testing.T) {
+	command := []string{"sh", "-c", "echo hello"}
+	if result := getUserCommand(command, ""); !reflect.DeepEqual(result, command) {
+		t.Errorf("the command must not be wrapped without a user: %v", result)
+	}
+}
+
+func Test_getUserCommandWrapper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test requires sh")
+	}
+
+	var tests = []struct {
+		name         string
+		user         string
+		fakes        []string
+		expectedArgs string
+		expectErr    bool
+	}{
+		{
+			name:         "sudo",
+			user:         "root",
+			fakes:        []string{"sudo", "su"},
+			expectedArgs: "sudo -u root --",
+		},
+		{
+			name:         "sudo-uid",
+			user:         "1000",
+			fakes:        []string{"sudo", "su"},
+			expectedArgs: "sudo -u #1000 --",
+		},
+		{
+			name:         "su",
+			user:         "root",
+			fakes:        []string{"su"},
+			expectedArgs: "su -s /bin/sh -c root",
+		},
+		{
+			name:      "su-uid",
+			user:      "1000",
+			fakes:     []string{"su"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			argsFile := filepath.Join(dir, "args")
+			output := filepath.Join(dir, "output")
+			fakes := map[string]string{
+				"sudo": "#!/bin/sh\necho sudo $1 $2 $3 > " + argsFile + "\nshift 3\nPATH=/bin:/usr/bin exec \"$@\"\n",
+				"su":   "#!/bin/sh\necho su $1 $2 $3 $5 > " + argsFile + "\nPATH=/bin:/usr/bin exec /bin/sh -c \"$4\"\n",
+			}
+			for _, f := range tt.fakes {
+				if err := ioutil.WriteFile(filepath.Join(dir, f), []byte(fakes[f]), 0700); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			command := []string{"sh", "-c", "echo \"it's 'quoted'\" > " + output}
+			wrapped := getUserCommand(command, tt.user)
+			c := osexec.Command("/bin/sh", wrapped[1:]...)
+			c.Env = []string{"PATH=" + dir}
+			err = c.Run()
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			args, err := ioutil.ReadFile(argsFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.TrimSpace(string(args)) != tt.expectedArgs {
+				t.Errorf("wrong wrapper args: '%s', expected '%s'", strings.TrimSpace(string(args)), tt.expectedArgs)
+			}
+
+			out, err := ioutil.ReadFile(output)
+			if err != nil {
+				t.Fatalf("the command wasn't executed: %s", err)
+			}
+			if string(out) != "it's 'quoted'\n" {
+				t.Errorf("unexpected command output: %q", string(out))
+			}
+		})
+	}
+}
+
+func Test_validateExecEnv(t *testing.T) {
+	var tests = []struct {
+		name      string
+		envs      []string
+		expectErr bool
+	}{
+		{name: "valid", envs: []string{"DEBUG=1", "EMPTY=", "URL=http://api:8080/?a=b"}},
+		{name: "no-value", envs: []string{"DEBUG"}, expectErr: true},
+		{name: "empty-key", envs: []string{"=1"}, expectErr: true},
+		{name: "invalid-key", envs: []string{"MY-VAR=1"}, expectErr: true},
+		{name: "flag", envs: []string{"-i=1"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExecEnv(tt.envs)
+			if tt.expectErr && err == nil {
+				t.Error("didn't get the expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func Test_getEnvCommand(t *testing.T) {
+	command := []string{"sh", "-c", "echo hello"}
+	if result := getEnvCommand(command, nil); !reflect.DeepEqual(result, command) {
+		t.Errorf("the command must not be wrapped without variables: %v", result)
+	}
+
+	if runtime.GOOS == "windows" {
+		t.Skip("this test requires sh")
+	}
+
+	command = []string{"sh", "-c", `echo "$DEBUG $GREETING"`}
+	wrapped := getEnvCommand(command, []string{"DEBUG=1", "GREETING=it's 'quoted'"})
+	expected := []string{"env", "DEBUG=1", "GREETING=it's 'quoted'", "sh", "-c", `echo "$DEBUG $GREETING"`}
+	if !reflect.DeepEqual(wrapped, expected) {
+		t.Fatalf("wrong command: %v", wrapped)
+	}
+
+	out, err := osexec.Command(wrapped[0], wrapped[1:]...).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "1 it's 'quoted'\n" {
+		t.Errorf("unexpected command output: %q", string(out))
+	}
+}
+
+func Test_getRunningPod(t *testing.T) {
+	ctx := context.Background()
+	running := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "test"},
+		Status:     apiv1.PodStatus{Phase: apiv1.PodRunning},
+	}
+	pending := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-2", Namespace: "test"},
+		Status:     apiv1.PodStatus{Phase: apiv1.PodPending},
+	}
+	c := fake.NewSimpleClientset(running, pending)
+
+	p, err := getRunningPod(ctx, "api-1", "test", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "api-1" {
+		t.Errorf("expected pod 'api-1', got '%s'", p.Name)
+	}
+
+	_, err = getRunningPod(ctx, "api-3", "test", c)
+	uErr, ok := err.(errors.UserError)
+	if !ok {
+		t.Fatalf("expected a user error, got %v", err)
+	}
+	if uErr.E.Error() != "pod 'api-3' not found in namespace 'test'" {
+		t.Errorf("wrong error: %s", uErr.E)
+	}
+
+	_, err = getRunningPod(ctx, "api-2", "test", c)
+	if _, ok := err.(errors.UserError); !ok {
+		t.Fatalf("expected a user error for a pod not running, got %v", err)
+	}
+}
+
+func Test_getExecContainer(t *testing.T) {
+	p := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1"},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{{Name: "api"}, {Name: "sidecar"}},
+		},
+	}
+
+	if c, err := getExecContainer(p, ""); err != nil || c != "api" {
+		t.Errorf("expected container 'api', got '%s': %v", c, err)
+	}
+
+	if c, err := getExecContainer(p, "sidecar"); err != nil || c != "sidecar" {
+		t.Errorf("expected container 'sidecar', got '%s': %v", c, err)
+	}
+
+	if _, err := getExecContainer(p, "worker"); err == nil {
+		t.Error("expected an error for a container not in the pod")
+	}
+}
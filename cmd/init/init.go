@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
@@ -50,12 +51,16 @@ func Init() *cobra.Command {
 	var k8sContext string
 	var devPath string
 	var overwrite bool
+	var language string
 	cmd := &cobra.Command{
 		Use:   "init",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli#init"),
 		Short: "Automatically generates your okteto manifest file",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			l := os.Getenv("OKTETO_LANGUAGE")
+			l, err := getLanguageOverride(language)
+			if err != nil {
+				return err
+			}
 			workDir, err := os.Getwd()
 			if err != nil {
 				return err
@@ -80,9 +85,23 @@ func Init() *cobra.Command {
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context target for generating the okteto manifest")
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
 	cmd.Flags().BoolVarP(&overwrite, "overwrite", "o", false, "overwrite existing manifest file")
+	cmd.Flags().StringVarP(&language, "language", "l", "", "language of your project. It skips the language autodetection")
 	return cmd
 }
 
+func getLanguageOverride(language string) (string, error) {
+	if language == "" {
+		language = os.Getenv("OKTETO_LANGUAGE")
+	}
+	if language == "" {
+		return "", nil
+	}
+	if !linguist.IsSupportedLanguage(language) {
+		return "", fmt.Errorf("language '%s' is not supported. Supported values are: %s", language, strings.Join(linguist.GetSupportedLanguages(), ", "))
+	}
+	return language, nil
+}
+
 // Run runs the sequence to generate okteto.yml
 func Run(namespace, k8sContext, devPath, language, workDir string, overwrite bool) error {
 	if k8sContext == "" {
@@ -156,7 +175,7 @@ func Run(namespace, k8sContext, devPath, language, workDir string, overwrite boo
 		}
 	}
 
-	if err := dev.Save(devPath); err != nil {
+	if err := dev.SaveGenerated(devPath); err != nil {
 		return err
 	}
 
@@ -167,7 +186,7 @@ func Run(namespace, k8sContext, devPath, language, workDir string, overwrite boo
 	stignore := filepath.Join(devDir, stignoreFile)
 
 	if !model.FileExists(stignore) {
-		c := linguist.GetSTIgnore(language)
+		c := model.MarkAsGenerated(linguist.GetSTIgnore(language), "//")
 		if err := ioutil.WriteFile(stignore, c, 0600); err != nil {
 			log.Infof("failed to write stignore file: %s", err)
 		}
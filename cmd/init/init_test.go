@@ -43,7 +43,7 @@ func TestRun(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	dev, err := utils.LoadDev(p, "namespace", "context")
+	dev, err := utils.LoadDev(p, "", "namespace", "context")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -56,7 +56,7 @@ func TestRun(t *testing.T) {
 		t.Fatalf("manifest wasn't overwritten: %s", err)
 	}
 
-	dev, err = utils.LoadDev(p, "namespace", "context")
+	dev, err = utils.LoadDev(p, "", "namespace", "context")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -94,3 +94,35 @@ func TestRunJustCreateNecessaryFields(t *testing.T) {
 	}
 
 }
+
+func TestRunWithLanguageOverride(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if _, err := getLanguageOverride("cobol"); err == nil {
+		t.Fatal("expected error for unsupported language")
+	}
+
+	language, err := getLanguageOverride("python")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := filepath.Join(dir, fmt.Sprintf("okteto-%s", uuid.New().String()))
+	if err := Run("", "", p, language, dir, false); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := utils.LoadDev(p, "", "namespace", "context")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Image.Name != "okteto/python:3" {
+		t.Errorf("got %s, expected %s", dev.Image.Name, "okteto/python:3")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/login"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// Kubeconfig writes the credentials of an okteto namespace into a kubeconfig file
+func Kubeconfig(ctx context.Context) *cobra.Command {
+	var kubeConfigFile string
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:   "kubeconfig",
+		Short: "Writes the credentials of your Okteto namespace into a kubeconfig file",
+		Long: `Writes the credentials of your Okteto namespace into a kubeconfig file
+
+By default, the credentials are merged into your current kubeconfig file. Use the '--output' flag to write them into a different file, so tools like kubectl or helm can target your Okteto namespace with:
+
+    $ okteto kubeconfig --output okteto.yml
+    $ KUBECONFIG=okteto.yml kubectl get pods
+`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#kubeconfig"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := login.WithEnvVarIfAvailable(ctx); err != nil {
+				return err
+			}
+
+			err := runKubeconfig(ctx, kubeConfigFile, namespace)
+			analytics.TrackKubeconfig(err == nil)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&kubeConfigFile, "output", "o", "", "path of the kubeconfig file to write (defaults to your current kubeconfig file)")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace of the kubeconfig context (defaults to your personal namespace)")
+	return cmd
+}
+
+func runKubeconfig(ctx context.Context, kubeConfigFile, namespace string) error {
+	if !okteto.IsAuthenticated() {
+		return errors.ErrNotLogged
+	}
+
+	cred, err := okteto.GetCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	if namespace == "" {
+		namespace = cred.Namespace
+	}
+
+	if kubeConfigFile == "" {
+		kubeConfigFile = config.GetKubeConfigFile()
+	}
+
+	clusterContext := okteto.GetClusterContext()
+	if err := okteto.SetKubeConfig(cred, kubeConfigFile, namespace, okteto.GetUserID(), clusterContext, true); err != nil {
+		return err
+	}
+
+	log.Success("Updated context '%s' in '%s'", clusterContext, kubeConfigFile)
+	return nil
+}
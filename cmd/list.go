@@ -15,19 +15,38 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/list"
 	"github.com/spf13/cobra"
 )
 
 // List lists resources
 func List(ctx context.Context) *cobra.Command {
+	var output string
+	var namespace string
+	var allNamespaces bool
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List resources",
+		Long:  "List resources. Without a subcommand, it lists the active development containers",
 		Args:  utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := list.ValidateOutput(output); err != nil {
+				return err
+			}
+			if namespace != "" && allNamespaces {
+				return fmt.Errorf("'--namespace' and '--all-namespaces' can't be used together")
+			}
+			return list.Run(os.Stdout, output, namespace, allNamespaces)
+		},
 	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. Use 'wide' to include namespace, pod and syncthing information")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "only list the development containers of this namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list the development containers of all namespaces, including a namespace column")
 	cmd.AddCommand(namespace.List(ctx))
 	return cmd
 }
@@ -29,6 +29,7 @@ import (
 // Login starts the login handshake with GitHub and okteto
 func Login() *cobra.Command {
 	token := ""
+	oktetoURL := ""
 	cmd := &cobra.Command{
 		Use:   "login [url]",
 		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#login"),
@@ -45,6 +46,7 @@ By default, this will log into cloud.okteto.com. If you want to log into your Ok
     $ okteto login https://okteto.example.com
 
 to log in to a Okteto Enterprise instance running at okteto.example.com.
+The URL can also be set with the '--url' flag or the OKTETO_URL environment variable.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -52,18 +54,30 @@ to log in to a Okteto Enterprise instance running at okteto.example.com.
 				return fmt.Errorf("this command is not supported without the '--token' flag from inside a pod")
 			}
 
-			oktetoURL := okteto.CloudURL
+			var err error
 			if len(args) > 0 {
-				u, err := utils.ParseURL(args[0])
+				argURL, err := okteto.GetOktetoURL(args[0])
 				if err != nil {
-					return fmt.Errorf("malformed login URL")
+					return err
+				}
+				if oktetoURL != "" {
+					flagURL, err := okteto.GetOktetoURL(oktetoURL)
+					if err != nil {
+						return err
+					}
+					if flagURL != argURL {
+						return fmt.Errorf("the login URL '%s' doesn't match the value of the '--url' flag '%s'", args[0], oktetoURL)
+					}
 				}
+				oktetoURL = argURL
+			}
 
-				oktetoURL = u
+			oktetoURL, err = okteto.GetOktetoURL(oktetoURL)
+			if err != nil {
+				return err
 			}
 
 			var u *okteto.User
-			var err error
 
 			if len(token) > 0 {
 				log.Infof("authenticating with an api token")
@@ -94,5 +108,6 @@ to log in to a Okteto Enterprise instance running at okteto.example.com.
 	}
 
 	cmd.Flags().StringVarP(&token, "token", "t", "", "API token for authentication.  (optional)")
+	cmd.Flags().StringVarP(&oktetoURL, "url", "u", "", "URL of your Okteto instance (optional)")
 	return cmd
 }
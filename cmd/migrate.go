@@ -0,0 +1,94 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// legacyBackupSuffix is appended to the path of a legacy manifest overwritten by 'okteto migrate'
+const legacyBackupSuffix = ".legacy"
+
+// Migrate converts a legacy cnd manifest into an okteto manifest
+func Migrate() *cobra.Command {
+	var devPath string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Converts a legacy manifest with 'swap' and 'mount' fields into an okteto manifest",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#migrate"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				output = devPath
+			}
+
+			warnings, err := migrateManifest(devPath, output)
+			if err != nil {
+				return err
+			}
+
+			for _, w := range warnings {
+				log.Yellow(w)
+			}
+			if output == devPath {
+				log.Information("Your legacy manifest was saved in '%s%s'", devPath, legacyBackupSuffix)
+			}
+			log.Success("Okteto manifest converted and saved in '%s'", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the legacy manifest file")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "path where the okteto manifest is written. It defaults to the path of the legacy manifest, which is saved with the '.legacy' suffix")
+	return cmd
+}
+
+// migrateManifest converts the legacy manifest in devPath and writes the result in output
+func migrateManifest(devPath, output string) ([]string, error) {
+	b, err := ioutil.ReadFile(devPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %s", devPath, err)
+	}
+
+	if !model.IsLegacyManifest(b) {
+		return nil, errors.UserError{
+			E:    fmt.Errorf("'%s' is not a legacy manifest", devPath),
+			Hint: "Only manifests with the legacy 'swap' and 'mount' fields can be converted",
+		}
+	}
+
+	dev, warnings, err := model.MigrateLegacyManifest(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == devPath {
+		if err := ioutil.WriteFile(devPath+legacyBackupSuffix, b, 0600); err != nil {
+			return nil, fmt.Errorf("failed to save a copy of your legacy manifest: %s", err)
+		}
+	}
+
+	if err := dev.Save(output); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
@@ -16,6 +16,7 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
@@ -116,18 +117,14 @@ func askIfLogin() bool {
 func askOktetoURL() (string, error) {
 	var oktetoURL string
 
-	fmt.Print(fmt.Sprintf("What is the URL of your Okteto instance? [%s]: ", okteto.CloudURL))
-	if _, err := fmt.Scanln(&oktetoURL); err != nil {
-		oktetoURL = okteto.CloudURL
-	}
-
-	u, err := utils.ParseURL(oktetoURL)
-	if err != nil {
-		return "", fmt.Errorf("malformed login URL")
+	if os.Getenv("OKTETO_URL") == "" {
+		fmt.Print(fmt.Sprintf("What is the URL of your Okteto instance? [%s]: ", okteto.CloudURL))
+		if _, err := fmt.Scanln(&oktetoURL); err != nil {
+			oktetoURL = okteto.CloudURL
+		}
 	}
-	oktetoURL = u
 
-	return oktetoURL, nil
+	return okteto.GetOktetoURL(oktetoURL)
 }
 
 func hasAccessToNamespace(ctx context.Context, namespace string) (bool, error) {
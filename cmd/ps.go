@@ -0,0 +1,98 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/ps"
+	"github.com/okteto/okteto/pkg/errors"
+	k8Client "github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// Ps lists the processes running in the development container
+func Ps() *cobra.Command {
+	var devPath string
+	var namespace string
+	var k8sContext string
+	cmd := &cobra.Command{
+		Use:   "ps [name]",
+		Short: "List the processes running in your development container",
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#ps"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			devName := ""
+			if len(args) > 0 {
+				devName = args[0]
+			}
+
+			if okteto.InDevContainer() {
+				return errors.ErrNotInDevContainer
+			}
+
+			dev, err := utils.LoadDev(devPath, devName, namespace, k8sContext)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			client, cfg, err := k8Client.GetLocalWithContext(dev.Context)
+			if err != nil {
+				return err
+			}
+
+			p, err := pods.GetDevPod(ctx, dev, client, true)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return errors.UserError{
+						E:    fmt.Errorf("Development container not found in namespace %s", dev.Namespace),
+						Hint: "Run 'okteto up' to launch it or use 'okteto namespace' to select the correct namespace and try again",
+					}
+				}
+				return err
+			}
+			if p == nil {
+				return errors.UserError{
+					E:    fmt.Errorf("development mode is not enabled"),
+					Hint: "Run 'okteto up' to enable it and try again",
+				}
+			}
+			if dev.Container == "" {
+				dev.Container = p.Spec.Containers[0].Name
+			}
+
+			processes, err := ps.Run(ctx, dev, p.Name, client, cfg)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+			fmt.Fprintf(w, "PID\tPPID\tUSER\tTIME\tCOMMAND\n")
+			for _, process := range processes {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", process.PID, process.PPID, process.User, process.Time, process.Command)
+			}
+			return w.Flush()
+		},
+	}
+	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the ps command is executed")
+	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the ps command is executed")
+	return cmd
+}
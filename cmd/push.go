@@ -44,6 +44,7 @@ func Push(ctx context.Context) *cobra.Command {
 	var autoDeploy bool
 	var progress string
 	var deploymentName string
+	var devName string
 	var noCache bool
 
 	cmd := &cobra.Command{
@@ -56,15 +57,11 @@ func Push(ctx context.Context) *cobra.Command {
 				return err
 			}
 
-			dev, err := utils.LoadDevOrDefault(devPath, deploymentName, namespace, k8sContext)
+			dev, err := loadPushDev(devPath, devName, deploymentName, namespace, k8sContext)
 			if err != nil {
 				return err
 			}
 
-			if len(deploymentName) > 0 && deploymentName != dev.Name {
-				return fmt.Errorf("deployment name provided does not match the name field in your okteto manifest")
-			}
-
 			c, _, err := k8Client.GetLocalWithContext(dev.Context)
 			if err != nil {
 				return err
@@ -115,10 +112,24 @@ func Push(ctx context.Context) *cobra.Command {
 	cmd.Flags().BoolVarP(&autoDeploy, "deploy", "d", false, "create deployment when it doesn't exist in a namespace")
 	cmd.Flags().StringVarP(&progress, "progress", "", "tty", "show plain/tty build output")
 	cmd.Flags().StringVar(&deploymentName, "name", "", "name of the deployment to push to")
+	cmd.Flags().StringVarP(&devName, "dev", "", "", "name of the development environment, if the manifest defines several ones")
 	cmd.Flags().BoolVarP(&noCache, "no-cache", "", false, "do not use cache when building the image")
 	return cmd
 }
 
+// loadPushDev loads the development environment devName, or a default one for deploymentName if there is no okteto manifest
+func loadPushDev(devPath, devName, deploymentName, namespace, k8sContext string) (*model.Dev, error) {
+	dev, err := utils.LoadDevOrDefault(devPath, devName, deploymentName, namespace, k8sContext)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deploymentName) > 0 && deploymentName != dev.Name {
+		return nil, fmt.Errorf("deployment name provided does not match the name field in your okteto manifest")
+	}
+	return dev, nil
+}
+
 func runPush(ctx context.Context, dev *model.Dev, autoDeploy bool, imageTag, oktetoRegistryURL, progress string, noCache bool, c *kubernetes.Clientset) error {
 	exists := true
 	d, err := deployments.Get(ctx, dev, dev.Namespace, c)
@@ -0,0 +1,83 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_loadPushDevSeveralDevs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	content := "dev:\n  api:\n    image: okteto/golang:1\n    namespace: n1\n    sync:\n      - .:/app\n  frontend:\n    image: okteto/node:14\n    namespace: n1\n    sync:\n      - .:/app\n"
+	if err := ioutil.WriteFile(manifest, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name           string
+		devName        string
+		deploymentName string
+		expected       string
+		expectErr      bool
+	}{
+		{
+			name:      "no-dev",
+			expectErr: true,
+		},
+		{
+			name:     "dev",
+			devName:  "frontend",
+			expected: "frontend",
+		},
+		{
+			name:           "dev-and-name",
+			devName:        "api",
+			deploymentName: "api",
+			expected:       "api",
+		},
+		{
+			name:           "name-mismatch",
+			devName:        "api",
+			deploymentName: "frontend",
+			expectErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := loadPushDev(manifest, tt.devName, tt.deploymentName, "", "")
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dev.Name != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, dev.Name)
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ func Restart() *cobra.Command {
 	var namespace string
 	var k8sContext string
 	var devPath string
+	var devName string
 
 	cmd := &cobra.Command{
 		Use:   "restart",
@@ -39,7 +40,7 @@ func Restart() *cobra.Command {
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#restart"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
-			dev, err := utils.LoadDev(devPath, namespace, k8sContext)
+			dev, err := utils.LoadDev(devPath, devName, namespace, k8sContext)
 			if err != nil {
 				return err
 			}
@@ -58,6 +59,7 @@ func Restart() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&devName, "dev", "", "", "name of the development environment, if the manifest defines several ones")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the restart command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the restart command is executed")
 
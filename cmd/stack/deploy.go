@@ -15,6 +15,8 @@ package stack
 
 import (
 	"context"
+	"os"
+	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
@@ -32,18 +34,28 @@ func Deploy(ctx context.Context) *cobra.Command {
 	var forceBuild bool
 	var wait bool
 	var noCache bool
+	var waitTimeout time.Duration
+	var dryRun bool
+	var variables []string
+	var varFile string
 
 	cmd := &cobra.Command{
 		Use:   "deploy",
 		Short: "Deploys a stack",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#deploy-1"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := login.WithEnvVarIfAvailable(ctx); err != nil {
-				return err
+			if !dryRun {
+				if err := login.WithEnvVarIfAvailable(ctx); err != nil {
+					return err
+				}
 			}
 
 			ctx := context.Background()
-			if err := utils.LoadEnvironment(ctx, true); err != nil {
+			if err := utils.LoadEnvironment(ctx, !dryRun); err != nil {
+				return err
+			}
+
+			if err := utils.LoadStackVariables(variables, varFile); err != nil {
 				return err
 			}
 
@@ -51,13 +63,18 @@ func Deploy(ctx context.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			analytics.TrackStackWarnings(s.Warnings.NotSupportedFields)
 
 			if err := s.UpdateNamespace(namespace); err != nil {
 				return err
 			}
 
-			err = stack.Deploy(ctx, s, forceBuild, wait, noCache)
+			if dryRun {
+				return stack.Render(ctx, s, os.Stdout)
+			}
+
+			analytics.TrackStackWarnings(s.Warnings.NotSupportedFields)
+
+			err = stack.Deploy(ctx, s, forceBuild, wait, noCache, waitTimeout)
 			analytics.TrackDeployStack(err == nil, s.IsCompose)
 			if err == nil {
 				log.Success("Stack '%s' successfully deployed", s.Name)
@@ -70,6 +87,10 @@ func Deploy(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "overwrites the stack namespace where the stack is deployed")
 	cmd.Flags().BoolVarP(&forceBuild, "build", "", false, "build images before starting any Stack service")
 	cmd.Flags().BoolVarP(&wait, "wait", "", false, "wait until a minimum number of containers are in a ready state for every service")
+	cmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "", 300*time.Second, "maximum time to wait for the services of the stack to be ready")
 	cmd.Flags().BoolVarP(&noCache, "no-cache", "", false, "do not use cache when building the image")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "print the resolved stack manifest without deploying it")
+	cmd.Flags().StringArrayVar(&variables, "var", []string{}, "set a stack variable (can be set more than once)")
+	cmd.Flags().StringVarP(&varFile, "var-file", "", "", "path to a file with the stack variables")
 	return cmd
 }
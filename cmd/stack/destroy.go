@@ -15,7 +15,10 @@ package stack
 
 import (
 	"context"
+	"fmt"
+	"os"
 
+	"github.com/moby/term"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/stack"
@@ -30,6 +33,7 @@ func Destroy(ctx context.Context) *cobra.Command {
 	var name string
 	var namespace string
 	var rm bool
+	var yes bool
 	cmd := &cobra.Command{
 		Use:   "destroy <name>",
 		Short: "Destroys a stack",
@@ -48,6 +52,18 @@ func Destroy(ctx context.Context) *cobra.Command {
 				return err
 			}
 
+			// the confirmation is skipped when stdin isn't a terminal, as in CI
+			if rm && !yes && term.IsTerminal(os.Stdin.Fd()) {
+				confirmed, err := utils.AskYesNo(fmt.Sprintf("The volumes of the stack '%s' will be permanently deleted. Do you want to continue? [y/n]: ", s.Name))
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					log.Information("Stack '%s' wasn't destroyed", s.Name)
+					return nil
+				}
+			}
+
 			to, err := model.GetTimeout()
 			if err != nil {
 				return err
@@ -65,5 +81,6 @@ func Destroy(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVarP(&name, "name", "", "", "overwrites the stack name")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "overwrites the stack namespace where the stack is destroyed")
 	cmd.Flags().BoolVarP(&rm, "volumes", "v", false, "remove persistent volumes")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "don't ask for confirmation before removing persistent volumes")
 	return cmd
 }
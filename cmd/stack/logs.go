@@ -0,0 +1,72 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"os"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/stack"
+	"github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// Logs prints the logs of the services of a stack
+func Logs(ctx context.Context) *cobra.Command {
+	var stackPath string
+	var namespace string
+	opts := &stack.LogsOptions{}
+	cmd := &cobra.Command{
+		Use:   "logs <name>",
+		Short: "Print the logs of the services of a stack",
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#logs-1"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+
+			s, err := utils.LoadStack(name, stackPath)
+			if err != nil {
+				if name == "" {
+					return err
+				}
+				log.Infof("error reading stack: %s", err.Error())
+				s = &model.Stack{Name: name}
+			}
+
+			if err := s.UpdateNamespace(namespace); err != nil {
+				return err
+			}
+			if s.Namespace == "" {
+				s.Namespace = client.GetContextNamespace("")
+			}
+
+			c, _, err := client.GetLocal()
+			if err != nil {
+				return err
+			}
+
+			return stack.Logs(ctx, s, opts, c, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&stackPath, "file", "f", utils.DefaultStackManifest, "path to the stack manifest file")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "overwrites the stack namespace where the logs are read from")
+	cmd.Flags().Int64VarP(&opts.Tail, "tail", "", -1, "number of recent log lines to show for each pod, -1 shows all the log lines")
+	cmd.Flags().BoolVarP(&opts.Follow, "follow", "", false, "stream the logs of the services of the stack, including the pods created after starting")
+	return cmd
+}
@@ -29,5 +29,6 @@ func Stack(ctx context.Context) *cobra.Command {
 	}
 	cmd.AddCommand(Deploy(ctx))
 	cmd.AddCommand(Destroy(ctx))
+	cmd.AddCommand(Logs(ctx))
 	return cmd
 }
@@ -15,6 +15,10 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
 	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
@@ -22,11 +26,14 @@ import (
 	"github.com/okteto/okteto/pkg/cmd/status"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
+	k8Client "github.com/okteto/okteto/pkg/k8s/client"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 // Status returns the status of the synchronization process
@@ -36,17 +43,30 @@ func Status() *cobra.Command {
 	var k8sContext string
 	var showInfo bool
 	var watch bool
+	var output string
 	cmd := &cobra.Command{
-		Use:   "status",
+		Use:   "status [name]",
 		Short: "Status of the synchronization process",
-		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#status"),
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#status"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			devName := ""
+			if len(args) > 0 {
+				devName = args[0]
+			}
 
 			if okteto.InDevContainer() {
 				return errors.ErrNotInDevContainer
 			}
 
-			dev, err := utils.LoadDev(devPath, namespace, k8sContext)
+			if output != "" && output != "json" {
+				return fmt.Errorf("'--output' must be 'json'")
+			}
+
+			if output != "" && (watch || showInfo) {
+				return fmt.Errorf("'--output' can't be used with '--watch' or '--info'")
+			}
+
+			dev, err := utils.LoadDev(devPath, devName, namespace, k8sContext)
 			if err != nil {
 				return err
 			}
@@ -63,8 +83,8 @@ func Status() *cobra.Command {
 				return errors.ErrNotInDevMode
 			}
 			if showInfo {
-				log.Information("Local syncthing url: http://%s", sy.GUIAddress)
-				log.Information("Remote syncthing url: http://%s", sy.RemoteGUIAddress)
+				log.Information("Local syncthing url: %s", syncthing.GetGUIURL(sy.GUIAddress))
+				log.Information("Remote syncthing url: %s", syncthing.GetGUIURL(sy.RemoteGUIAddress))
 				log.Information("Syncthing username: okteto")
 				log.Information("Syncthing password: %s", sy.GUIPassword)
 			}
@@ -72,7 +92,7 @@ func Status() *cobra.Command {
 			if watch {
 				err = runWithWatch(ctx, dev, sy)
 			} else {
-				err = runWithoutWatch(ctx, dev, sy)
+				err = runWithoutWatch(ctx, dev, sy, output)
 			}
 
 			analytics.TrackStatus(err == nil, showInfo)
@@ -83,44 +103,101 @@ func Status() *cobra.Command {
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the up command is executing")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the up command is executing")
 	cmd.Flags().BoolVarP(&showInfo, "info", "i", false, "show syncthing links for troubleshooting the synchronization service")
-	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "watch for changes")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "continuously display the synchronization, connection and pod status until interrupted")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. Use 'json' to print the status as a json object")
 	return cmd
 }
 
 func runWithWatch(ctx context.Context, dev *model.Dev, sy *syncthing.Syncthing) error {
-	suffix := "Synchronizing your files..."
-	spinner := utils.NewSpinner(suffix)
-	pbScaling := 0.30
-	spinner.Start()
-	defer spinner.Stop()
+	c, _, err := k8Client.GetLocalWithContext(dev.Context)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
 
+	screen := status.NewScreen(os.Stdout)
 	ticker := time.NewTicker(1000 * time.Millisecond)
+	defer ticker.Stop()
 	for {
-		<-ticker.C
-		message := ""
-		progress, err := status.Run(ctx, dev, sy)
+		info := status.GetInfo(ctx, dev, sy, c)
+		width, _, err := term.GetSize(int(os.Stdout.Fd()))
 		if err != nil {
-			log.Infof("error accessing status: %s", err)
-			continue
+			width = 0
 		}
-		if progress == 100 {
-			message = "Files synchronized"
-		} else {
-			message = utils.RenderProgressBar(suffix, progress, pbScaling)
+		screen.Draw(status.Render(info, width), width)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
 		}
-		spinner.Update(message)
 	}
 }
 
-func runWithoutWatch(ctx context.Context, dev *model.Dev, sy *syncthing.Syncthing) error {
-	progress, err := status.Run(ctx, dev, sy)
+// runWithoutWatch prints the status of the development container once.
+// It fails if the files are not synchronized or the development pod is not running
+func runWithoutWatch(ctx context.Context, dev *model.Dev, sy *syncthing.Syncthing, output string) error {
+	c, _, err := k8Client.GetLocalWithContext(dev.Context)
 	if err != nil {
 		return err
 	}
-	if progress == 100 {
-		log.Success("Synchronization status: %.2f%%", progress)
+
+	info := status.GetInfo(ctx, dev, sy, c)
+	if output == "json" {
+		b, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal the status: %s", err)
+		}
+		fmt.Println(string(b))
 	} else {
-		log.Yellow("Synchronization status: %.2f%%", progress)
+		printStatus(info)
+	}
+
+	if !info.Ready {
+		return errors.UserError{
+			E:    fmt.Errorf("your development container is not ready"),
+			Hint: "Run 'okteto status --watch' to follow its status",
+		}
 	}
 	return nil
 }
+
+func printStatus(info *status.Info) {
+	switch {
+	case !info.SyncAvailable:
+		log.Yellow("Synchronization status: unavailable")
+	case info.Progress == 100:
+		log.Success("Synchronization status: %.2f%%", info.Progress)
+	default:
+		log.Yellow("Synchronization status: %.2f%%", info.Progress)
+	}
+
+	switch {
+	case info.Pod == "":
+		log.Yellow("Pod: not found")
+	case info.PodPhase == string(apiv1.PodRunning):
+		log.Success("Pod: %s (%s)", info.Pod, info.PodPhase)
+	default:
+		log.Yellow("Pod: %s (%s)", info.Pod, info.PodPhase)
+	}
+
+	for _, f := range info.Forwards {
+		if f.Listening {
+			log.Success("Forward %d -> %d: listening", f.Local, f.Remote)
+		} else {
+			log.Yellow("Forward %d -> %d: not listening", f.Local, f.Remote)
+		}
+	}
+}
@@ -27,7 +27,6 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/diverts"
 	"github.com/okteto/okteto/pkg/k8s/ingressesv1"
 	"github.com/okteto/okteto/pkg/k8s/pods"
-	"github.com/okteto/okteto/pkg/k8s/secrets"
 	"github.com/okteto/okteto/pkg/k8s/services"
 	"github.com/okteto/okteto/pkg/k8s/volumes"
 	"github.com/okteto/okteto/pkg/log"
@@ -42,7 +41,7 @@ import (
 func (up *upContext) activate(autoDeploy, build bool) error {
 	log.Infof("activating development container retry=%t", up.isRetry)
 
-	if err := config.UpdateStateFile(up.Dev, config.Activating); err != nil {
+	if err := up.updateState(config.Activating); err != nil {
 		return err
 	}
 
@@ -58,6 +57,15 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 	up.CommandResult = make(chan error, 1)
 	up.cleaned = make(chan string, 1)
 	up.hardTerminate = make(chan error, 1)
+	up.waitFileStarted = false
+
+	if !up.isRetry {
+		hash, err := up.Dev.Hash()
+		if err != nil {
+			return err
+		}
+		up.devHash = hash
+	}
 
 	d, create, err := up.getCurrentDeployment(ctx, autoDeploy)
 	if err != nil {
@@ -80,12 +88,45 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 		}
 	}
 
+	if up.attachExisting {
+		if err := checkAttachExisting(d, create, up.devHash); err != nil {
+			return err
+		}
+	}
+
+	if !up.isRetry && !create {
+		if err := up.selectDevContainer(d); err != nil {
+			return err
+		}
+	}
+
+	if up.recreateOnChange && !up.attachExisting && !create && !up.isRetry {
+		recreate, err := recreateIfConfigChanged(ctx, d, up.devHash, up.Dev.Timeout.Resources, up.Client)
+		if err != nil {
+			return err
+		}
+		if recreate {
+			d = up.Dev.GevSandbox()
+			create = true
+		}
+	}
+
+	if up.recreateOnImage && !create && !up.isRetry && deployments.IsDevModeOn(d) {
+		up.recreateIfImageChanged(ctx)
+	}
+
+	if !up.isRetry && !create {
+		if err := up.checkImageRepository(d); err != nil {
+			return err
+		}
+	}
+
 	if _, err := registry.GetImageTagWithDigest(ctx, up.Dev.Namespace, up.Dev.Image.Name); err == errors.ErrNotFound {
 		log.Infof("image '%s' not found, building it: %s", up.Dev.Image.Name, err.Error())
 		build = true
 	}
 
-	if !up.isRetry && build {
+	if !up.isRetry && !up.attachExisting && build {
 		if err := up.buildDevImage(ctx, d, create); err != nil {
 			return fmt.Errorf("error building dev image: %s", err)
 		}
@@ -97,6 +138,9 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 		return err
 	}
 
+	up.activationTimer = startActivationTimer(up.waitTimeout, cancel)
+	defer up.activationTimer.stop()
+
 	if err := up.devMode(ctx, d, create); err != nil {
 		if errors.IsTransient(err) {
 			return err
@@ -110,6 +154,10 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 		return fmt.Errorf("couldn't activate your development container\n    %s", err.Error())
 	}
 
+	if !up.isRetry {
+		up.reportImageDigest(ctx)
+	}
+
 	up.isRetry = true
 
 	if err := up.forwards(ctx); err != nil {
@@ -124,19 +172,33 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 		}
 		return fmt.Errorf("couldn't connect to your development container: %s", err.Error())
 	}
+
+	if err := up.writeEnvFile(); err != nil {
+		return err
+	}
+
 	go up.cleanCommand(ctx)
 
 	if err := up.sync(ctx); err != nil {
 		if up.shouldRetry(ctx, err) {
+			if err := up.checkOOMKilled(ctx); err != nil {
+				return err
+			}
 			return errors.ErrLostSyncthing
 		}
 		return err
 	}
 
+	if !up.activationTimer.stop() {
+		return fmt.Errorf("activation cancelled after %s", up.waitTimeout)
+	}
+
 	up.success = true
+	up.connected = true
 	if up.isRetry {
 		analytics.TrackReconnect(true, up.isSwap)
 	}
+	up.notifyReconnected()
 
 	go func() {
 		output := <-up.cleaned
@@ -177,7 +239,14 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 				divertURL = i.Spec.Rules[0].Host
 			}
 		}
-		printDisplayContext(up.Dev, divertURL)
+		if err := up.waitUntilServicesAreReady(ctx); err != nil {
+			select {
+			case up.Disconnect <- err:
+			case <-ctx.Done():
+			}
+			return
+		}
+		printDisplayContext(up.Dev, up.getForwards(), divertURL)
 		if hook == "yes" {
 			log.Information("Running start.sh hook...")
 			if err := up.runCommand(ctx, []string{"/var/okteto/cloudbin/start.sh"}); err != nil {
@@ -185,11 +254,17 @@ func (up *upContext) activate(autoDeploy, build bool) error {
 				return
 			}
 		}
-		up.CommandResult <- up.runCommand(ctx, up.Dev.Command.Values)
+		up.CommandResult <- up.runCommand(ctx, up.Dev.GetCommand())
 	}()
 	prevError := up.waitUntilExitOrInterrupt()
 
 	if up.shouldRetry(ctx, prevError) {
+		if err := up.checkOOMKilled(ctx); err != nil {
+			return err
+		}
+		if up.nonInteractive {
+			return errors.ErrLostConnection
+		}
 		if !up.Dev.PersistentVolumeEnabled() {
 			if err := pods.Destroy(ctx, up.Pod.Name, up.Dev.Namespace, up.Client); err != nil {
 				return err
@@ -214,11 +289,32 @@ func (up *upContext) shouldRetry(ctx context.Context, err error) bool {
 	return false
 }
 
+// checkOOMKilled returns an error if the development container was OOMKilled, instead of reconnecting again and again
+func (up *upContext) checkOOMKilled(ctx context.Context) error {
+	if up.Pod == nil {
+		return nil
+	}
+
+	pod, err := up.Client.CoreV1().Pods(up.Dev.Namespace).Get(ctx, up.Pod.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Infof("failed to get pod '%s': %s", up.Pod.Name, err.Error())
+		return nil
+	}
+
+	return pods.CheckOOMKilled(pod, up.Dev.Container, pods.GetRestartCount(up.Pod, up.Dev.Container))
+}
+
 func (up *upContext) devMode(ctx context.Context, d *appsv1.Deployment, create bool) error {
 	if err := up.createDevContainer(ctx, d, create); err != nil {
 		return err
 	}
-	return up.waitUntilDevelopmentContainerIsRunning(ctx)
+	if err := up.waitUntilDevelopmentContainerIsRunning(ctx); err != nil {
+		if up.dumpPodSpecOnError {
+			up.dumpPodDiagnostics()
+		}
+		return err
+	}
+	return nil
 }
 
 func (up *upContext) createDevContainer(ctx context.Context, d *appsv1.Deployment, create bool) error {
@@ -226,10 +322,34 @@ func (up *upContext) createDevContainer(ctx context.Context, d *appsv1.Deploymen
 	spinner.Start()
 	defer spinner.Stop()
 
-	if err := config.UpdateStateFile(up.Dev, config.Starting); err != nil {
+	if err := up.updateState(config.Starting); err != nil {
+		return err
+	}
+
+	if up.attachExisting {
+		log.Info("attaching to the existing development container")
+		if initSyncErr := <-up.hardTerminate; initSyncErr != nil {
+			return initSyncErr
+		}
+	} else if err := up.deployDevContainer(ctx, d, create); err != nil {
+		return err
+	}
+
+	pod, err := pods.GetDevPodInLoop(ctx, up.Dev, up.Client, create)
+	if err != nil {
 		return err
 	}
 
+	up.Pod = pod
+	up.Sy.Pod = pod.Name
+	if err := up.Sy.SaveConfig(up.Dev); err != nil {
+		log.Infof("error saving syncthing object: %s", err)
+	}
+	return nil
+}
+
+// deployDevContainer translates the deployments of the okteto manifest to dev mode and deploys them
+func (up *upContext) deployDevContainer(ctx context.Context, d *appsv1.Deployment, create bool) error {
 	if up.Dev.PersistentVolumeEnabled() {
 		if err := volumes.CreateForDev(ctx, up.Dev, up.Client); err != nil {
 			return err
@@ -242,6 +362,10 @@ func (up *upContext) createDevContainer(ctx context.Context, d *appsv1.Deploymen
 		return err
 	}
 
+	if up.isOktetoNamespace {
+		expandOktetoDevImages(trList, up.Dev.Namespace, up.Dev.RegistryURL)
+	}
+
 	if err := deployments.TranslateDevMode(trList, up.Client, up.isOktetoNamespace); err != nil {
 		return err
 	}
@@ -252,11 +376,15 @@ func (up *upContext) createDevContainer(ctx context.Context, d *appsv1.Deploymen
 	}
 
 	log.Info("create deployment secrets")
-	if err := secrets.Create(ctx, up.Dev, up.Client, up.Sy); err != nil {
+	if err := createSecrets(ctx, up.Dev, up.Sy, up.recreateSecret, up.Client); err != nil {
 		return err
 	}
 
 	for name := range trList {
+		if name == d.Name {
+			up.annotateDevDeployment(trList[name].Deployment)
+		}
+
 		if name == d.Name && create {
 			if err := deployments.Create(ctx, trList[name].Deployment, up.Client); err != nil {
 				return err
@@ -277,18 +405,13 @@ func (up *upContext) createDevContainer(ctx context.Context, d *appsv1.Deploymen
 
 	}
 
+	up.recreateSecret = false
+
 	if create {
 		if err := services.CreateDev(ctx, up.Dev, up.Client); err != nil {
 			return err
 		}
 	}
-
-	pod, err := pods.GetDevPodInLoop(ctx, up.Dev, up.Client, create)
-	if err != nil {
-		return err
-	}
-
-	up.Pod = pod
 	return nil
 }
 
@@ -296,7 +419,7 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context)
 	msg := "Pulling images..."
 	if up.Dev.PersistentVolumeEnabled() {
 		msg = "Attaching persistent volume..."
-		if err := config.UpdateStateFile(up.Dev, config.Attaching); err != nil {
+		if err := up.updateState(config.Attaching); err != nil {
 			log.Infof("error updating state: %s", err.Error())
 		}
 	}
@@ -347,7 +470,7 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context)
 			optsWatchEvents.ResourceVersion = e.ResourceVersion
 			log.Infof("pod event: %s:%s", e.Reason, e.Message)
 			switch e.Reason {
-			case "Failed", "FailedScheduling", "FailedCreatePodSandBox", "ErrImageNeverPull", "InspectFailed", "FailedCreatePodContainer":
+			case "Failed", "FailedScheduling", "FailedCreatePodSandBox", "InspectFailed", "FailedCreatePodContainer":
 				if strings.Contains(e.Message, "pod has unbound immediate PersistentVolumeClaims") {
 					continue
 				}
@@ -356,6 +479,9 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context)
 					spinner.Update("Insufficient cpu/memory in the cluster. Waiting for new nodes to come up...")
 					continue
 				}
+				if err := pods.CheckMissingStartScriptMessage(e.Message); err != nil {
+					return err
+				}
 				return fmt.Errorf(e.Message)
 			case "SuccessfulAttachVolume":
 				spinner.Stop()
@@ -371,7 +497,7 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context)
 			case "Pulling":
 				message := getPullingMessage(e.Message, up.Dev.Namespace)
 				spinner.Update(fmt.Sprintf("%s...", message))
-				if err := config.UpdateStateFile(up.Dev, config.Pulling); err != nil {
+				if err := up.updateState(config.Pulling); err != nil {
 					log.Infof("error updating state: %s", err.Error())
 				}
 			}
@@ -386,6 +512,12 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context)
 				continue
 			}
 			log.Infof("dev pod %s is now %s", pod.Name, pod.Status.Phase)
+			if err := pods.CheckImagePullErrors(pod); err != nil {
+				return err
+			}
+			if err := pods.CheckMissingStartScript(pod, up.Dev.Container); err != nil {
+				return err
+			}
 			if pod.Status.Phase == apiv1.PodRunning {
 				spinner.Stop()
 				log.Success("Images successfully pulled")
@@ -409,3 +541,12 @@ func getPullingMessage(message, namespace string) string {
 	toReplace := fmt.Sprintf("%s/%s", registry, namespace)
 	return strings.Replace(message, toReplace, okteto.DevRegistry, 1)
 }
+
+// expandOktetoDevImages expands the okteto.dev shorthand of the images of the translation rules to the okteto registry of the namespace
+func expandOktetoDevImages(trList map[string]*model.Translation, namespace, oktetoRegistryURL string) {
+	for _, tr := range trList {
+		for _, rule := range tr.Rules {
+			rule.Image = registry.ExpandOktetoDevImage(rule.Image, namespace, oktetoRegistryURL)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// checkAttachExisting checks that the deployment is in dev mode with the same okteto manifest, so it can be reused without translating it again
+func checkAttachExisting(d *appsv1.Deployment, create bool, hash string) error {
+	if create || !deployments.IsDevModeOn(d) {
+		return errors.UserError{
+			E:    fmt.Errorf("'--attach-existing' requires your development container to be active"),
+			Hint: "Run 'okteto up' without '--attach-existing' to activate it",
+		}
+	}
+
+	if d.Annotations[model.OktetoDevHashAnnotation] != hash {
+		return errors.UserError{
+			E:    fmt.Errorf("your okteto manifest has changed since your development container was activated"),
+			Hint: "Run 'okteto up' without '--attach-existing' to apply your changes",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_checkAttachExisting(t *testing.T) {
+	var tests = []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		create      bool
+		expectErr   bool
+	}{
+		{
+			name:        "active-same-manifest",
+			labels:      map[string]string{model.DevLabel: "true"},
+			annotations: map[string]string{model.OktetoDevHashAnnotation: "hash"},
+			expectErr:   false,
+		},
+		{
+			name:        "active-changed-manifest",
+			labels:      map[string]string{model.DevLabel: "true"},
+			annotations: map[string]string{model.OktetoDevHashAnnotation: "old"},
+			expectErr:   true,
+		},
+		{
+			name:      "active-without-hash",
+			labels:    map[string]string{model.DevLabel: "true"},
+			expectErr: true,
+		},
+		{
+			name:        "not-active",
+			annotations: map[string]string{model.OktetoDevHashAnnotation: "hash"},
+			expectErr:   true,
+		},
+		{
+			name:      "not-deployed",
+			create:    true,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "web",
+					Labels:      tt.labels,
+					Annotations: tt.annotations,
+				},
+			}
+			err := checkAttachExisting(d, tt.create, "hash")
+			if tt.expectErr && err == nil {
+				t.Error("expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// maxClockSkew is the maximum difference between the local clock and the clock of the cluster before warning
+	maxClockSkew = 30 * time.Second
+
+	clockSkewTimeout = 5 * time.Second
+)
+
+// getClockSkew returns the difference between the local clock and the Date header of the API server.
+// A positive value means that the local clock is ahead of the clock of the cluster
+func getClockSkew(ctx context.Context, config *rest.Config, now func() time.Time) (time.Duration, error) {
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return 0, err
+	}
+
+	host := config.Host
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = fmt.Sprintf("https://%s", host)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clockSkewTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/version", strings.TrimSuffix(host, "/")), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	start := now()
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	end := now()
+
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return 0, fmt.Errorf("the API server didn't return the 'Date' header")
+	}
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return 0, fmt.Errorf("invalid 'Date' header '%s': %s", date, err)
+	}
+
+	local := start.Add(end.Sub(start) / 2)
+	return local.Sub(serverTime), nil
+}
+
+// clockSkewWarning returns the warning to display for the given skew, or an empty string if it is below maxClockSkew
+func clockSkewWarning(skew time.Duration) string {
+	direction := "ahead of"
+	if skew < 0 {
+		skew = -skew
+		direction = "behind"
+	}
+	if skew <= maxClockSkew {
+		return ""
+	}
+	return fmt.Sprintf("Your local clock is %s %s the clock of your cluster. This can cause authentication and synchronization errors. Sync your clock using NTP and try again", skew.Round(time.Second), direction)
+}
+
+// checkClockSkew warns if the local clock is not in sync with the clock of the cluster
+func (up *upContext) checkClockSkew(ctx context.Context) {
+	skew, err := getClockSkew(ctx, up.RestConfig, time.Now)
+	if err != nil {
+		log.Infof("failed to check the clock skew with your cluster: %s", err)
+		return
+	}
+
+	log.Infof("clock skew with your cluster: %s", skew)
+	if w := clockSkewWarning(skew); w != "" {
+		log.Yellow("%s", w)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func Test_getClockSkew(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		name         string
+		serverTime   time.Time
+		expectedSkew time.Duration
+		expectWarn   bool
+	}{
+		{
+			name:         "in-sync",
+			serverTime:   now,
+			expectedSkew: 0,
+			expectWarn:   false,
+		},
+		{
+			name:         "small-skew",
+			serverTime:   now.Add(10 * time.Second),
+			expectedSkew: -10 * time.Second,
+			expectWarn:   false,
+		},
+		{
+			name:         "local-behind",
+			serverTime:   now.Add(5 * time.Minute),
+			expectedSkew: -5 * time.Minute,
+			expectWarn:   true,
+		},
+		{
+			name:         "local-ahead",
+			serverTime:   now.Add(-2 * time.Minute),
+			expectedSkew: 2 * time.Minute,
+			expectWarn:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/version" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Date", tt.serverTime.Format(http.TimeFormat))
+				w.Write([]byte(`{"gitVersion": "v1.20.1"}`))
+			}))
+			defer server.Close()
+
+			skew, err := getClockSkew(context.Background(), &rest.Config{Host: server.URL}, func() time.Time { return now })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if skew != tt.expectedSkew {
+				t.Errorf("expected skew %s, got %s", tt.expectedSkew, skew)
+			}
+
+			w := clockSkewWarning(skew)
+			if tt.expectWarn && w == "" {
+				t.Error("didn't get the expected warning")
+			}
+			if !tt.expectWarn && w != "" {
+				t.Errorf("got an unexpected warning: %s", w)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/okteto/okteto/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// clusterInfoEnabled prints the cluster info before activating the development container
+	clusterInfoEnabled = "true"
+
+	// clusterInfoOnly prints the cluster info and exits
+	clusterInfoOnly = "only"
+)
+
+// clusterInfo represents the cluster where the development container is activated
+type clusterInfo struct {
+	Context   string
+	Host      string
+	Version   string
+	Namespace string
+	Nodes     int
+}
+
+// parseClusterInfoFlag validates the value of '--cluster-info'
+func parseClusterInfoFlag(value string) (string, error) {
+	switch value {
+	case "", "false":
+		return "", nil
+	case clusterInfoEnabled, clusterInfoOnly:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid value '%s' for '--cluster-info': must be 'true', 'false' or 'only'", value)
+	}
+}
+
+// getClusterInfo returns the info of the cluster. The node count is -1 if nodes can't be listed
+func getClusterInfo(ctx context.Context, c kubernetes.Interface, k8sContext, host, namespace string) (*clusterInfo, error) {
+	v, err := c.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the version of your cluster: %s", err)
+	}
+
+	info := &clusterInfo{
+		Context:   k8sContext,
+		Host:      host,
+		Version:   v.GitVersion,
+		Namespace: namespace,
+		Nodes:     -1,
+	}
+
+	nodes, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Infof("failed to list the nodes of your cluster: %s", err)
+		return info, nil
+	}
+	info.Nodes = len(nodes.Items)
+	return info, nil
+}
+
+func printClusterInfo(info *clusterInfo, w io.Writer) {
+	nodes := "unknown"
+	if info.Nodes >= 0 {
+		nodes = fmt.Sprintf("%d", info.Nodes)
+	}
+	fmt.Fprintf(w, "Context:    %s\n", info.Context)
+	fmt.Fprintf(w, "API server: %s\n", info.Host)
+	fmt.Fprintf(w, "Version:    %s\n", info.Version)
+	fmt.Fprintf(w, "Namespace:  %s\n", info.Namespace)
+	fmt.Fprintf(w, "Nodes:      %s\n", nodes)
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_getClusterInfo(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+	)
+	c.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.20.1"}
+
+	info, err := getClusterInfo(context.Background(), c, "dev-cluster", "https://1.2.3.4:6443", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := clusterInfo{
+		Context:   "dev-cluster",
+		Host:      "https://1.2.3.4:6443",
+		Version:   "v1.20.1",
+		Namespace: "test",
+		Nodes:     2,
+	}
+	if *info != expected {
+		t.Errorf("expected %+v, got %+v", expected, *info)
+	}
+
+	var out bytes.Buffer
+	printClusterInfo(info, &out)
+	if !strings.Contains(out.String(), "Version:    v1.20.1") || !strings.Contains(out.String(), "Nodes:      2") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func Test_parseClusterInfoFlag(t *testing.T) {
+	var tests = []struct {
+		value     string
+		expected  string
+		expectErr bool
+	}{
+		{value: "", expected: ""},
+		{value: "false", expected: ""},
+		{value: "true", expected: clusterInfoEnabled},
+		{value: "only", expected: clusterInfoOnly},
+		{value: "yes", expectErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseClusterInfoFlag(tt.value)
+		if tt.expectErr {
+			if err == nil {
+				t.Errorf("expected error for '%s'", tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for '%s': %s", tt.value, err)
+		}
+		if got != tt.expected {
+			t.Errorf("expected '%s' for '%s', got '%s'", tt.expected, tt.value, got)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+	"github.com/okteto/okteto/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// askForOption asks for one of the options in an interactive terminal
+var askForOption = func(label string, options []string) (string, error) {
+	prompt := promptui.Select{
+		Label: label,
+		Items: options,
+		Size:  len(options),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Selected: " ✓  {{ . | oktetoblue }}",
+			Active:   fmt.Sprintf("%s {{ . | oktetoblue }}", promptui.IconSelect),
+			Inactive: "  {{ . | oktetoblue }}",
+			FuncMap:  promptui.FuncMap,
+		},
+	}
+	prompt.Templates.FuncMap["oktetoblue"] = log.BlueString
+
+	i, _, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+	return options[i], nil
+}
+
+// selectDevContainer asks for the container to replace with the development container when the okteto manifest doesn't set 'container' and the deployment has several containers.
+// The selected container is kept in the manifest for the rest of the session
+func (up *upContext) selectDevContainer(d *appsv1.Deployment) error {
+	if !up.selectContainer || up.Dev.Container != "" || !up.isTerm || up.nonInteractive {
+		return nil
+	}
+
+	if len(d.Spec.Template.Spec.Containers) < 2 {
+		return nil
+	}
+
+	containers := []string{}
+	for i := range d.Spec.Template.Spec.Containers {
+		containers = append(containers, d.Spec.Template.Spec.Containers[i].Name)
+	}
+
+	container, err := askForOption(
+		fmt.Sprintf("The deployment '%s' has %d containers. Select the container you want to replace with your development container:", d.Name, len(containers)),
+		containers,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to select the development container: %s", err.Error())
+	}
+
+	log.Infof("selected container '%s' of deployment '%s'", container, d.Name)
+	up.Dev.Container = container
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func Test_selectDevContainer(t *testing.T) {
+	d := &appsv1.Deployment{}
+	d.Name = "api"
+	d.Spec.Template.Spec.Containers = []apiv1.Container{{Name: "proxy"}, {Name: "api"}, {Name: "metrics"}}
+
+	var tests = []struct {
+		name           string
+		up             *upContext
+		d              *appsv1.Deployment
+		expectedAsked  bool
+		expectedResult string
+	}{
+		{
+			name:           "multiple-containers",
+			up:             &upContext{Dev: &model.Dev{Name: "api"}, selectContainer: true, isTerm: true},
+			d:              d,
+			expectedAsked:  true,
+			expectedResult: "api",
+		},
+		{
+			name:           "container-set",
+			up:             &upContext{Dev: &model.Dev{Name: "api", Container: "metrics"}, selectContainer: true, isTerm: true},
+			d:              d,
+			expectedResult: "metrics",
+		},
+		{
+			name:           "no-tty",
+			up:             &upContext{Dev: &model.Dev{Name: "api"}, selectContainer: true},
+			d:              d,
+			expectedResult: "",
+		},
+		{
+			name:           "non-interactive",
+			up:             &upContext{Dev: &model.Dev{Name: "api"}, selectContainer: true, isTerm: true, nonInteractive: true},
+			d:              d,
+			expectedResult: "",
+		},
+		{
+			name:           "disabled",
+			up:             &upContext{Dev: &model.Dev{Name: "api"}, isTerm: true},
+			d:              d,
+			expectedResult: "",
+		},
+		{
+			name: "single-container",
+			up:   &upContext{Dev: &model.Dev{Name: "api"}, selectContainer: true, isTerm: true},
+			d: &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{
+				Containers: []apiv1.Container{{Name: "api"}},
+			}}}},
+			expectedResult: "",
+		},
+	}
+
+	defaultAskForOption := askForOption
+	defer func() { askForOption = defaultAskForOption }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asked := false
+			askForOption = func(label string, options []string) (string, error) {
+				asked = true
+				return options[1], nil
+			}
+
+			if err := tt.up.selectDevContainer(tt.d); err != nil {
+				t.Fatal(err)
+			}
+			if asked != tt.expectedAsked {
+				t.Errorf("expected asked %t, got %t", tt.expectedAsked, asked)
+			}
+			if tt.up.Dev.Container != tt.expectedResult {
+				t.Errorf("expected container '%s', got '%s'", tt.expectedResult, tt.up.Dev.Container)
+			}
+		})
+	}
+}
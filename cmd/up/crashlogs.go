@@ -0,0 +1,69 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultCrashLogsLines is the number of log lines printed by '--container-logs-on-crash' when no value is given
+	defaultCrashLogsLines = "50"
+
+	crashLogsTimeout = 10 * time.Second
+)
+
+// printCrashLogs prints the last lines of the logs of the development container after the command failed
+func (up *upContext) printCrashLogs(c kubernetes.Interface, w io.Writer) {
+	if up.crashLogsLines <= 0 || up.Pod == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), crashLogsTimeout)
+	defer cancel()
+
+	logs, err := getContainerLogs(ctx, up.Pod, up.Dev.Container, int64(up.crashLogsLines), c)
+	if err != nil {
+		log.Infof("failed to get the logs of the development container: %s", err)
+		log.Yellow("Couldn't get the logs of your development container")
+		return
+	}
+
+	log.Yellow("Last %d lines of the logs of your development container:", up.crashLogsLines)
+	fmt.Fprint(w, logs)
+}
+
+// getContainerLogs returns the last tail lines of the logs of a container of the pod
+func getContainerLogs(ctx context.Context, p *apiv1.Pod, container string, tail int64, c kubernetes.Interface) (string, error) {
+	if container == "" {
+		container = p.Spec.Containers[0].Name
+	}
+
+	logOpts := &apiv1.PodLogOptions{
+		Container: container,
+		TailLines: &tail,
+	}
+	b, err := c.CoreV1().Pods(p.Namespace).GetLogs(p.Name, logOpts).DoRaw(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_printCrashLogs(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "test"},
+		Spec:       apiv1.PodSpec{Containers: []apiv1.Container{{Name: "api"}}},
+	}
+	c := fake.NewSimpleClientset(pod)
+
+	var tests = []struct {
+		name           string
+		crashLogsLines int
+		expectLogs     bool
+	}{
+		{
+			name:           "enabled",
+			crashLogsLines: 50,
+			expectLogs:     true,
+		},
+		{
+			name:           "disabled",
+			crashLogsLines: 0,
+			expectLogs:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := &upContext{
+				Dev:            &model.Dev{Name: "api", Namespace: "test"},
+				Pod:            pod,
+				crashLogsLines: tt.crashLogsLines,
+			}
+
+			var b bytes.Buffer
+			up.printCrashLogs(c, &b)
+
+			// the fake clientset returns 'fake logs' as the logs of any pod
+			hasLogs := strings.Contains(b.String(), "fake logs")
+			if tt.expectLogs && !hasLogs {
+				t.Errorf("the logs of the development container weren't printed: %q", b.String())
+			}
+			if !tt.expectLogs && hasLogs {
+				t.Errorf("the logs of the development container were printed: %q", b.String())
+			}
+		})
+	}
+}
@@ -27,7 +27,7 @@ func downloadSyncthing() error {
 	var err error
 	for i := 0; i < 3; i++ {
 		p := &utils.ProgressBar{}
-		err = syncthing.Install(p)
+		err = syncthing.InstallWithLock(p)
 		if err == nil {
 			return nil
 		}
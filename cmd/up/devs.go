@@ -0,0 +1,45 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// selectDev returns the development environment 'name' of the okteto manifest.
+// If name is empty and the manifest defines several development environments, it asks for one in interactive terminals
+func selectDev(devs map[string]*model.Dev, name string, isTerm bool) (*model.Dev, error) {
+	names := model.GetDevNames(devs)
+	if name != "" || len(names) == 1 {
+		return utils.GetDevByName(devs, name)
+	}
+
+	if !isTerm {
+		return nil, errors.UserError{
+			E:    fmt.Errorf("your okteto manifest defines several development environments: %s", strings.Join(names, ", ")),
+			Hint: "Select one of them with 'okteto up <name>'",
+		}
+	}
+
+	selected, err := askForOption("Your okteto manifest defines several development environments. Select the one you want to activate:", names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select the development environment: %s", err.Error())
+	}
+	return devs[selected], nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_selectDev(t *testing.T) {
+	devs := map[string]*model.Dev{
+		"api":      {Name: "api"},
+		"frontend": {Name: "frontend"},
+		"worker":   {Name: "worker"},
+	}
+
+	var tests = []struct {
+		name      string
+		devs      map[string]*model.Dev
+		devName   string
+		isTerm    bool
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "by-name",
+			devs:     devs,
+			devName:  "worker",
+			expected: "worker",
+		},
+		{
+			name:      "unknown-name",
+			devs:      devs,
+			devName:   "db",
+			expectErr: true,
+		},
+		{
+			name:     "single-dev",
+			devs:     map[string]*model.Dev{"api": {Name: "api"}},
+			expected: "api",
+		},
+		{
+			name:     "prompt",
+			devs:     devs,
+			isTerm:   true,
+			expected: "frontend",
+		},
+		{
+			name:      "no-tty",
+			devs:      devs,
+			expectErr: true,
+		},
+	}
+
+	defaultAskForOption := askForOption
+	defer func() { askForOption = defaultAskForOption }()
+	askForOption = func(label string, options []string) (string, error) {
+		return options[1], nil
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := selectDev(tt.devs, tt.devName, tt.isTerm)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dev.Name != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, dev.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/k8s/events"
+	"github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	podDiagnosticsFile = "pod-diagnostics.json"
+
+	// maxDiagnosticsEvents is the number of recent events of the namespace included in the diagnostics
+	maxDiagnosticsEvents = 100
+
+	redactedValue = "<redacted>"
+)
+
+// podDiagnostics represents the state of the development pod when it fails to become ready
+type podDiagnostics struct {
+	Pod    *apiv1.Pod    `json:"pod"`
+	Events []apiv1.Event `json:"events"`
+}
+
+// dumpPodDiagnostics writes the spec of the development pod and the recent events of its namespace to the deployment home
+func (up *upContext) dumpPodDiagnostics() {
+	if up.Pod == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	d, err := getPodDiagnostics(ctx, up.Pod.Name, up.Dev.Namespace, up.Client)
+	if err != nil {
+		log.Infof("failed to get the pod diagnostics: %s", err)
+		return
+	}
+
+	path := filepath.Join(config.GetDeploymentHome(up.Dev.Namespace, up.Dev.Name), podDiagnosticsFile)
+	if err := d.save(path); err != nil {
+		log.Infof("failed to save the pod diagnostics: %s", err)
+		return
+	}
+	log.Yellow("The pod spec and the recent events of namespace '%s' have been saved to '%s'", up.Dev.Namespace, path)
+}
+
+func getPodDiagnostics(ctx context.Context, podName, namespace string, c kubernetes.Interface) (*podDiagnostics, error) {
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod '%s': %w", podName, err)
+	}
+
+	e, err := events.ListRecent(ctx, namespace, maxDiagnosticsEvents, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the events of namespace '%s': %w", namespace, err)
+	}
+
+	return &podDiagnostics{Pod: redactEnvValues(pod), Events: e}, nil
+}
+
+// redactEnvValues returns a copy of pod without the values of its environment variables, they might contain secrets
+func redactEnvValues(pod *apiv1.Pod) *apiv1.Pod {
+	pod = pod.DeepCopy()
+	for _, containers := range [][]apiv1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			for j := range containers[i].Env {
+				if containers[i].Env[j].Value != "" {
+					containers[i].Env[j].Value = redactedValue
+				}
+			}
+		}
+	}
+	return pod
+}
+
+func (d *podDiagnostics) save(path string) error {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
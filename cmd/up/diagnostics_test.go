@@ -0,0 +1,84 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_getPodDiagnostics(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-123", Namespace: "test"},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{
+					Name: "web",
+					Env: []apiv1.EnvVar{
+						{Name: "PASSWORD", Value: "secret"},
+						{Name: "TOKEN", ValueFrom: &apiv1.EnvVarSource{SecretKeyRef: &apiv1.SecretKeySelector{Key: "token"}}},
+					},
+				},
+			},
+		},
+	}
+	now := time.Now()
+	scheduling := &apiv1.Event{
+		ObjectMeta:    metav1.ObjectMeta{Name: "scheduling", Namespace: "test"},
+		Reason:        "FailedScheduling",
+		Message:       "0/1 nodes are available: 1 Insufficient memory",
+		LastTimestamp: metav1.NewTime(now),
+	}
+	pulling := &apiv1.Event{
+		ObjectMeta:    metav1.ObjectMeta{Name: "pulling", Namespace: "test"},
+		Reason:        "Pulling",
+		LastTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+	}
+	c := fake.NewSimpleClientset(pod, scheduling, pulling)
+
+	d, err := getPodDiagnostics(context.Background(), pod.Name, pod.Namespace, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Events) != 2 {
+		t.Fatalf("got %d events, expected 2", len(d.Events))
+	}
+	if d.Events[0].Name != "pulling" || d.Events[1].Name != "scheduling" {
+		t.Errorf("events are not sorted by timestamp: %s, %s", d.Events[0].Name, d.Events[1].Name)
+	}
+
+	env := d.Pod.Spec.Containers[0].Env
+	if env[0].Value != redactedValue {
+		t.Errorf("the value of '%s' wasn't redacted: %s", env[0].Name, env[0].Value)
+	}
+	if env[1].ValueFrom == nil {
+		t.Errorf("the secret reference of '%s' was removed", env[1].Name)
+	}
+	if pod.Spec.Containers[0].Env[0].Value != "secret" {
+		t.Errorf("the original pod was modified")
+	}
+}
+
+func Test_getPodDiagnosticsPodNotFound(t *testing.T) {
+	c := fake.NewSimpleClientset()
+	if _, err := getPodDiagnostics(context.Background(), "web-123", "test", c); err == nil {
+		t.Errorf("didn't get an error for a missing pod")
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/registry"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reportImageDigest prints the digest of the image running in the development container, and pins it in the manifest if '--pin-image' is set
+func (up *upContext) reportImageDigest(ctx context.Context) {
+	if !up.showImageDigest && !up.pinImage {
+		return
+	}
+
+	pod, err := up.Client.CoreV1().Pods(up.Dev.Namespace).Get(ctx, up.Pod.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Infof("failed to get pod '%s': %s", up.Pod.Name, err.Error())
+		log.Warning("Couldn't get the image digest of your development container")
+		return
+	}
+
+	digest, err := pods.GetImageDigest(pod, devContainerName(pod, up.Dev.Container))
+	if err != nil {
+		log.Infof("failed to get image digest: %s", err.Error())
+		log.Warning("Couldn't get the image digest of your development container")
+		return
+	}
+
+	image := pinnedImage(up.Dev.Image.Name, digest)
+	log.Information("Running image: %s", image)
+
+	if !up.pinImage {
+		return
+	}
+
+	if err := pinImageInManifest(up.devPath, up.Dev.Image.Name, image); err != nil {
+		log.Warning("Couldn't pin the image in your manifest: %s", err.Error())
+		return
+	}
+	log.Success("Image pinned to '%s' in '%s'", image, up.devPath)
+}
+
+// recreateIfImageChanged forces the pull of the image of the development container when its tag points to a new digest in the registry
+func (up *upContext) recreateIfImageChanged(ctx context.Context) {
+	if strings.Contains(up.Dev.Image.Name, "@") {
+		return
+	}
+
+	pod, err := pods.GetDevPod(ctx, up.Dev, up.Client, false)
+	if err != nil || pod == nil {
+		log.Infof("failed to get the development container to compare its image digest: %v", err)
+		return
+	}
+
+	podRepoDigest, err := pods.GetImageDigest(pod, devContainerName(pod, up.Dev.Container))
+	if err != nil {
+		log.Infof("failed to get the image digest of the development container: %s", err.Error())
+		podRepoDigest = ""
+	}
+
+	registryImage, err := registry.GetImageTagWithDigest(ctx, up.Dev.Namespace, up.Dev.Image.Name)
+	if err != nil {
+		log.Infof("failed to get the registry digest of '%s': %s", up.Dev.Image.Name, err.Error())
+		registryImage = ""
+	}
+
+	if !strings.Contains(registryImage, "@") || podRepoDigest == "" {
+		log.Yellow("Skipping '--recreate-if-image-changed': the digest of the image '%s' is not available", up.Dev.Image.Name)
+		return
+	}
+
+	if !imageDigestChanged(registryImage, podRepoDigest) {
+		log.Infof("the image '%s' of the development container is up to date", up.Dev.Image.Name)
+		return
+	}
+
+	log.Information("The image '%s' has changed in the registry, recreating your development container...", up.Dev.Image.Name)
+	up.Dev.LoadForcePull()
+}
+
+// imageDigestChanged returns true if the digest of the registry image is different from the repository digest running in the pod.
+// It returns false if the registry image doesn't include a digest, as it happens with registries other than the okteto registry,
+// or if the pod doesn't report a repository digest, as both digests must be manifest digests to be comparable
+func imageDigestChanged(registryImage, podRepoDigest string) bool {
+	i := strings.LastIndex(registryImage, "@")
+	if i < 0 || podRepoDigest == "" {
+		return false
+	}
+	return registryImage[i+1:] != podRepoDigest
+}
+
+// devContainerName returns the name of the development container of a pod, the first container if it isn't set in the manifest
+func devContainerName(pod *apiv1.Pod, container string) string {
+	if c := deployments.GetDevContainer(&pod.Spec, container); c != nil {
+		return c.Name
+	}
+	return container
+}
+
+// pinnedImage returns the reference of an image pinned to a digest
+func pinnedImage(image, digest string) string {
+	repository := image
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	} else if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	return fmt.Sprintf("%s@%s", repository, digest)
+}
+
+// pinImageInManifest replaces the value of the top level 'image' field of a manifest, preserving the rest of the file
+func pinImageInManifest(devPath, image, pinned string) error {
+	b, err := ioutil.ReadFile(devPath)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %s", devPath, err)
+	}
+
+	lines := strings.Split(string(b), "\n")
+	found := false
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "image:") {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(line, "image:"))
+		value = strings.Trim(value, `"'`)
+		if value != image {
+			return fmt.Errorf("the 'image' field of '%s' is not '%s'", devPath, image)
+		}
+		lines[i] = fmt.Sprintf("image: %s", pinned)
+		found = true
+		break
+	}
+
+	if !found {
+		return fmt.Errorf("the 'image' field is not defined in '%s'", devPath)
+	}
+
+	if err := ioutil.WriteFile(devPath, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+		return fmt.Errorf("failed to write '%s': %s", devPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func Test_pinnedImage(t *testing.T) {
+	var tests = []struct {
+		image    string
+		expected string
+	}{
+		{image: "okteto/golang", expected: "okteto/golang@sha256:123"},
+		{image: "okteto/golang:1", expected: "okteto/golang@sha256:123"},
+		{image: "localhost:5000/golang:1", expected: "localhost:5000/golang@sha256:123"},
+		{image: "localhost:5000/golang", expected: "localhost:5000/golang@sha256:123"},
+		{image: "okteto/golang@sha256:456", expected: "okteto/golang@sha256:123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := pinnedImage(tt.image, "sha256:123"); got != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_imageDigestChanged(t *testing.T) {
+	var tests = []struct {
+		name          string
+		registryImage string
+		podRepoDigest string
+		expected      bool
+	}{
+		{
+			name:          "same-digest",
+			registryImage: "cindy/api@sha256:123",
+			podRepoDigest: "sha256:123",
+			expected:      false,
+		},
+		{
+			name:          "new-digest",
+			registryImage: "cindy/api@sha256:456",
+			podRepoDigest: "sha256:123",
+			expected:      true,
+		},
+		{
+			name:          "registry-without-digest",
+			registryImage: "okteto/golang:1",
+			podRepoDigest: "sha256:123",
+			expected:      false,
+		},
+		{
+			name:          "pod-without-digest",
+			registryImage: "cindy/api@sha256:456",
+			podRepoDigest: "",
+			expected:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := imageDigestChanged(tt.registryImage, tt.podRepoDigest); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_pinImageInManifest(t *testing.T) {
+	file, err := ioutil.TempFile("", "okteto.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	manifest := "name: api\n# the dev image\nimage: \"okteto/golang:1\"\nsync:\n  - .:/app\nservices:\n  - name: worker\n    image: okteto/golang:1\n"
+	if err := ioutil.WriteFile(file.Name(), []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pinImageInManifest(file.Name(), "okteto/other:1", "okteto/other@sha256:123"); err == nil {
+		t.Errorf("expected error when the image doesn't match")
+	}
+
+	if err := pinImageInManifest(file.Name(), "okteto/golang:1", "okteto/golang@sha256:123"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "name: api\n# the dev image\nimage: okteto/golang@sha256:123\nsync:\n  - .:/app\nservices:\n  - name: worker\n    image: okteto/golang:1\n"
+	if string(b) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, string(b))
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// neverInherited are local variables that are only inherited when requested by their exact name
+var neverInherited = map[string]bool{
+	"OKTETO_TOKEN":                   true,
+	"OKTETO_URL":                     true,
+	"KUBECONFIG":                     true,
+	"SSH_AUTH_SOCK":                  true,
+	"AWS_SECRET_ACCESS_KEY":          true,
+	"AWS_SESSION_TOKEN":              true,
+	"GOOGLE_APPLICATION_CREDENTIALS": true,
+	"HOME":                           true,
+	"PATH":                           true,
+	"PWD":                            true,
+	"SHELL":                          true,
+	"USER":                           true,
+}
+
+// sensitiveNameParts are the parts of the names of local variables that are likely secrets.
+// Glob patterns never match them, they are only inherited when requested by their exact name
+var sensitiveNameParts = []string{"TOKEN", "SECRET", "PASSWORD", "KEY", "CREDENTIAL", "AUTH"}
+
+// isSensitiveEnvVar returns true if the local variable must not be inherited by a glob pattern
+func isSensitiveEnvVar(name string) bool {
+	if neverInherited[name] {
+		return true
+	}
+	upper := strings.ToUpper(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(upper, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// inheritEnvVars adds to the dev environment the local variables matching any of the patterns
+func inheritEnvVars(dev *model.Dev, patterns, environ []string) error {
+	local := map[string]string{}
+	for _, kv := range environ {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		local[parts[0]] = parts[1]
+	}
+
+	inherited := map[string]string{}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid value '%s' for '--inherit-env': %s", pattern, err.Error())
+		}
+		if !strings.ContainsAny(pattern, "*?[") {
+			if value, ok := local[pattern]; ok {
+				inherited[pattern] = value
+			} else {
+				log.Infof("local environment variable '%s' is not defined", pattern)
+			}
+			continue
+		}
+		for name, value := range local {
+			if isSensitiveEnvVar(name) {
+				continue
+			}
+			if ok, _ := path.Match(pattern, name); ok {
+				inherited[name] = value
+			}
+		}
+	}
+
+	names := make([]string, 0, len(inherited))
+	for name := range inherited {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dev.SetLocalEnvVar(name, inherited[name])
+		log.Infof("inherited local environment variable '%s'", name)
+	}
+	return nil
+}
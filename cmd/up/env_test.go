@@ -0,0 +1,114 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_inheritEnvVars(t *testing.T) {
+	environ := []string{
+		"AWS_PROFILE=dev",
+		"AWS_REGION=eu-west-1",
+		"AWS_SECRET_ACCESS_KEY=secret",
+		"GITHUB_TOKEN=token",
+		"NPM_TOKEN=npm",
+		"DB_PASSWORD=password",
+		"DOCKER_AUTH_CONFIG={}",
+		"GOOGLE_CREDENTIALS=google",
+		"OKTETO_TOKEN=okteto",
+		"EMPTY=",
+		"PATH=/usr/bin",
+	}
+	var tests = []struct {
+		name        string
+		environment model.Environment
+		patterns    []string
+		expected    model.Environment
+		expectErr   bool
+	}{
+		{
+			name:     "glob",
+			patterns: []string{"AWS_*"},
+			expected: model.Environment{
+				{Name: "AWS_PROFILE", Value: "dev"},
+				{Name: "AWS_REGION", Value: "eu-west-1"},
+			},
+		},
+		{
+			name:     "exact-names",
+			patterns: []string{"GITHUB_TOKEN", "EMPTY", "UNDEFINED"},
+			expected: model.Environment{
+				{Name: "EMPTY", Value: ""},
+				{Name: "GITHUB_TOKEN", Value: "token"},
+			},
+		},
+		{
+			name:     "sensitive-by-name",
+			patterns: []string{"AWS_SECRET_ACCESS_KEY", "DB_PASSWORD"},
+			expected: model.Environment{
+				{Name: "AWS_SECRET_ACCESS_KEY", Value: "secret"},
+				{Name: "DB_PASSWORD", Value: "password"},
+			},
+		},
+		{
+			name:     "glob-skips-sensitive",
+			patterns: []string{"*_TOKEN", "DB_*", "DOCKER_*", "GOOGLE_*"},
+		},
+		{
+			name:     "wildcard-skips-sensitive",
+			patterns: []string{"*"},
+			expected: model.Environment{
+				{Name: "AWS_PROFILE", Value: "dev"},
+				{Name: "AWS_REGION", Value: "eu-west-1"},
+				{Name: "EMPTY", Value: ""},
+			},
+		},
+		{
+			name:        "overrides-manifest",
+			environment: model.Environment{{Name: "AWS_PROFILE", Value: "prod"}, {Name: "FOO", Value: "bar"}},
+			patterns:    []string{"AWS_PROF*"},
+			expected: model.Environment{
+				{Name: "AWS_PROFILE", Value: "dev"},
+				{Name: "FOO", Value: "bar"},
+			},
+		},
+		{
+			name:      "bad-pattern",
+			patterns:  []string{"AWS_["},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{Environment: tt.environment}
+			err := inheritEnvVars(dev, tt.patterns, environ)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dev.Environment, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, dev.Environment)
+			}
+		})
+	}
+}
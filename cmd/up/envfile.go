@@ -0,0 +1,88 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+var invalidEnvNameChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// getForwardsEnv returns the dotenv content mapping the forwards to their local URLs, like 'API_URL=http://localhost:8080'.
+// Forwards are named after their service, or after the development container if they don't have a service.
+// If a name has several forwards, the remote port is added to the name of all but the first one
+func getForwardsEnv(dev *model.Dev, forwards []model.Forward) string {
+	var b strings.Builder
+	names := map[string]bool{}
+	for _, f := range forwards {
+		name := dev.Name
+		if f.ServiceName != "" {
+			name = f.ServiceName
+		}
+		name = invalidEnvNameChars.ReplaceAllString(strings.ToUpper(name), "_")
+
+		key := fmt.Sprintf("%s_URL", name)
+		if names[key] {
+			key = fmt.Sprintf("%s_%d_URL", name, f.Remote)
+		}
+		names[key] = true
+
+		fmt.Fprintf(&b, "%s=http://%s\n", key, f.GetDialAddress(dev.Interface))
+	}
+	return b.String()
+}
+
+// checkEnvFile returns an error if path exists and wasn't written by 'okteto up --write-env', to not overwrite user files
+func checkEnvFile(path string) error {
+	written, err := model.IsWriteEnvFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read '%s': %s", path, err)
+	}
+	if !written {
+		return errors.UserError{
+			E:    fmt.Errorf("'%s' already exists and wasn't written by 'okteto up --write-env'", path),
+			Hint: "Choose another path for '--write-env' or remove the file",
+		}
+	}
+	return nil
+}
+
+// writeEnvFile writes the dotenv file of '--write-env' with the current forwards
+func (up *upContext) writeEnvFile() error {
+	if up.writeEnv == "" {
+		return nil
+	}
+
+	if err := checkEnvFile(up.writeEnv); err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("# %s\n%s", model.WriteEnvMarker, getForwardsEnv(up.Dev, up.getForwards()))
+	if err := ioutil.WriteFile(up.writeEnv, []byte(content), 0600); err != nil {
+		return fmt.Errorf("failed to write '%s': %s", up.writeEnv, err)
+	}
+	log.Infof("forwarded endpoints written to '%s'", up.writeEnv)
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_getForwardsEnv(t *testing.T) {
+	var tests = []struct {
+		name     string
+		dev      *model.Dev
+		expected string
+	}{
+		{
+			name:     "no-forwards",
+			dev:      &model.Dev{Name: "api"},
+			expected: "",
+		},
+		{
+			name: "dev-container-forward",
+			dev: &model.Dev{
+				Name:    "api",
+				Forward: []model.Forward{{Local: 8080, Remote: 8080}},
+			},
+			expected: "API_URL=http://localhost:8080\n",
+		},
+		{
+			name: "service-forwards",
+			dev: &model.Dev{
+				Name: "my-api",
+				Forward: []model.Forward{
+					{Local: 8080, Remote: 8080},
+					{Local: 9229, Remote: 9229},
+					{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+					{Local: 6379, Remote: 6379, Service: true, ServiceName: "redis.cache"},
+				},
+			},
+			expected: "MY_API_URL=http://localhost:8080\nMY_API_9229_URL=http://localhost:9229\nDB_URL=http://localhost:5432\nREDIS_CACHE_URL=http://localhost:6379\n",
+		},
+		{
+			name: "interface",
+			dev: &model.Dev{
+				Name:      "api",
+				Interface: "192.168.1.10",
+				Forward:   []model.Forward{{Local: 8080, Remote: 80}},
+			},
+			expected: "API_URL=http://192.168.1.10:8080\n",
+		},
+		{
+			name: "all-interfaces",
+			dev: &model.Dev{
+				Name:      "api",
+				Interface: "0.0.0.0",
+				Forward:   []model.Forward{{Local: 8080, Remote: 80}},
+			},
+			expected: "API_URL=http://localhost:8080\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getForwardsEnv(tt.dev, tt.dev.Forward); got != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_writeEnvFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	up := &upContext{
+		Dev:      &model.Dev{Name: "api", Forward: []model.Forward{{Local: 8080, Remote: 8080}}},
+		writeEnv: filepath.Join(dir, ".env"),
+	}
+	if err := up.writeEnvFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	up.serviceForwards = []model.Forward{{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"}}
+	if err := up.writeEnvFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(up.writeEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "# " + model.WriteEnvMarker + "\nAPI_URL=http://localhost:8080\nDB_URL=http://localhost:5432\n"
+	if string(b) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, string(b))
+	}
+}
+
+func Test_writeEnvFileKeepsUserFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".env")
+	if err := ioutil.WriteFile(path, []byte("SECRET=value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	up := &upContext{
+		Dev:      &model.Dev{Name: "api", Forward: []model.Forward{{Local: 8080, Remote: 8080}}},
+		writeEnv: path,
+	}
+	if err := up.writeEnvFile(); err == nil {
+		t.Fatal("overwriting a user file didn't fail")
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "SECRET=value\n" {
+		t.Errorf("the user file was modified: %s", string(b))
+	}
+}
@@ -17,7 +17,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	osexec "os/exec"
+	"runtime"
 	"strings"
 
 	"github.com/okteto/okteto/pkg/config"
@@ -26,6 +29,7 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/pods"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/ssh"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (up *upContext) cleanCommand(ctx context.Context) {
@@ -57,12 +61,23 @@ func (up *upContext) cleanCommand(ctx context.Context) {
 
 func (up *upContext) runCommand(ctx context.Context, cmd []string) error {
 	log.Infof("starting remote command")
-	if err := config.UpdateStateFile(up.Dev, config.Ready); err != nil {
-		return err
+	if up.waitFile == "" {
+		if err := up.setReady(ctx); err != nil {
+			return err
+		}
+	} else if !up.waitFileStarted {
+		up.waitFileStarted = true
+		go up.waitForReadyFile(ctx)
+	}
+
+	tty := !up.nonInteractive
+	var stdin io.Reader = os.Stdin
+	if up.nonInteractive {
+		stdin = strings.NewReader("")
 	}
 
 	if up.Dev.RemoteModeEnabled() {
-		return ssh.Exec(ctx, up.Dev.Interface, up.Dev.RemotePort, true, os.Stdin, os.Stdout, os.Stderr, cmd)
+		return ssh.Exec(ctx, up.Dev.Interface, up.Dev.RemotePort, tty, stdin, os.Stdout, os.Stderr, cmd)
 	}
 
 	return exec.Exec(
@@ -72,15 +87,111 @@ func (up *upContext) runCommand(ctx context.Context, cmd []string) error {
 		up.Dev.Namespace,
 		up.Pod.Name,
 		up.Dev.Container,
-		true,
-		os.Stdin,
+		tty,
+		stdin,
 		os.Stdout,
 		os.Stderr,
 		cmd,
 	)
 }
 
+func (up *upContext) setReady(ctx context.Context) error {
+	if err := up.updateState(config.Ready); err != nil {
+		return err
+	}
+
+	up.runPostReadyHook(ctx)
+	return nil
+}
+
+// runPostReadyHook runs the local command of the '--post-ready' flag the first time the development container is ready.
+// It runs in the background, so it doesn't delay the session, and it is stopped if the connection to the development container is lost
+func (up *upContext) runPostReadyHook(ctx context.Context) {
+	if up.postReady == "" || up.postReadyExecuted {
+		return
+	}
+	up.postReadyExecuted = true
+
+	up.hooks.Add(1)
+	go func() {
+		defer up.hooks.Done()
+		log.Infof("running post-ready command: %s", up.postReady)
+		if err := runLocalCommand(ctx, up.postReady, up.postReadyDir); err != nil {
+			log.Warning("The post-ready command failed: %s", err.Error())
+		}
+	}()
+}
+
+// notifyDisconnected runs the local command of the '--reconnect-notify' flag when the connection to the development container is lost
+func (up *upContext) notifyDisconnected() {
+	if up.reconnecting {
+		return
+	}
+	up.reconnecting = true
+	up.runReconnectNotifyHook(reconnectEventDisconnected)
+}
+
+// notifyReconnected runs the local command of the '--reconnect-notify' flag when the connection to the development container is restored
+func (up *upContext) notifyReconnected() {
+	if !up.reconnecting {
+		return
+	}
+	up.reconnecting = false
+	up.runReconnectNotifyHook(reconnectEventReconnected)
+}
+
+// runReconnectNotifyHook runs the local command of the '--reconnect-notify' flag in the background, so it doesn't delay the reconnection.
+// Each command is bounded by reconnectNotifyTimeout and starts after the previous one finishes, to notify the events in order
+func (up *upContext) runReconnectNotifyHook(event string) {
+	if up.reconnectNotify == "" {
+		return
+	}
+
+	command := fmt.Sprintf("%s %s", up.reconnectNotify, event)
+	previous := up.lastReconnectHook
+	done := make(chan struct{})
+	up.lastReconnectHook = done
+
+	up.hooks.Add(1)
+	go func() {
+		defer up.hooks.Done()
+		defer close(done)
+		if previous != nil {
+			<-previous
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), reconnectNotifyTimeout)
+		defer cancel()
+		log.Infof("running reconnect-notify command: %s", command)
+		if err := runLocalCommand(ctx, command, up.reconnectNotifyDir); err != nil {
+			log.Warning("The reconnect-notify command failed: %s", err.Error())
+		}
+	}()
+}
+
+func runLocalCommand(ctx context.Context, command, dir string) error {
+	var cmd *osexec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = osexec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = osexec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 func (up *upContext) checkOktetoStartError(ctx context.Context, msg string) error {
+	if up.Pod != nil {
+		pod, err := up.Client.CoreV1().Pods(up.Dev.Namespace).Get(ctx, up.Pod.Name, metav1.GetOptions{})
+		if err != nil {
+			log.Infof("failed to get pod '%s': %s", up.Pod.Name, err.Error())
+		} else if err := pods.CheckMissingStartScript(pod, up.Dev.Container); err != nil {
+			return err
+		}
+	}
+
 	userID := pods.GetDevPodUserID(ctx, up.Dev, up.Client)
 	if up.Dev.PersistentVolumeEnabled() {
 		if userID != -1 && userID != *up.Dev.SecurityContext.RunAsUser {
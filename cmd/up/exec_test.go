@@ -0,0 +1,143 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_setReadyRunsPostReadyHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test hook uses a posix shell")
+	}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	defer os.Unsetenv("OKTETO_FOLDER")
+
+	dev := &model.Dev{Name: "test", Namespace: "namespace"}
+	stateFile := filepath.Join(config.GetDeploymentHome(dev.Namespace, dev.Name), config.StateFile)
+	up := &upContext{
+		Dev:          dev,
+		postReady:    fmt.Sprintf("cat %s >> hook.out", stateFile),
+		postReadyDir: dir,
+	}
+
+	if err := up.setReady(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := up.setReady(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	up.hooks.Wait()
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "hook.out"))
+	if err != nil {
+		t.Fatalf("the post-ready hook didn't run: %s", err)
+	}
+
+	if string(b) != string(config.Ready) {
+		t.Errorf("expected the post-ready hook to run once after the ready state, got '%s'", string(b))
+	}
+}
+
+func Test_runPostReadyHookFailureIsNotFatal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test hook uses a posix shell")
+	}
+
+	up := &upContext{
+		postReady: "exit 1",
+	}
+	up.runPostReadyHook(context.Background())
+	up.hooks.Wait()
+	if !up.postReadyExecuted {
+		t.Errorf("the post-ready hook wasn't executed")
+	}
+}
+
+func Test_reconnectNotifyHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test hook uses a posix shell")
+	}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	up := &upContext{
+		ShutdownCompleted:  make(chan bool, 1),
+		reconnectNotify:    `sh -c 'echo $0 >> hook.out'`,
+		reconnectNotifyDir: dir,
+	}
+
+	calls := 0
+	err = up.retryActivate(func() error {
+		calls++
+		up.isRetry = true
+		up.ShutdownCompleted <- true
+		if calls <= 2 {
+			return errors.ErrLostSyncthing
+		}
+		up.notifyReconnected()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	up.hooks.Wait()
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "hook.out"))
+	if err != nil {
+		t.Fatalf("the reconnect-notify hook didn't run: %s", err)
+	}
+
+	expected := fmt.Sprintf("%s\n%s\n", reconnectEventDisconnected, reconnectEventReconnected)
+	if string(b) != expected {
+		t.Errorf("expected %q, got %q", expected, string(b))
+	}
+}
+
+func Test_reconnectNotifyHookFailureIsNotFatal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test hook uses a posix shell")
+	}
+
+	up := &upContext{
+		reconnectNotify: "exit 1",
+	}
+	up.notifyDisconnected()
+	up.hooks.Wait()
+	if !up.reconnecting {
+		t.Errorf("the disconnection wasn't recorded")
+	}
+}
@@ -16,13 +16,17 @@ package up
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/forward"
+	"github.com/okteto/okteto/pkg/k8s/services"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/ssh"
-	"github.com/okteto/okteto/pkg/syncthing"
+	"k8s.io/client-go/kubernetes"
 )
 
 func (up *upContext) forwards(ctx context.Context) error {
@@ -51,11 +55,17 @@ func (up *upContext) forwards(ctx context.Context) error {
 		}
 	}
 
-	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
+	for _, f := range up.serviceForwards {
+		if err := up.Forwarder.Add(f); err != nil {
+			return err
+		}
+	}
+
+	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemotePort, Remote: model.SyncthingClusterPort}); err != nil {
 		return err
 	}
 
-	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemoteGUIPort, Remote: syncthing.GUIPort}); err != nil {
+	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemoteGUIPort, Remote: model.SyncthingGUIPort}); err != nil {
 		return err
 	}
 
@@ -71,11 +81,11 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 
 	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Dev.Namespace)
 
-	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
+	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemotePort, Remote: model.SyncthingClusterPort}); err != nil {
 		return err
 	}
 
-	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemoteGUIPort, Remote: syncthing.GUIPort}); err != nil {
+	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemoteGUIPort, Remote: model.SyncthingGUIPort}); err != nil {
 		return err
 	}
 
@@ -93,6 +103,12 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 		}
 	}
 
+	for _, f := range up.serviceForwards {
+		if err := up.Forwarder.Add(f); err != nil {
+			return err
+		}
+	}
+
 	for _, r := range up.Dev.Reverse {
 		if err := up.Forwarder.AddReverse(r); err != nil {
 			return err
@@ -104,5 +120,118 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 		return fmt.Errorf("failed to add entry to your SSH config file")
 	}
 
+	if up.printSSHConfig && !up.isRetry {
+		fmt.Print(ssh.GetEntry(up.Dev.Name, up.Dev.Interface, up.Dev.RemotePort))
+	}
+
 	return up.Forwarder.Start(up.Pod.Name, up.Dev.Namespace)
 }
+
+// parseServiceForwards parses the values of '--forward-service', like 'name:localPort[:remotePort]'.
+// The remote port is zero when it isn't set, and it is resolved from the service by resolveServiceForwards
+func parseServiceForwards(values []string) ([]model.Forward, error) {
+	result := []model.Forward{}
+	for _, v := range values {
+		parts := strings.Split(v, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid value '%s' for '--forward-service': must be 'name:localPort' or 'name:localPort:remotePort'", v)
+		}
+
+		f := model.Forward{Service: true, ServiceName: parts[0]}
+		local, err := strconv.Atoi(parts[1])
+		if err != nil || local < 1 || local > 65535 {
+			return nil, fmt.Errorf("invalid local port '%s' in '--forward-service %s'", parts[1], v)
+		}
+		f.Local = local
+
+		if len(parts) == 3 {
+			remote, err := strconv.Atoi(parts[2])
+			if err != nil || remote < 1 || remote > 65535 {
+				return nil, fmt.Errorf("invalid remote port '%s' in '--forward-service %s'", parts[2], v)
+			}
+			f.Remote = remote
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// resolveServiceForwards validates that the services of the forwards exist in the namespace.
+// Forwards without remote port use the first port of their service
+func resolveServiceForwards(ctx context.Context, forwards []model.Forward, namespace string, c kubernetes.Interface) ([]model.Forward, error) {
+	result := []model.Forward{}
+	for _, f := range forwards {
+		svc, err := services.Get(ctx, f.ServiceName, namespace, c)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, errors.UserError{
+					E:    fmt.Errorf("service '%s' of '--forward-service' not found in namespace '%s'", f.ServiceName, namespace),
+					Hint: "Run 'kubectl get services' to list the services of the namespace and try again",
+				}
+			}
+			return nil, fmt.Errorf("failed to get service '%s': %s", f.ServiceName, err)
+		}
+
+		if f.Remote == 0 {
+			if len(svc.Spec.Ports) == 0 {
+				return nil, fmt.Errorf("service '%s' of '--forward-service' doesn't have ports", f.ServiceName)
+			}
+			f.Remote = int(svc.Spec.Ports[0].Port)
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// checkServiceForwards returns an error if the local port of a forward of '--forward-service' is already used
+// by a forward of the okteto manifest, by the remote port of the development container or by another '--forward-service'
+func checkServiceForwards(forwards []model.Forward, dev *model.Dev) error {
+	used := map[int]string{}
+	for _, f := range dev.Forward {
+		used[f.Local] = "a forward of your okteto manifest"
+	}
+	if dev.RemotePort > 0 {
+		used[dev.RemotePort] = "the remote port of your development container"
+	}
+
+	for _, f := range forwards {
+		if owner, ok := used[f.Local]; ok {
+			return errors.UserError{
+				E:    fmt.Errorf("local port %d of '--forward-service %s' is already used by %s", f.Local, f.ServiceName, owner),
+				Hint: "Use a different local port for '--forward-service'",
+			}
+		}
+		used[f.Local] = fmt.Sprintf("'--forward-service %s'", f.ServiceName)
+	}
+	return nil
+}
+
+// addServiceForwards resolves the forwards of '--forward-service' for this session.
+// They are kept apart from the forwards of the development container, as they are not part of the okteto manifest
+func (up *upContext) addServiceForwards(ctx context.Context) error {
+	if len(up.serviceForwards) == 0 {
+		return nil
+	}
+
+	if err := checkServiceForwards(up.serviceForwards, up.Dev); err != nil {
+		return err
+	}
+
+	forwards, err := resolveServiceForwards(ctx, up.serviceForwards, up.Dev.Namespace, up.Client)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range forwards {
+		log.Infof("adding forward to service '%s': %s", f.ServiceName, f.String())
+	}
+	up.serviceForwards = forwards
+	return nil
+}
+
+// getForwards returns the forwards of the development container followed by the forwards of '--forward-service'
+func (up *upContext) getForwards() []model.Forward {
+	forwards := []model.Forward{}
+	forwards = append(forwards, up.Dev.Forward...)
+	return append(forwards, up.serviceForwards...)
+}
@@ -0,0 +1,194 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_parseServiceForwards(t *testing.T) {
+	var tests = []struct {
+		name      string
+		values    []string
+		expected  []model.Forward
+		expectErr bool
+	}{
+		{
+			name:     "empty",
+			values:   []string{},
+			expected: []model.Forward{},
+		},
+		{
+			name:     "local-port",
+			values:   []string{"db:5432"},
+			expected: []model.Forward{{Local: 5432, Service: true, ServiceName: "db"}},
+		},
+		{
+			name:   "local-and-remote-port",
+			values: []string{"db:15432:5432", "api:8080"},
+			expected: []model.Forward{
+				{Local: 15432, Remote: 5432, Service: true, ServiceName: "db"},
+				{Local: 8080, Service: true, ServiceName: "api"},
+			},
+		},
+		{
+			name:      "missing-port",
+			values:    []string{"db"},
+			expectErr: true,
+		},
+		{
+			name:      "missing-name",
+			values:    []string{":5432"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid-local-port",
+			values:    []string{"db:local"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid-remote-port",
+			values:    []string{"db:5432:70000"},
+			expectErr: true,
+		},
+		{
+			name:      "too-many-parts",
+			values:    []string{"db:1:2:3"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseServiceForwards(tt.values)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_resolveServiceForwards(t *testing.T) {
+	ctx := context.Background()
+	svc := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test"},
+		Spec: apiv1.ServiceSpec{
+			Ports: []apiv1.ServicePort{{Port: 5432}, {Port: 9187}},
+		},
+	}
+	c := fake.NewSimpleClientset(svc)
+
+	var tests = []struct {
+		name      string
+		forwards  []model.Forward
+		expected  []model.Forward
+		expectErr bool
+	}{
+		{
+			name:     "first-port",
+			forwards: []model.Forward{{Local: 15432, Service: true, ServiceName: "db"}},
+			expected: []model.Forward{{Local: 15432, Remote: 5432, Service: true, ServiceName: "db"}},
+		},
+		{
+			name:     "remote-port",
+			forwards: []model.Forward{{Local: 19187, Remote: 9187, Service: true, ServiceName: "db"}},
+			expected: []model.Forward{{Local: 19187, Remote: 9187, Service: true, ServiceName: "db"}},
+		},
+		{
+			name:      "service-not-found",
+			forwards:  []model.Forward{{Local: 8080, Service: true, ServiceName: "api"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := resolveServiceForwards(ctx, tt.forwards, "test", c)
+			if tt.expectErr {
+				if _, ok := err.(errors.UserError); !ok {
+					t.Fatalf("expected a user error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_checkServiceForwards(t *testing.T) {
+	dev := &model.Dev{
+		Forward:    []model.Forward{{Local: 8080, Remote: 8080}},
+		RemotePort: 2222,
+	}
+
+	var tests = []struct {
+		name      string
+		forwards  []model.Forward
+		expectErr bool
+	}{
+		{
+			name:     "ok",
+			forwards: []model.Forward{{Local: 5432, Service: true, ServiceName: "db"}, {Local: 6379, Service: true, ServiceName: "redis"}},
+		},
+		{
+			name:      "manifest-forward",
+			forwards:  []model.Forward{{Local: 8080, Service: true, ServiceName: "db"}},
+			expectErr: true,
+		},
+		{
+			name:      "remote-port",
+			forwards:  []model.Forward{{Local: 2222, Service: true, ServiceName: "db"}},
+			expectErr: true,
+		},
+		{
+			name:      "duplicated-service-forward",
+			forwards:  []model.Forward{{Local: 5432, Service: true, ServiceName: "db"}, {Local: 5432, Service: true, ServiceName: "replica"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkServiceForwards(tt.forwards, dev)
+			if tt.expectErr && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
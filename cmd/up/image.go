@@ -0,0 +1,105 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/registry"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// checkImageRepository warns when the image of the okteto manifest comes from a different repository as the image of the container swapped by 'okteto up'.
+// It asks for confirmation in interactive mode
+func (up *upContext) checkImageRepository(d *appsv1.Deployment) error {
+	if !up.checkImage || up.Dev.Image.Name == "" {
+		return nil
+	}
+
+	container, image := getOriginalImage(d, up.Dev.Container)
+	if image == "" {
+		return nil
+	}
+
+	if sameImageRepository(up.Dev.Image.Name, image) {
+		return nil
+	}
+
+	log.Yellow("The image of your okteto manifest '%s' doesn't come from the same repository as the image of the container '%s' of deployment '%s': '%s'", up.Dev.Image.Name, container, d.Name, image)
+	log.Yellow("Your synchronized code might not work in the development container")
+	if !up.isTerm || up.nonInteractive {
+		return nil
+	}
+
+	confirmed, err := utils.AskYesNo("    Do you want to continue? [y/n] ")
+	if err != nil {
+		return fmt.Errorf("failed to confirm the image of your development container: %s", err.Error())
+	}
+	if !confirmed {
+		return errors.UserError{
+			E:    fmt.Errorf("the image of your okteto manifest doesn't match the image of deployment '%s'", d.Name),
+			Hint: "Update the 'image' field of your okteto manifest or run 'okteto up' without '--check-image'",
+		}
+	}
+	return nil
+}
+
+// getOriginalImage returns the name and the image of the development container before 'okteto up' swapped it.
+// Deployments in dev mode keep the original deployment in an annotation
+func getOriginalImage(d *appsv1.Deployment, container string) (string, string) {
+	if deployments.IsDevModeOn(d) {
+		dOrig, err := deployments.GetOriginalDeploymentFromAnnotation(d)
+		if err != nil {
+			log.Infof("failed to get the original deployment of '%s': %s", d.Name, err)
+			return "", ""
+		}
+		if dOrig == nil {
+			return "", ""
+		}
+		d = dOrig
+	}
+
+	devContainer := deployments.GetDevContainer(&d.Spec.Template.Spec, container)
+	if devContainer == nil {
+		return "", ""
+	}
+	return devContainer.Name, devContainer.Image
+}
+
+// sameImageRepository returns if two images come from the same repository, regardless of their tags or digests
+func sameImageRepository(image1, image2 string) bool {
+	return normalizeImageRepository(image1) == normalizeImageRepository(image2)
+}
+
+func normalizeImageRepository(image string) string {
+	repo, _ := registry.GetRepoNameAndTag(image)
+	repo = strings.ToLower(repo)
+
+	i := strings.IndexRune(repo, '/')
+	if i == -1 || (!strings.ContainsAny(repo[:i], ".:") && repo[:i] != "localhost") {
+		repo = "docker.io/" + repo
+	} else if repo[:i] == "index.docker.io" {
+		repo = "docker.io" + repo[i:]
+	}
+
+	if strings.HasPrefix(repo, "docker.io/") && strings.Count(repo, "/") == 1 {
+		repo = strings.Replace(repo, "docker.io/", "docker.io/library/", 1)
+	}
+	return repo
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_sameImageRepository(t *testing.T) {
+	var tests = []struct {
+		name     string
+		image1   string
+		image2   string
+		expected bool
+	}{
+		{
+			name:     "same-tag",
+			image1:   "okteto/api:1.0",
+			image2:   "okteto/api:1.0",
+			expected: true,
+		},
+		{
+			name:     "different-tag",
+			image1:   "okteto/api:dev",
+			image2:   "okteto/api:1.0",
+			expected: true,
+		},
+		{
+			name:     "digest",
+			image1:   "okteto/api@sha256:5f8d2a3b",
+			image2:   "okteto/api:1.0",
+			expected: true,
+		},
+		{
+			name:     "official-image",
+			image1:   "python:3",
+			image2:   "docker.io/library/python:3.9",
+			expected: true,
+		},
+		{
+			name:     "index-docker-io",
+			image1:   "index.docker.io/okteto/api",
+			image2:   "okteto/api:1.0",
+			expected: true,
+		},
+		{
+			name:     "registry-with-port",
+			image1:   "localhost:5000/api:dev",
+			image2:   "localhost:5000/api:1.0",
+			expected: true,
+		},
+		{
+			name:     "different-language",
+			image1:   "golang:1.16",
+			image2:   "node:14",
+			expected: false,
+		},
+		{
+			name:     "different-registry",
+			image1:   "gcr.io/okteto/api:1.0",
+			image2:   "okteto/api:1.0",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := sameImageRepository(tt.image1, tt.image2); result != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_getOriginalImage(t *testing.T) {
+	original := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "api", Image: "okteto/api:1.0"}},
+				},
+			},
+		},
+	}
+	b, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	devMode := original.DeepCopy()
+	devMode.Labels = map[string]string{model.DevLabel: "true"}
+	devMode.Annotations = map[string]string{model.DeploymentAnnotation: string(b)}
+	devMode.Spec.Template.Spec.Containers[0].Image = "okteto/golang:1"
+
+	legacy := devMode.DeepCopy()
+	legacy.Annotations = nil
+
+	var tests = []struct {
+		name      string
+		d         *appsv1.Deployment
+		container string
+		image     string
+	}{
+		{
+			name:      "dev-mode-off",
+			d:         original,
+			container: "api",
+			image:     "okteto/api:1.0",
+		},
+		{
+			name:      "dev-mode-on",
+			d:         devMode,
+			container: "api",
+			image:     "okteto/api:1.0",
+		},
+		{
+			name: "dev-mode-on-without-original",
+			d:    legacy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container, image := getOriginalImage(tt.d, "")
+			if container != tt.container || image != tt.image {
+				t.Errorf("expected '%s' and '%s', got '%s' and '%s'", tt.container, tt.image, container, image)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// printManifest writes the resolved okteto manifest, after defaults, env expansion and overrides
+func printManifest(dev *model.Dev, w io.Writer) error {
+	marshalled, err := yaml.Marshal(dev)
+	if err != nil {
+		log.Infof("failed to marshal development container: %s", err)
+		return fmt.Errorf("failed to generate the resolved manifest")
+	}
+
+	_, err = w.Write(marshalled)
+	return err
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func Test_printManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	defer os.Unsetenv("OKTETO_FOLDER")
+	os.Setenv("OKTETO_EXECUTE_SSH", "false")
+	defer os.Unsetenv("OKTETO_EXECUTE_SSH")
+	os.Setenv("TEST_PRINT_MANIFEST_TAG", "1.16")
+	defer os.Unsetenv("TEST_PRINT_MANIFEST_TAG")
+
+	dev, err := model.Read([]byte(`name: web
+image: okteto/golang:${TEST_PRINT_MANIFEST_TAG}
+sync:
+  - .:/app`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadDevOverrides(dev, &devOverrides{syncMode: model.SyncModeSendOnly}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := printManifest(dev, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	printed, err := model.Read(out.Bytes())
+	if err != nil {
+		t.Fatalf("printed manifest is not valid: %s\n%s", err, out.String())
+	}
+	if printed.Image.Name != "okteto/golang:1.16" {
+		t.Errorf("expected expanded image 'okteto/golang:1.16', got '%s'", printed.Image.Name)
+	}
+	if printed.Sync.Mode != model.SyncModeSendOnly {
+		t.Errorf("expected overridden sync mode '%s', got '%s'", model.SyncModeSendOnly, printed.Sync.Mode)
+	}
+
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(out.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := raw["imagePullPolicy"]; !ok {
+		t.Errorf("printed manifest doesn't include the defaults:\n%s", out.String())
+	}
+}
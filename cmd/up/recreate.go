@@ -0,0 +1,67 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// recreateIfConfigChanged destroys a deployment created by 'okteto up' when it was activated with a different okteto manifest.
+// It returns true if the deployment was destroyed and has to be created again
+func recreateIfConfigChanged(ctx context.Context, d *appsv1.Deployment, hash string, timeout time.Duration, c kubernetes.Interface) (bool, error) {
+	if d.Annotations[model.OktetoAutoCreateAnnotation] != model.OktetoUpCmd {
+		return false, nil
+	}
+
+	previous := d.Annotations[model.OktetoDevHashAnnotation]
+	if previous == "" || previous == hash {
+		return false, nil
+	}
+
+	log.Information("Your okteto manifest has changed, recreating your development container...")
+	log.Infof("manifest hash changed from %s to %s", previous, hash)
+	if err := deployments.Destroy(ctx, d.Name, d.Namespace, c); err != nil {
+		return false, err
+	}
+
+	t := time.NewTicker(500 * time.Millisecond)
+	defer t.Stop()
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+	for {
+		_, err := c.AppsV1().Deployments(d.Namespace).Get(ctx, d.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			log.Infof("failed to get deployment '%s': %s", d.Name, err)
+		}
+
+		select {
+		case <-t.C:
+		case <-to.C:
+			return false, fmt.Errorf("deployment '%s' wasn't deleted after %s, please try again", d.Name, timeout)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_recreateIfConfigChanged(t *testing.T) {
+	var tests = []struct {
+		name        string
+		annotations map[string]string
+		recreate    bool
+	}{
+		{
+			name: "changed",
+			annotations: map[string]string{
+				model.OktetoAutoCreateAnnotation: model.OktetoUpCmd,
+				model.OktetoDevHashAnnotation:    "old",
+			},
+			recreate: true,
+		},
+		{
+			name: "unchanged",
+			annotations: map[string]string{
+				model.OktetoAutoCreateAnnotation: model.OktetoUpCmd,
+				model.OktetoDevHashAnnotation:    "new",
+			},
+			recreate: false,
+		},
+		{
+			name: "no-hash",
+			annotations: map[string]string{
+				model.OktetoAutoCreateAnnotation: model.OktetoUpCmd,
+			},
+			recreate: false,
+		},
+		{
+			name: "not-autocreated",
+			annotations: map[string]string{
+				model.OktetoDevHashAnnotation: "old",
+			},
+			recreate: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dev := &model.Dev{Name: "web", Namespace: "test", Image: &model.BuildInfo{}}
+			d := dev.GevSandbox()
+			d.Annotations = tt.annotations
+			c := fake.NewSimpleClientset(d)
+
+			recreated, err := recreateIfConfigChanged(ctx, d, "new", time.Second, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if recreated != tt.recreate {
+				t.Errorf("expected recreate %t, got %t", tt.recreate, recreated)
+			}
+
+			_, err = c.AppsV1().Deployments("test").Get(ctx, "web", metav1.GetOptions{})
+			if tt.recreate && err == nil {
+				t.Errorf("deployment wasn't deleted")
+			}
+			if !tt.recreate && err != nil {
+				t.Errorf("deployment was deleted: %s", err)
+			}
+		})
+	}
+}
+
+func Test_recreateOnConfigChangeDefault(t *testing.T) {
+	f := Up().Flags().Lookup("recreate-on-config-change")
+	if f == nil {
+		t.Fatal("'--recreate-on-config-change' is not defined")
+	}
+	if f.DefValue != "true" {
+		t.Errorf("expected '--recreate-on-config-change' to be enabled by default, got '%s'", f.DefValue)
+	}
+}
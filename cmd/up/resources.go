@@ -0,0 +1,57 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/limitranges"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+// checkLimitRanges validates the resources of the development containers against the limit ranges of the namespace.
+// Development containers without resources get the defaults of the limit ranges, which are displayed
+func checkLimitRanges(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	lrs, err := limitranges.List(ctx, dev.Namespace, c)
+	if err != nil {
+		log.Infof("failed to list the limit ranges of namespace '%s': %s", dev.Namespace, err)
+		return nil
+	}
+	if len(lrs) == 0 {
+		return nil
+	}
+
+	devs := append([]*model.Dev{dev}, dev.Services...)
+	for _, d := range devs {
+		if len(d.Resources.Requests) == 0 && len(d.Resources.Limits) == 0 {
+			defaults := limitranges.GetContainerDefaults(lrs)
+			if len(defaults.Requests) > 0 || len(defaults.Limits) > 0 {
+				log.Information("'%s' uses the default resources of namespace '%s': %s", d.Name, dev.Namespace, limitranges.FormatResources(defaults))
+			}
+			continue
+		}
+
+		if err := limitranges.ValidateResources(lrs, d.Resources); err != nil {
+			return errors.UserError{
+				E:    fmt.Errorf("the resources of '%s' are not allowed in namespace '%s': %s", d.Name, dev.Namespace, err),
+				Hint: "Update the 'resources' field of your okteto manifest, or remove it to use the defaults of the namespace",
+			}
+		}
+	}
+	return nil
+}
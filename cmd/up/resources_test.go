@@ -0,0 +1,107 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_checkLimitRanges(t *testing.T) {
+	ctx := context.Background()
+	lr := &apiv1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "test"},
+		Spec: apiv1.LimitRangeSpec{
+			Limits: []apiv1.LimitRangeItem{
+				{
+					Type:    apiv1.LimitTypeContainer,
+					Max:     apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("2Gi")},
+					Default: apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+		},
+	}
+
+	var tests = []struct {
+		name        string
+		manifest    string
+		limitRanges bool
+		expectErr   bool
+	}{
+		{
+			name:        "no-resources",
+			manifest:    "name: api\nnamespace: test",
+			limitRanges: true,
+			expectErr:   false,
+		},
+		{
+			name:        "valid-resources",
+			manifest:    "name: api\nnamespace: test\nresources:\n  limits:\n    memory: 2Gi",
+			limitRanges: true,
+			expectErr:   false,
+		},
+		{
+			name:        "violating-resources",
+			manifest:    "name: api\nnamespace: test\nresources:\n  limits:\n    memory: 4Gi",
+			limitRanges: true,
+			expectErr:   true,
+		},
+		{
+			name:        "violating-service-resources",
+			manifest:    "name: api\nnamespace: test\nservices:\n  - name: worker\n    resources:\n      limits:\n        memory: 4Gi",
+			limitRanges: true,
+			expectErr:   true,
+		},
+		{
+			name:        "no-limit-ranges",
+			manifest:    "name: api\nnamespace: test\nresources:\n  limits:\n    memory: 4Gi",
+			limitRanges: false,
+			expectErr:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := model.Read([]byte(tt.manifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := fake.NewSimpleClientset()
+			if tt.limitRanges {
+				c = fake.NewSimpleClientset(lr)
+			}
+
+			err = checkLimitRanges(ctx, dev, c)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				if !strings.Contains(err.Error(), "greater than the maximum '2Gi'") {
+					t.Errorf("unexpected error message: %s", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/okteto/okteto/pkg/k8s/annotations"
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// createSecrets creates the syncthing and pull secrets of the development container.
+// If recreate is set, the syncthing secret is deleted first, so no value of the previous secret is kept
+func createSecrets(ctx context.Context, dev *model.Dev, sy *syncthing.Syncthing, recreate bool, c kubernetes.Interface) error {
+	if recreate {
+		log.Info("recreating the syncthing secret")
+		if err := secrets.Destroy(ctx, dev, c); err != nil {
+			return err
+		}
+	}
+
+	if err := secrets.Create(ctx, dev, c, sy); err != nil {
+		return err
+	}
+	return secrets.CreatePullSecret(ctx, dev, c)
+}
+
+// annotateDevDeployment sets the manifest hash on the dev deployment.
+// With '--recreate-secret' it also sets a restart annotation on the pod template, so the development container loads the new secret
+func (up *upContext) annotateDevDeployment(d *appsv1.Deployment) {
+	if up.devHash != "" {
+		annotations.Set(d.GetObjectMeta(), model.OktetoDevHashAnnotation, up.devHash)
+	}
+
+	if up.recreateSecret {
+		annotations.Set(d.Spec.Template.GetObjectMeta(), model.OktetoRestartAnnotation, uuid.New().String())
+	}
+}
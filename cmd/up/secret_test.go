@@ -0,0 +1,107 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_createSecrets(t *testing.T) {
+	var tests = []struct {
+		name     string
+		recreate bool
+	}{
+		{
+			name:     "update",
+			recreate: false,
+		},
+		{
+			name:     "recreate",
+			recreate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{Name: "dev", Namespace: "test"}
+			c := fake.NewSimpleClientset(&apiv1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secrets.GetSecretName(dev), Namespace: dev.Namespace},
+			})
+			sy := &syncthing.Syncthing{APIKey: "apikey", RescanInterval: "300", Compression: "true"}
+
+			if err := createSecrets(context.Background(), dev, sy, tt.recreate, c); err != nil {
+				t.Fatal(err)
+			}
+
+			deleted := false
+			for _, a := range c.Actions() {
+				if a.GetVerb() == "delete" && a.GetResource().Resource == "secrets" {
+					deleted = true
+				}
+			}
+			if deleted != tt.recreate {
+				t.Errorf("expected secret deleted to be %t, got %t", tt.recreate, deleted)
+			}
+
+			s, err := secrets.Get(context.Background(), secrets.GetSecretName(dev), dev.Namespace, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(s.Data["config.xml"]) == 0 {
+				t.Error("the secret doesn't contain the syncthing configuration")
+			}
+		})
+	}
+}
+
+func Test_annotateDevDeployment(t *testing.T) {
+	var tests = []struct {
+		name           string
+		recreateSecret bool
+	}{
+		{
+			name:           "no-recreate-secret",
+			recreateSecret: false,
+		},
+		{
+			name:           "recreate-secret",
+			recreateSecret: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := &upContext{devHash: "hash", recreateSecret: tt.recreateSecret}
+			d := &appsv1.Deployment{}
+			up.annotateDevDeployment(d)
+
+			if d.Annotations[model.OktetoDevHashAnnotation] != "hash" {
+				t.Errorf("expected the manifest hash annotation, got '%s'", d.Annotations[model.OktetoDevHashAnnotation])
+			}
+			_, ok := d.Spec.Template.Annotations[model.OktetoRestartAnnotation]
+			if ok != tt.recreateSecret {
+				t.Errorf("expected restart annotation to be %t, got %t", tt.recreateSecret, ok)
+			}
+		})
+	}
+}
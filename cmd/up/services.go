@@ -0,0 +1,105 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+const waitForServicesInterval = 1 * time.Second
+
+// waitUntilServicesAreReady waits for the pods of every service to be ready when '--wait-for-services' is set
+func (up *upContext) waitUntilServicesAreReady(ctx context.Context) error {
+	if up.waitForServices <= 0 || len(up.Dev.Services) == 0 {
+		return nil
+	}
+
+	spinner := utils.NewSpinner("Waiting for your services to be ready...")
+	spinner.Start()
+	defer spinner.Stop()
+
+	return waitForServices(ctx, up.Client, up.Dev, waitForServicesInterval, up.waitForServices)
+}
+
+// waitForServices checks every interval if the services of the development container are ready until they are or the timeout expires
+func waitForServices(ctx context.Context, c kubernetes.Interface, dev *model.Dev, interval, timeout time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+
+	for {
+		pending := []string{}
+		for _, s := range dev.Services {
+			name, ready, err := isServiceReady(ctx, c, dev.Namespace, s)
+			if err != nil {
+				return err
+			}
+			if !ready {
+				pending = append(pending, name)
+			}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+		log.Infof("waiting for services to be ready: %s", strings.Join(pending, ", "))
+
+		select {
+		case <-t.C:
+		case <-to.C:
+			return errors.UserError{
+				E:    fmt.Errorf("services %s weren't ready after %s", strings.Join(pending, ", "), timeout),
+				Hint: "Check the status of their pods or increase the value of '--wait-for-services-timeout'",
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// isServiceReady returns the name of the deployment of a service and if it has a ready pod running the development container
+func isServiceReady(ctx context.Context, c kubernetes.Interface, namespace string, s *model.Dev) (string, bool, error) {
+	d, err := deployments.Get(ctx, s, namespace, c)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return s.Name, false, nil
+		}
+		return s.Name, false, err
+	}
+
+	ps, err := pods.ListBySelector(ctx, namespace, d.Spec.Template.Labels, c)
+	if err != nil {
+		return d.Name, false, err
+	}
+
+	for i := range ps {
+		if pods.IsReady(&ps[i]) {
+			return d.Name, true, nil
+		}
+	}
+
+	return d.Name, false, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newServiceDeployment(name string, labels map[string]string) *appsv1.Deployment {
+	podLabels := map[string]string{"app": name, model.DetachedDevLabel: "dev"}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			},
+		},
+	}
+}
+
+func newServicePod(name string, ready bool) *apiv1.Pod {
+	status := apiv1.ConditionFalse
+	if ready {
+		status = apiv1.ConditionTrue
+	}
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-pod",
+			Namespace: "ns",
+			Labels:    map[string]string{"app": name, model.DetachedDevLabel: "dev"},
+		},
+		Status: apiv1.PodStatus{
+			Phase:      apiv1.PodRunning,
+			Conditions: []apiv1.PodCondition{{Type: apiv1.PodReady, Status: status}},
+		},
+	}
+}
+
+func Test_waitForServices(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		newServiceDeployment("api", nil),
+		newServiceDeployment("worker", map[string]string{"role": "worker"}),
+		newServicePod("api", false),
+	)
+
+	dev := &model.Dev{
+		Name:      "dev",
+		Namespace: "ns",
+		Services: []*model.Dev{
+			{Name: "api"},
+			{Labels: map[string]string{"role": "worker"}},
+		},
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		if _, err := c.CoreV1().Pods("ns").Update(ctx, newServicePod("api", true), metav1.UpdateOptions{}); err != nil {
+			t.Error(err)
+		}
+		time.Sleep(50 * time.Millisecond)
+		if _, err := c.CoreV1().Pods("ns").Create(ctx, newServicePod("worker", true), metav1.CreateOptions{}); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	start := time.Now()
+	if err := waitForServices(ctx, c, dev, 10*time.Millisecond, 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if time.Since(start) < 100*time.Millisecond {
+		t.Errorf("returned before the services were ready")
+	}
+}
+
+func Test_waitForServicesTimeout(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		newServiceDeployment("api", nil),
+		newServicePod("api", true),
+		newServiceDeployment("worker", nil),
+		newServicePod("worker", false),
+	)
+
+	dev := &model.Dev{
+		Name:      "dev",
+		Namespace: "ns",
+		Services:  []*model.Dev{{Name: "api"}, {Name: "worker"}, {Name: "db"}},
+	}
+
+	err := waitForServices(context.Background(), c, dev, 10*time.Millisecond, 50*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+
+	uErr, ok := err.(errors.UserError)
+	if !ok {
+		t.Fatalf("expected a user error, got %T: %s", err, err)
+	}
+
+	if uErr.E.Error() != "services worker, db weren't ready after 50ms" {
+		t.Errorf("unexpected error: %s", uErr.E)
+	}
+}
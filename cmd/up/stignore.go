@@ -22,6 +22,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	initCMD "github.com/okteto/okteto/cmd/init"
@@ -32,6 +33,202 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 )
 
+const (
+	gitignoreBlockStart  = "// okteto: begin .gitignore patterns (managed by 'sync.useGitignore')"
+	gitignoreBlockEnd    = "// okteto: end .gitignore patterns"
+	largeFilesBlockStart = "// okteto: begin large files (managed by '--sync-exclude-large')"
+	largeFilesBlockEnd   = "// okteto: end large files"
+)
+
+// addGitignorePatterns keeps the block of .gitignore patterns of the .stignore files of the sync folders.
+// The block is removed when 'sync.useGitignore' is disabled, so no stale patterns are left behind
+func addGitignorePatterns(dev *model.Dev) error {
+	for _, folder := range dev.Sync.Folders {
+		patterns := []string{}
+		gitignorePath := filepath.Join(folder.LocalPath, ".gitignore")
+		if dev.Sync.UseGitignore && model.FileExists(gitignorePath) {
+			gitignoreBytes, err := ioutil.ReadFile(gitignorePath)
+			if err != nil {
+				return fmt.Errorf("failed to read '%s': %s", gitignorePath, err.Error())
+			}
+			patterns = translateGitignore(strings.Split(string(gitignoreBytes), "\n"))
+		}
+
+		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
+		updated, err := updateStignoreBlock(stignorePath, gitignoreBlockStart, gitignoreBlockEnd, patterns)
+		if err != nil {
+			return err
+		}
+		if updated {
+			log.Infof("updated the '.gitignore' patterns of '%s'", stignorePath)
+		}
+	}
+	return nil
+}
+
+// translateGitignore converts .gitignore lines into syncthing ignore patterns.
+// Git applies the last matching pattern and syncthing the first one, so the order is reversed
+func translateGitignore(lines []string) []string {
+	result := []string{}
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimRight(lines[i], " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		negate := false
+		if strings.HasPrefix(line, "!") {
+			negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, "\\#") || strings.HasPrefix(line, "\\!") {
+			line = line[1:]
+		}
+
+		line = strings.TrimSuffix(line, "/")
+		if line == "" {
+			continue
+		}
+
+		if strings.Contains(line, "/") && !strings.HasPrefix(line, "/") && !strings.HasPrefix(line, "**/") {
+			line = "/" + line
+		}
+
+		if negate {
+			line = "!" + line
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+// mergeGitignoreBlock replaces the block of .gitignore patterns in the content of a .stignore file
+func mergeGitignoreBlock(stignoreContent string, patterns []string) string {
+	return mergeStignoreBlock(stignoreContent, gitignoreBlockStart, gitignoreBlockEnd, patterns)
+}
+
+// addLargeFilePatterns ignores the files of the sync folders larger than maxSizeMB megabytes.
+// The block of large files is removed from the .stignore files when maxSizeMB is zero
+func addLargeFilePatterns(dev *model.Dev, maxSizeMB int) error {
+	for _, folder := range dev.Sync.Folders {
+		patterns := []string{}
+		if maxSizeMB > 0 {
+			for _, rel := range getLargeFiles(folder.LocalPath, int64(maxSizeMB)*1024*1024) {
+				log.Information("Excluding '%s' from the file synchronization: it is larger than %dMB", filepath.Join(folder.LocalPath, rel), maxSizeMB)
+				patterns = append(patterns, "/"+escapeStignorePattern(filepath.ToSlash(rel), runtime.GOOS))
+			}
+		}
+
+		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
+		if _, err := updateStignoreBlock(stignorePath, largeFilesBlockStart, largeFilesBlockEnd, patterns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getLargeFiles returns the paths, relative to folder, of the files under folder larger than maxSize bytes.
+// Entries that can't be read are skipped
+func getLargeFiles(folder string, maxSize int64) []string {
+	result := []string{}
+	err := filepath.Walk(folder, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			log.Infof("skipping '%s' looking for large files: %s", p, err.Error())
+			if info != nil && info.IsDir() && p != folder {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || info.Size() <= maxSize {
+			return nil
+		}
+		rel, err := filepath.Rel(folder, p)
+		if err != nil {
+			log.Infof("skipping '%s' looking for large files: %s", p, err.Error())
+			return nil
+		}
+		result = append(result, rel)
+		return nil
+	})
+	if err != nil {
+		log.Infof("failed to look for large files in '%s': %s", folder, err.Error())
+	}
+	return result
+}
+
+// escapeStignorePattern escapes the characters with a special meaning in syncthing ignore patterns, so p only matches itself.
+// Syncthing doesn't support backslash escaping on Windows, where it is the path separator, so the characters that open
+// a pattern are wrapped in a character class instead. ']' and '}' are literals without the character that opens them
+func escapeStignorePattern(p, goos string) string {
+	var b strings.Builder
+	for _, r := range p {
+		switch {
+		case goos == "windows" && strings.ContainsRune(`*?[{`, r):
+			b.WriteString("[" + string(r) + "]")
+		case goos != "windows" && strings.ContainsRune(`\*?[]{}`, r):
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// updateStignoreBlock replaces the block delimited by start and end in a .stignore file with patterns.
+// The file is only written if its content changes, and it isn't created if there are no patterns
+func updateStignoreBlock(stignorePath, start, end string, patterns []string) (bool, error) {
+	stignoreContent := ""
+	if model.FileExists(stignorePath) {
+		stignoreBytes, err := ioutil.ReadFile(stignorePath)
+		if err != nil {
+			return false, fmt.Errorf("failed to read '%s': %s", stignorePath, err.Error())
+		}
+		stignoreContent = string(stignoreBytes)
+	}
+
+	if len(patterns) == 0 && !strings.Contains(stignoreContent, start) {
+		return false, nil
+	}
+
+	merged := mergeStignoreBlock(stignoreContent, start, end, patterns)
+	if merged == stignoreContent {
+		return false, nil
+	}
+
+	if err := ioutil.WriteFile(stignorePath, []byte(merged), 0644); err != nil {
+		return false, fmt.Errorf("failed to update '%s': %s", stignorePath, err.Error())
+	}
+	return true, nil
+}
+
+// mergeStignoreBlock replaces the block delimited by start and end in the content of a .stignore file
+func mergeStignoreBlock(stignoreContent, start, end string, patterns []string) string {
+	lines := []string{}
+	inBlock := false
+	for _, line := range strings.Split(stignoreContent, "\n") {
+		switch strings.TrimSpace(line) {
+		case start:
+			inBlock = true
+			continue
+		case end:
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			lines = append(lines, line)
+		}
+	}
+
+	content := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if len(patterns) == 0 {
+		return content + "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	return fmt.Sprintf("%s%s\n%s\n%s\n", content, start, strings.Join(patterns, "\n"), end)
+}
+
 func addStignoreSecrets(dev *model.Dev) error {
 	output := ""
 	for i, folder := range dev.Sync.Folders {
@@ -73,7 +270,7 @@ func addStignoreSecrets(dev *model.Dev) error {
 			if strings.Contains(line, "(?d)") {
 				continue
 			}
-			if strings.HasPrefix(line, "#") {
+			if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
 				continue
 			}
 
@@ -0,0 +1,209 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_translateGitignore(t *testing.T) {
+	gitignore := `# dependencies
+node_modules/
+/dist
+*.log
+!important.log
+docs/build
+**/tmp
+\#notes
+
+`
+	expected := []string{
+		"#notes",
+		"**/tmp",
+		"/docs/build",
+		"!important.log",
+		"*.log",
+		"/dist",
+		"node_modules",
+	}
+
+	result := translateGitignore(strings.Split(gitignore, "\n"))
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func Test_mergeGitignoreBlock(t *testing.T) {
+	var tests = []struct {
+		name     string
+		stignore string
+		patterns []string
+		expected string
+	}{
+		{
+			name:     "empty-stignore",
+			stignore: "",
+			patterns: []string{"/dist"},
+			expected: gitignoreBlockStart + "\n/dist\n" + gitignoreBlockEnd + "\n",
+		},
+		{
+			name:     "append-block",
+			stignore: ".git\n",
+			patterns: []string{"/dist", "node_modules"},
+			expected: ".git\n" + gitignoreBlockStart + "\n/dist\nnode_modules\n" + gitignoreBlockEnd + "\n",
+		},
+		{
+			name:     "replace-block",
+			stignore: ".git\n" + gitignoreBlockStart + "\n/old\n" + gitignoreBlockEnd + "\n",
+			patterns: []string{"/dist"},
+			expected: ".git\n" + gitignoreBlockStart + "\n/dist\n" + gitignoreBlockEnd + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mergeGitignoreBlock(tt.stignore, tt.patterns)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_addGitignorePatterns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "okteto-gitignore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, ".gitignore"), []byte("/dist\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stignorePath := filepath.Join(dir, ".stignore")
+	if err := ioutil.WriteFile(stignorePath, []byte(".git\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dev := &model.Dev{
+		Sync: model.Sync{
+			UseGitignore: true,
+			Folders:      []model.SyncFolder{{LocalPath: dir, RemotePath: "/app"}},
+		},
+	}
+
+	if err := addGitignorePatterns(dev); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(stignorePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ".git\n" + gitignoreBlockStart + "\n/dist\n" + gitignoreBlockEnd + "\n"
+	if string(content) != expected {
+		t.Errorf("expected %q, got %q", expected, string(content))
+	}
+
+	dev.Sync.UseGitignore = false
+	if err := addGitignorePatterns(dev); err != nil {
+		t.Fatal(err)
+	}
+	content, err = ioutil.ReadFile(stignorePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != ".git\n" {
+		t.Errorf("the block of a disabled 'sync.useGitignore' wasn't removed: %q", string(content))
+	}
+}
+
+func Test_addLargeFilePatterns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "okteto-large-files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "data", "dump.sql"), make([]byte, 2*1024*1024), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stignorePath := filepath.Join(dir, ".stignore")
+	if err := ioutil.WriteFile(stignorePath, []byte(".git\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dev := &model.Dev{
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{{LocalPath: dir, RemotePath: "/app"}},
+		},
+	}
+
+	if err := addLargeFilePatterns(dev, 1); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(stignorePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ".git\n" + largeFilesBlockStart + "\n/data/dump.sql\n" + largeFilesBlockEnd + "\n"
+	if string(content) != expected {
+		t.Errorf("expected %q, got %q", expected, string(content))
+	}
+
+	if err := addLargeFilePatterns(dev, 0); err != nil {
+		t.Fatal(err)
+	}
+	content, err = ioutil.ReadFile(stignorePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != ".git\n" {
+		t.Errorf("expected %q, got %q", ".git\n", string(content))
+	}
+}
+
+func Test_escapeStignorePattern(t *testing.T) {
+	var tests = []struct {
+		path     string
+		goos     string
+		expected string
+	}{
+		{path: "data/dump.sql", goos: "linux", expected: "data/dump.sql"},
+		{path: "data/[old]/dump*.sql", goos: "linux", expected: `data/\[old\]/dump\*.sql`},
+		{path: "video?.mp4", goos: "darwin", expected: `video\?.mp4`},
+		{path: "assets/{a,b}.bin", goos: "linux", expected: `assets/\{a,b\}.bin`},
+		{path: "data/dump.sql", goos: "windows", expected: "data/dump.sql"},
+		{path: "data/[old]/dump.sql", goos: "windows", expected: "data/[[]old]/dump.sql"},
+		{path: "assets/{a,b}.bin", goos: "windows", expected: "assets/[{]a,b}.bin"},
+	}
+
+	for _, tt := range tests {
+		if result := escapeStignorePattern(tt.path, tt.goos); result != tt.expected {
+			t.Errorf("'%s' on %s: expected '%s', got '%s'", tt.path, tt.goos, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+const (
+	// summaryText prints the session summary as text when 'okteto up' exits
+	summaryText = "text"
+
+	// summaryJSON prints the session summary as json when 'okteto up' exits
+	summaryJSON = "json"
+)
+
+// sessionMetrics are the metrics accumulated during an 'okteto up' session
+// They are guarded by mu, as they are updated by the activation goroutine and read when 'okteto up' exits
+type sessionMetrics struct {
+	mu            sync.Mutex
+	start         time.Time
+	reconnects    int
+	bytesReceived int64
+	bytesSent     int64
+	state         config.UpState
+	sampled       *syncthing.Syncthing
+}
+
+// sessionSummary is the report of an 'okteto up' session printed by '--summary-on-exit'
+type sessionSummary struct {
+	Duration      string `json:"duration"`
+	BytesReceived int64  `json:"bytesReceived"`
+	BytesSent     int64  `json:"bytesSent"`
+	Reconnects    int    `json:"reconnects"`
+	FinalState    string `json:"finalState"`
+	Pod           string `json:"pod"`
+	Error         string `json:"error,omitempty"`
+}
+
+// parseSummaryOnExitFlag validates the value of '--summary-on-exit'
+func parseSummaryOnExitFlag(value string) (string, error) {
+	switch value {
+	case "", "false":
+		return "", nil
+	case summaryText, summaryJSON:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid value '%s' for '--summary-on-exit': must be 'text' or 'json'", value)
+	}
+}
+
+// updateState updates the state file of the development container and records it for the session summary
+func (up *upContext) updateState(state config.UpState) error {
+	up.metrics.mu.Lock()
+	up.metrics.state = state
+	up.metrics.mu.Unlock()
+	return config.UpdateStateFile(up.Dev, state, up.saveStateFile)
+}
+
+// recordTransferredBytes adds the bytes transferred by the current syncthing instance to the session metrics.
+// It must be called before syncthing is stopped, every instance is only recorded once
+func (up *upContext) recordTransferredBytes(ctx context.Context) {
+	up.metrics.mu.Lock()
+	defer up.metrics.mu.Unlock()
+	if up.Sy == nil || up.metrics.sampled == up.Sy {
+		return
+	}
+
+	received, sent, err := up.Sy.GetTransferredBytes(ctx)
+	if err != nil {
+		log.Infof("failed to get the bytes transferred by syncthing: %s", err.Error())
+		return
+	}
+	up.metrics.sampled = up.Sy
+	up.metrics.bytesReceived += received
+	up.metrics.bytesSent += sent
+}
+
+// recordReconnect adds a reconnection to the session metrics
+func (up *upContext) recordReconnect() {
+	up.metrics.mu.Lock()
+	defer up.metrics.mu.Unlock()
+	up.metrics.reconnects++
+}
+
+func (up *upContext) getSessionSummary(err error) *sessionSummary {
+	up.metrics.mu.Lock()
+	defer up.metrics.mu.Unlock()
+	summary := &sessionSummary{
+		Duration:      time.Since(up.metrics.start).Round(time.Second).String(),
+		BytesReceived: up.metrics.bytesReceived,
+		BytesSent:     up.metrics.bytesSent,
+		Reconnects:    up.metrics.reconnects,
+		FinalState:    string(up.metrics.state),
+	}
+	if up.Pod != nil {
+		summary.Pod = up.Pod.Name
+	}
+	if err != nil {
+		summary.Error = err.Error()
+	}
+	return summary
+}
+
+// printSessionSummary prints the summary of the session when '--summary-on-exit' is set
+func (up *upContext) printSessionSummary(err error, w io.Writer) {
+	if up.summaryOnExit == "" {
+		return
+	}
+
+	summary := up.getSessionSummary(err)
+	if up.summaryOnExit == summaryJSON {
+		bytes, err := json.Marshal(summary)
+		if err != nil {
+			log.Infof("failed to marshal the session summary: %s", err.Error())
+			return
+		}
+		fmt.Fprintln(w, string(bytes))
+		return
+	}
+
+	fmt.Fprintf(w, "Duration:       %s\n", summary.Duration)
+	fmt.Fprintf(w, "Bytes received: %d\n", summary.BytesReceived)
+	fmt.Fprintf(w, "Bytes sent:     %d\n", summary.BytesSent)
+	fmt.Fprintf(w, "Reconnects:     %d\n", summary.Reconnects)
+	fmt.Fprintf(w, "Final state:    %s\n", summary.FinalState)
+	fmt.Fprintf(w, "Pod:            %s\n", summary.Pod)
+	if summary.Error != "" {
+		fmt.Fprintf(w, "Error:          %s\n", summary.Error)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_parseSummaryOnExitFlag(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected string
+		isErr    bool
+	}{
+		{value: "", expected: ""},
+		{value: "false", expected: ""},
+		{value: "text", expected: summaryText},
+		{value: "json", expected: summaryJSON},
+		{value: "yaml", isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := parseSummaryOnExitFlag(tt.value)
+			if tt.isErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_printSessionSummary(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	defer os.Unsetenv("OKTETO_FOLDER")
+
+	up := &upContext{
+		Dev:               &model.Dev{Name: "test", Namespace: "namespace"},
+		ShutdownCompleted: make(chan bool, 1),
+		summaryOnExit:     summaryJSON,
+	}
+	up.metrics.start = time.Now().Add(-90 * time.Second)
+
+	calls := 0
+	sessionErr := up.retryActivate(func() error {
+		calls++
+		up.isRetry = true
+		up.ShutdownCompleted <- true
+		if calls <= 2 {
+			return errors.ErrLostSyncthing
+		}
+		up.Pod = &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-1234"}}
+		up.metrics.bytesReceived += 2048
+		up.metrics.bytesSent += 1024
+		if err := up.updateState(config.Ready); err != nil {
+			t.Fatal(err)
+		}
+		return fmt.Errorf("command failed")
+	})
+
+	var out bytes.Buffer
+	up.printSessionSummary(sessionErr, &out)
+
+	summary := &sessionSummary{}
+	if err := json.Unmarshal(out.Bytes(), summary); err != nil {
+		t.Fatalf("failed to unmarshal the summary '%s': %s", out.String(), err)
+	}
+
+	expected := &sessionSummary{
+		Duration:      "1m30s",
+		BytesReceived: 2048,
+		BytesSent:     1024,
+		Reconnects:    2,
+		FinalState:    string(config.Ready),
+		Pod:           "test-1234",
+		Error:         "command failed",
+	}
+	if *summary != *expected {
+		t.Errorf("expected %+v, got %+v", expected, summary)
+	}
+
+	up.summaryOnExit = summaryText
+	out.Reset()
+	up.printSessionSummary(nil, &out)
+	if !strings.Contains(out.String(), "Reconnects:     2\n") || strings.Contains(out.String(), "Error:") {
+		t.Errorf("wrong text summary: %s", out.String())
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/syncthing"
 )
 
@@ -34,7 +35,7 @@ func (up *upContext) initializeSyncthing() error {
 	sy.ResetDatabase = up.resetSyncthing
 	up.Sy = sy
 
-	log.Infof("local syncthing initialized: gui -> %d, sync -> %d", up.Sy.LocalGUIPort, up.Sy.LocalPort)
+	log.Infof("local syncthing initialized: gui -> %s, sync -> %d", up.Sy.GUIAddress, up.Sy.LocalPort)
 	log.Infof("remote syncthing initialized: gui -> %d, sync -> %d", up.Sy.RemoteGUIPort, up.Sy.RemotePort)
 
 	if err := up.Sy.SaveConfig(up.Dev); err != nil {
@@ -52,7 +53,7 @@ func (up *upContext) sync(ctx context.Context) error {
 	}
 
 	start := time.Now()
-	if err := config.UpdateStateFile(up.Dev, config.Synchronizing); err != nil {
+	if err := up.updateState(config.Synchronizing); err != nil {
 		return err
 	}
 
@@ -73,22 +74,29 @@ func (up *upContext) sync(ctx context.Context) error {
     More information is available here: https://okteto.com/docs/reference/file-synchronization`, minutes, seconds)
 	}
 
-	up.Sy.Type = "sendreceive"
-	up.Sy.IgnoreDelete = false
-	if err := up.Sy.UpdateConfig(); err != nil {
+	if err := up.updateSyncMode(); err != nil {
 		return err
 	}
 
 	go up.Sy.Monitor(ctx, up.Disconnect)
 	go up.Sy.MonitorStatus(ctx, up.Disconnect)
-	log.Infof("restarting syncthing to update sync mode to sendreceive")
+	log.Infof("restarting syncthing to update sync mode to %s", up.Sy.Type)
 	return up.Sy.Restart(ctx)
 }
 
+func (up *upContext) updateSyncMode() error {
+	up.Sy.Type = up.Dev.Sync.Mode
+	if up.Sy.Type == "" {
+		up.Sy.Type = model.SyncModeSendReceive
+	}
+	up.Sy.IgnoreDelete = false
+	return up.Sy.UpdateConfig()
+}
+
 func (up *upContext) startSyncthing(ctx context.Context) error {
 	spinner := utils.NewSpinner("Starting the file synchronization service...")
 	spinner.Start()
-	if err := config.UpdateStateFile(up.Dev, config.StartingSync); err != nil {
+	if err := up.updateState(config.StartingSync); err != nil {
 		return err
 	}
 	defer spinner.Stop()
@@ -0,0 +1,76 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+func Test_updateSyncMode(t *testing.T) {
+	var tests = []struct {
+		name     string
+		mode     string
+		expected string
+	}{
+		{name: "default", mode: "", expected: model.SyncModeSendReceive},
+		{name: "sendreceive", mode: model.SyncModeSendReceive, expected: model.SyncModeSendReceive},
+		{name: "sendonly", mode: model.SyncModeSendOnly, expected: model.SyncModeSendOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			up := &upContext{
+				Dev: &model.Dev{Sync: model.Sync{Mode: tt.mode}},
+				Sy: &syncthing.Syncthing{
+					Home:         dir,
+					Type:         "sendonly",
+					IgnoreDelete: true,
+					Folders:      []*syncthing.Folder{{Name: "1", LocalPath: dir, RemotePath: "/app"}},
+				},
+			}
+
+			if err := up.updateSyncMode(); err != nil {
+				t.Fatal(err)
+			}
+
+			if up.Sy.Type != tt.expected {
+				t.Errorf("expected type '%s', got '%s'", tt.expected, up.Sy.Type)
+			}
+			if up.Sy.IgnoreDelete {
+				t.Errorf("ignoreDelete wasn't disabled")
+			}
+
+			b, err := ioutil.ReadFile(filepath.Join(dir, "config.xml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), `type="`+tt.expected+`"`) {
+				t.Errorf("config.xml doesn't contain the sync mode '%s'", tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// activationTimer cancels the activation of the development container when it doesn't complete before '--wait-timeout'
+type activationTimer struct {
+	timer    *time.Timer
+	timedOut int32
+}
+
+func startActivationTimer(timeout time.Duration, cancel context.CancelFunc) *activationTimer {
+	t := &activationTimer{}
+	t.timer = time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&t.timedOut, 1)
+		log.Infof("activation didn't complete within %s, cancelling it", timeout)
+		cancel()
+	})
+	return t
+}
+
+// stop stops the timer. It returns false if the activation already timed out
+func (t *activationTimer) stop() bool {
+	t.timer.Stop()
+	return !t.hasTimedOut()
+}
+
+func (t *activationTimer) hasTimedOut() bool {
+	return atomic.LoadInt32(&t.timedOut) == 1
+}
+
+// activationTimedOut returns true if the last activation was cancelled by '--wait-timeout'
+func (up *upContext) activationTimedOut() bool {
+	return up.activationTimer != nil && up.activationTimer.hasTimedOut()
+}
+
+// getActivationTimeoutError sets the state to failed and returns the error displayed when the activation times out
+func (up *upContext) getActivationTimeoutError() error {
+	if err := up.updateState(config.Failed); err != nil {
+		log.Infof("failed to update the state file: %s", err)
+	}
+	return errors.UserError{
+		E:    fmt.Errorf("development environment didn't activate within %s", up.waitTimeout),
+		Hint: "Check the events of your development container with 'kubectl describe pod' or increase the value of '--wait-timeout'",
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_activationTimer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := startActivationTimer(10*time.Millisecond, cancel)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the activation wasn't cancelled")
+	}
+
+	if !timer.hasTimedOut() {
+		t.Error("the activation didn't time out")
+	}
+	if timer.stop() {
+		t.Error("stop didn't report the timeout")
+	}
+}
+
+func Test_activationTimerStopped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := startActivationTimer(50*time.Millisecond, cancel)
+
+	if !timer.stop() {
+		t.Fatal("the activation timed out before stopping the timer")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if ctx.Err() != nil {
+		t.Error("the activation was cancelled after stopping the timer")
+	}
+	if timer.hasTimedOut() {
+		t.Error("the activation timed out after stopping the timer")
+	}
+}
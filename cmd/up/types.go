@@ -15,6 +15,8 @@ package up
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/moby/term"
 	"github.com/okteto/okteto/pkg/model"
@@ -26,27 +28,66 @@ import (
 
 // upContext is the common context of all operations performed during the up command
 type upContext struct {
-	Cancel            context.CancelFunc
-	ShutdownCompleted chan bool
-	Dev               *model.Dev
-	isOktetoNamespace bool
-	isSwap            bool
-	isRetry           bool
-	Client            *kubernetes.Clientset
-	RestConfig        *rest.Config
-	Pod               *apiv1.Pod
-	Forwarder         forwarder
-	Disconnect        chan error
-	CommandResult     chan error
-	Exit              chan error
-	Sy                *syncthing.Syncthing
-	cleaned           chan string
-	hardTerminate     chan error
-	success           bool
-	resetSyncthing    bool
-	inFd              uintptr
-	isTerm            bool
-	stateTerm         *term.State
+	Cancel             context.CancelFunc
+	ShutdownCompleted  chan bool
+	Dev                *model.Dev
+	isOktetoNamespace  bool
+	isSwap             bool
+	isRetry            bool
+	Client             *kubernetes.Clientset
+	RestConfig         *rest.Config
+	Pod                *apiv1.Pod
+	Forwarder          forwarder
+	Disconnect         chan error
+	CommandResult      chan error
+	Exit               chan error
+	Sy                 *syncthing.Syncthing
+	cleaned            chan string
+	hardTerminate      chan error
+	success            bool
+	connected          bool
+	resetSyncthing     bool
+	postReady          string
+	postReadyDir       string
+	postReadyExecuted  bool
+	reconnectNotify    string
+	reconnectNotifyDir string
+	reconnecting       bool
+	lastReconnectHook  chan struct{}
+	hooks              sync.WaitGroup
+	summaryOnExit      string
+	metrics            sessionMetrics
+	serviceForwards    []model.Forward
+	writeEnv           string
+	saveStateFile      string
+	waitFile           string
+	waitFileTimeout    time.Duration
+	waitTimeout        time.Duration
+	activationTimer    *activationTimer
+	waitFileStarted    bool
+	nonInteractive     bool
+	recreateOnChange   bool
+	recreateOnImage    bool
+	attachExisting     bool
+	recreateSecret     bool
+	waitForSyncIdle    time.Duration
+	waitForServices    time.Duration
+	waitNamespaceReady time.Duration
+	dumpPodSpecOnError bool
+	printSSHConfig     bool
+	maxReconnects      int
+	crashLogsLines     int
+	clusterInfo        string
+	devHash            string
+	deactivate         func(context.Context) error
+	showImageDigest    bool
+	pinImage           bool
+	checkImage         bool
+	selectContainer    bool
+	devPath            string
+	inFd               uintptr
+	isTerm             bool
+	stateTerm          *term.State
 }
 
 // Forwarder is an interface for the port-forwarding features
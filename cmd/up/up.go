@@ -28,6 +28,7 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	buildCMD "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/cmd/down"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	k8sClient "github.com/okteto/okteto/pkg/k8s/client"
@@ -43,11 +44,22 @@ import (
 
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 // ReconnectingMessage is the message shown when we are trying to reconnect
 const ReconnectingMessage = "Trying to reconnect to your cluster. File synchronization will automatically resume when the connection improves."
 
+const (
+	reconnectEventDisconnected = "disconnected"
+	reconnectEventReconnected  = "reconnected"
+
+	// reconnectNotifyTimeout is the maximum duration of the command of '--reconnect-notify'
+	reconnectNotifyTimeout = 30 * time.Second
+)
+
+const waitNamespaceReadyInterval = 2 * time.Second
+
 // Up starts a development container
 func Up() *cobra.Command {
 	var devPath string
@@ -57,16 +69,169 @@ func Up() *cobra.Command {
 	var autoDeploy bool
 	var build bool
 	var forcePull bool
+	var pullPolicies []string
 	var reset bool
+	var syncMode string
+	var syncExcludeLarge int
+	var postReady string
+	var reconnectNotify string
+	var showImageDigest bool
+	var pinImage bool
+	var checkImage bool
+	var selectContainer bool
+	var inheritEnv []string
+	var podAnnotations []string
+	var forwardServices []string
+	var printResolvedManifest bool
+	var waitFile string
+	var waitFileTimeout time.Duration
+	var waitTimeout time.Duration
+	var interactive bool
+	var recreateOnConfigChange bool
+	var recreateIfImageChanged bool
+	var attachExisting bool
+	var recreateSecret bool
+	var waitForSyncIdle bool
+	var waitForSyncIdleTimeout time.Duration
+	var waitForServices bool
+	var waitForServicesTimeout time.Duration
+	var waitNamespaceReady bool
+	var waitNamespaceReadyTimeout time.Duration
+	var dumpPodSpecOnError bool
+	var saveStateFile string
+	var writeEnv string
+	var maxReconnects int
+	var crashLogsLines int
+	var failOnWarning bool
+	var noInitContainer bool
+	var printSSHConfig bool
+	var clusterInfoFlag string
+	var summaryOnExitFlag string
 	cmd := &cobra.Command{
-		Use:   "up",
+		Use:   "up [name]",
 		Short: "Activates your development container",
-		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/index.html#up"),
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/index.html#up"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			devName := ""
+			if len(args) > 0 {
+				devName = args[0]
+			}
+
 			if okteto.InDevContainer() {
 				return errors.ErrNotInDevContainer
 			}
 
+			if waitFile != "" && !strings.HasPrefix(waitFile, "/") {
+				return fmt.Errorf("'--wait-file' must be an absolute path")
+			}
+
+			if waitTimeout <= 0 {
+				return fmt.Errorf("'--wait-timeout' must be greater than 0")
+			}
+
+			if waitFileTimeout <= 0 {
+				return fmt.Errorf("'--wait-file-timeout' must be greater than 0")
+			}
+
+			if waitForSyncIdle && waitForSyncIdleTimeout <= 0 {
+				return fmt.Errorf("'--wait-for-sync-idle-timeout' must be greater than 0")
+			}
+
+			if waitForServices && waitForServicesTimeout <= 0 {
+				return fmt.Errorf("'--wait-for-services-timeout' must be greater than 0")
+			}
+
+			if waitNamespaceReady && waitNamespaceReadyTimeout <= 0 {
+				return fmt.Errorf("'--wait-namespace-ready-timeout' must be greater than 0")
+			}
+
+			if maxReconnects < 0 {
+				return fmt.Errorf("'--max-reconnects' must be greater than or equal to 0")
+			}
+
+			if crashLogsLines < 0 {
+				return fmt.Errorf("'--container-logs-on-crash' must be greater than or equal to 0")
+			}
+
+			if syncExcludeLarge < 0 {
+				return fmt.Errorf("'--sync-exclude-large' must be greater than or equal to 0")
+			}
+
+			if recreateSecret && attachExisting {
+				return fmt.Errorf("'--recreate-secret' and '--attach-existing' can't be used together")
+			}
+
+			clusterInfoMode, err := parseClusterInfoFlag(clusterInfoFlag)
+			if err != nil {
+				return err
+			}
+
+			summaryOnExit, err := parseSummaryOnExitFlag(summaryOnExitFlag)
+			if err != nil {
+				return err
+			}
+
+			serviceForwards, err := parseServiceForwards(forwardServices)
+			if err != nil {
+				return err
+			}
+
+			if saveStateFile == "" {
+				saveStateFile = os.Getenv("OKTETO_STATE_FILE")
+			}
+			if saveStateFile != "" {
+				saveStateFile, err = filepath.Abs(saveStateFile)
+				if err != nil {
+					return fmt.Errorf("invalid value for '--save-state-file': %s", err)
+				}
+			}
+
+			if writeEnv != "" {
+				writeEnv, err = filepath.Abs(writeEnv)
+				if err != nil {
+					return fmt.Errorf("invalid value for '--write-env': %s", err)
+				}
+				if err := checkEnvFile(writeEnv); err != nil {
+					return err
+				}
+			}
+
+			projectConfig, err := utils.LoadProjectConfig(devPath)
+			if err != nil {
+				return err
+			}
+			namespace = projectConfig.GetNamespace(namespace)
+			k8sContext = projectConfig.GetContext(k8sContext)
+			autoDeploy = projectConfig.GetAutoDeploy(autoDeploy, cmd.Flags().Changed("deploy"))
+
+			overrides := &devOverrides{
+				forcePull:       forcePull,
+				pullPolicies:    pullPolicies,
+				remote:          remote,
+				autoDeploy:      autoDeploy,
+				syncMode:        syncMode,
+				inheritEnv:      inheritEnv,
+				noInitContainer: noInitContainer,
+				podAnnotations:  podAnnotations,
+			}
+
+			if printResolvedManifest {
+				if err := utils.LoadEnvironment(context.Background(), false); err != nil {
+					return err
+				}
+				dev, err := loadDev(devPath, devName, namespace, k8sContext)
+				if err != nil {
+					return err
+				}
+				if err := loadDevOverrides(dev, overrides); err != nil {
+					return err
+				}
+				if err := projectConfig.LoadForward(dev); err != nil {
+					return err
+				}
+				return printManifest(dev, os.Stdout)
+			}
+
 			u := utils.UpgradeAvailable()
 			if len(u) > 0 {
 				warningFolder := filepath.Join(config.GetOktetoHome(), ".warnings")
@@ -97,7 +262,7 @@ func Up() *cobra.Command {
 
 			checkLocalWatchesConfiguration()
 
-			if autoDeploy {
+			if autoDeploy && cmd.Flags().Changed("deploy") {
 				log.Warning(`The 'deploy' flag is deprecated and will be removed in a future release.
     Set the 'autocreate' field in your okteto manifest to get the same behavior.
     More information is available here: https://okteto.com/docs/reference/cli#up`)
@@ -108,21 +273,58 @@ func Up() *cobra.Command {
 				return err
 			}
 
-			dev, err := loadDevOrInit(namespace, k8sContext, devPath)
+			dev, err := loadDevOrInit(namespace, k8sContext, devPath, devName)
 			if err != nil {
 				return err
 			}
 
-			if err := loadDevOverrides(dev, forcePull, remote, autoDeploy); err != nil {
+			if err := loadDevOverrides(dev, overrides); err != nil {
 				return err
 			}
 
+			if err := projectConfig.LoadForward(dev); err != nil {
+				return err
+			}
+
+			if printSSHConfig && !dev.RemoteModeEnabled() {
+				return fmt.Errorf("'--print-ssh-config' requires remote mode, which is disabled by OKTETO_EXECUTE_SSH=false")
+			}
+
+			if err := checkWarnings(dev.Warnings, failOnWarning); err != nil {
+				return err
+			}
+
+			if !dev.InitContainer.IsEnabled() && dev.PersistentVolumeEnabled() {
+				log.Yellow("The init container is disabled: the persistent volume won't be initialized with the content of your image and the first synchronization will upload all your files")
+			}
+
 			log.ConfigureFileLogger(config.GetDeploymentHome(dev.Namespace, dev.Name), config.VersionString)
 
+			sessionStore, err := utils.LoadSessionStore(dev.Context, dev.Namespace)
+			if err != nil {
+				return err
+			}
+
+			if err := config.SaveSession(ctx, sessionStore, devPath, dev); err != nil {
+				log.Infof("failed to save the session: %s", err.Error())
+			} else if writeEnv != "" {
+				if err := config.SetSessionEnvFile(ctx, sessionStore, devPath, dev.ManifestKey(), writeEnv); err != nil {
+					log.Infof("failed to save the '--write-env' file in the session: %s", err.Error())
+				}
+			}
+
 			if err := checkStignoreConfiguration(dev); err != nil {
 				log.Infof("failed to check '.stignore' configuration: %s", err.Error())
 			}
 
+			if err := addGitignorePatterns(dev); err != nil {
+				return err
+			}
+
+			if err := addLargeFilePatterns(dev, syncExcludeLarge); err != nil {
+				return err
+			}
+
 			if err := addStignoreSecrets(dev); err != nil {
 				return err
 			}
@@ -132,9 +334,57 @@ func Up() *cobra.Command {
 			}
 
 			up := &upContext{
-				Dev:            dev,
-				Exit:           make(chan error, 1),
-				resetSyncthing: reset,
+				Dev:                dev,
+				Exit:               make(chan error, 1),
+				resetSyncthing:     reset,
+				postReady:          postReady,
+				reconnectNotify:    reconnectNotify,
+				showImageDigest:    showImageDigest,
+				pinImage:           pinImage,
+				checkImage:         checkImage,
+				selectContainer:    selectContainer,
+				devPath:            devPath,
+				waitFile:           waitFile,
+				waitFileTimeout:    waitFileTimeout,
+				waitTimeout:        waitTimeout,
+				nonInteractive:     !interactive,
+				recreateOnChange:   recreateOnConfigChange,
+				recreateOnImage:    recreateIfImageChanged,
+				attachExisting:     attachExisting,
+				recreateSecret:     recreateSecret,
+				clusterInfo:        clusterInfoMode,
+				summaryOnExit:      summaryOnExit,
+				serviceForwards:    serviceForwards,
+				writeEnv:           writeEnv,
+				saveStateFile:      saveStateFile,
+				dumpPodSpecOnError: dumpPodSpecOnError,
+				printSSHConfig:     printSSHConfig,
+				maxReconnects:      maxReconnects,
+				crashLogsLines:     crashLogsLines,
+			}
+			if waitForSyncIdle {
+				up.waitForSyncIdle = waitForSyncIdleTimeout
+			}
+			if waitForServices {
+				up.waitForServices = waitForServicesTimeout
+			}
+			if waitNamespaceReady {
+				up.waitNamespaceReady = waitNamespaceReadyTimeout
+			}
+			if up.nonInteractive {
+				up.deactivate = up.deactivateDevContainer
+			}
+			if postReady != "" {
+				up.postReadyDir, err = filepath.Abs(filepath.Dir(devPath))
+				if err != nil {
+					return err
+				}
+			}
+			if reconnectNotify != "" {
+				up.reconnectNotifyDir, err = filepath.Abs(filepath.Dir(devPath))
+				if err != nil {
+					return err
+				}
 			}
 			up.inFd, up.isTerm = term.GetFdInfo(os.Stdin)
 			if up.isTerm {
@@ -160,12 +410,66 @@ func Up() *cobra.Command {
 	cmd.Flags().MarkHidden("deploy")
 	cmd.Flags().BoolVarP(&build, "build", "", false, "build on-the-fly the dev image using the info provided by the 'build' okteto manifest field")
 	cmd.Flags().BoolVarP(&forcePull, "pull", "", false, "force dev image pull")
+	cmd.Flags().StringArrayVarP(&pullPolicies, "pull-policy-per-service", "", []string{}, "image pull policy of a service, like 'worker=Always'. It overrides the manifest and '--pull' for that service (can be set more than once)")
 	cmd.Flags().BoolVarP(&reset, "reset", "", false, "reset the file synchronization database")
+	cmd.Flags().BoolVarP(&showImageDigest, "show-image-digest", "", false, "show the digest of the image running in the development container")
+	cmd.Flags().BoolVarP(&pinImage, "pin-image", "", false, "pin the image of the okteto manifest to the digest running in the development container")
+	cmd.Flags().BoolVarP(&checkImage, "check-image", "", false, "warn when the image of the okteto manifest comes from a different repository than the image of the deployment, asking for confirmation in interactive mode")
+	cmd.Flags().BoolVarP(&selectContainer, "interactive-select-container", "", false, "ask for the container to replace with your development container when the okteto manifest doesn't set 'container' and the deployment has several containers. Only in interactive terminals")
+	cmd.Flags().StringVarP(&postReady, "post-ready", "", "", "local command to run once the development container is ready")
+	cmd.Flags().StringVarP(&waitFile, "wait-file", "", "", "path of a file in the development container whose existence marks the development container as ready")
+	cmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "", 5*time.Minute, "maximum time to activate the development container and synchronize your files before giving up")
+	cmd.Flags().DurationVarP(&waitFileTimeout, "wait-file-timeout", "", 5*time.Minute, "maximum time to wait for the file of '--wait-file' to exist")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "", true, "run the development command in an interactive terminal. When false, 'okteto up' runs the command, deactivates the development container and exits with the exit code of the command")
+	cmd.Flags().BoolVarP(&recreateOnConfigChange, "recreate-on-config-change", "", true, "recreate the development container created by 'okteto up' when the okteto manifest has changed since it was created. Use '--recreate-on-config-change=false' to keep the existing development container")
+	cmd.Flags().BoolVarP(&recreateIfImageChanged, "recreate-if-image-changed", "", false, "recreate the development container pulling its image when the image tag points to a new digest in the registry. The check is skipped if the registry doesn't return the image digest, as it happens with registries other than the okteto registry")
+	cmd.Flags().BoolVarP(&attachExisting, "attach-existing", "", false, "reuse the active development container without deploying it again. It fails if the development container is not active or the okteto manifest has changed")
+	cmd.Flags().BoolVarP(&recreateSecret, "recreate-secret", "", false, "delete and recreate the syncthing secret of the development container, and restart the development container to load it")
+	cmd.Flags().BoolVarP(&waitForSyncIdle, "wait-for-sync-idle", "", false, "wait for the pending local file changes to be synchronized before exiting once the development command finishes")
+	cmd.Flags().DurationVarP(&waitForSyncIdleTimeout, "wait-for-sync-idle-timeout", "", time.Minute, "maximum time to wait for the file synchronization of '--wait-for-sync-idle' to be idle")
+	cmd.Flags().BoolVarP(&waitForServices, "wait-for-services", "", false, "wait for the services of the okteto manifest to have a ready pod before running the development command")
+	cmd.Flags().DurationVarP(&waitForServicesTimeout, "wait-for-services-timeout", "", 5*time.Minute, "maximum time to wait for the services of '--wait-for-services' to be ready")
+	cmd.Flags().BoolVarP(&waitNamespaceReady, "wait-namespace-ready", "", false, "wait for the namespace to exist and allow okteto operations before activating the development container, useful right after creating it")
+	cmd.Flags().DurationVarP(&waitNamespaceReadyTimeout, "wait-namespace-ready-timeout", "", time.Minute, "maximum time to wait for the namespace of '--wait-namespace-ready' to be ready")
+	cmd.Flags().StringVarP(&writeEnv, "write-env", "", "", "path of a dotenv file where the local URLs of the forwards are written, like 'API_URL=http://localhost:8080'. It is updated on reconnection and removed by 'okteto down'")
+	cmd.Flags().StringVarP(&saveStateFile, "save-state-file", "", "", "path of a file where the state of 'okteto up' is also written: activating, starting, attaching, pulling, startingSync, synchronizing, ready or failed. It can be set with the OKTETO_STATE_FILE environment variable too")
+	cmd.Flags().BoolVarP(&dumpPodSpecOnError, "dump-pod-spec-on-error", "", false, "save the spec of the development pod and the recent events of the namespace when the development container fails to start")
+	cmd.Flags().StringVarP(&reconnectNotify, "reconnect-notify", "", "", "local command to run when the connection to the development container is lost and when it is restored. The event, 'disconnected' or 'reconnected', is passed as its last argument")
+	cmd.Flags().IntVarP(&maxReconnects, "max-reconnects", "", 0, "maximum number of consecutive reconnects to the development container after losing the connection before giving up (0 means unlimited)")
+	cmd.Flags().IntVarP(&crashLogsLines, "container-logs-on-crash", "", 0, "print the last lines of the logs of the development container when the command fails. Use '--container-logs-on-crash=N' to print N lines")
+	cmd.Flags().Lookup("container-logs-on-crash").NoOptDefVal = defaultCrashLogsLines
+	cmd.Flags().BoolVarP(&noInitContainer, "no-init-container", "", false, "don't initialize the persistent volume with the content of the image of the development container. The first synchronization uploads all your files")
+	cmd.Flags().BoolVarP(&failOnWarning, "fail-on-warning", "", false, "fail before activating the development container if the okteto manifest has warnings, like deprecated fields or privileged ports")
+	cmd.Flags().BoolVarP(&printSSHConfig, "print-ssh-config", "", false, "print the SSH config entry of the development container once remote mode is established")
+	cmd.Flags().StringVarP(&clusterInfoFlag, "cluster-info", "", "", "print the API server, version, namespace and node count of the cluster before activating the development container. Use '--cluster-info=only' to exit after printing it")
+	cmd.Flags().Lookup("cluster-info").NoOptDefVal = clusterInfoEnabled
+	cmd.Flags().StringVarP(&summaryOnExitFlag, "summary-on-exit", "", "", "print the duration, bytes synchronized, number of reconnects, final state and pod of the session when 'okteto up' exits. Use '--summary-on-exit=json' to print it as json")
+	cmd.Flags().Lookup("summary-on-exit").NoOptDefVal = summaryText
+	cmd.Flags().IntVarP(&syncExcludeLarge, "sync-exclude-large", "", 0, "exclude from the file synchronization the files larger than the given size in megabytes (0 means disabled)")
+	cmd.Flags().StringVarP(&syncMode, "sync-mode", "", "", "file synchronization mode once the initial sync is completed: 'sendreceive' or 'sendonly'")
+	cmd.Flags().BoolVarP(&printResolvedManifest, "print-manifest", "", false, "print the resolved okteto manifest and exit without activating the development container")
+	cmd.Flags().StringArrayVarP(&podAnnotations, "pod-annotation", "", []string{}, "annotation of the pod of the development container, like 'sidecar.istio.io/inject=false'. It isn't set in the deployment (can be set more than once)")
+	cmd.Flags().StringArrayVarP(&inheritEnv, "inherit-env", "", []string{}, "local environment variable to inject in the development container, glob patterns like 'AWS_*' are supported. Glob patterns skip variables whose name contains TOKEN, SECRET, PASSWORD, KEY, CREDENTIAL or AUTH (can be set more than once)")
+	cmd.Flags().StringArrayVarP(&forwardServices, "forward-service", "", []string{}, "forward a local port to a service of the namespace during the session, like 'name:localPort[:remotePort]'. The first port of the service is used if the remote port isn't set (can be set more than once)")
 	return cmd
 }
 
-func loadDevOrInit(namespace, k8sContext, devPath string) (*model.Dev, error) {
-	dev, err := utils.LoadDev(devPath, namespace, k8sContext)
+// checkWarnings displays the warnings of the okteto manifest and fails if failOnWarning is set
+func checkWarnings(warnings []string, failOnWarning bool) error {
+	for _, w := range warnings {
+		log.Yellow("%s", w)
+	}
+	if failOnWarning && len(warnings) > 0 {
+		return errors.UserError{
+			E:    fmt.Errorf("your okteto manifest has %d warning(s)", len(warnings)),
+			Hint: "Fix the warnings above or run 'okteto up' without '--fail-on-warning'",
+		}
+	}
+	return nil
+}
+
+func loadDevOrInit(namespace, k8sContext, devPath, devName string) (*model.Dev, error) {
+	dev, err := loadDev(devPath, devName, namespace, k8sContext)
 
 	if err == nil {
 		return dev, nil
@@ -186,12 +490,46 @@ func loadDevOrInit(namespace, k8sContext, devPath string) (*model.Dev, error) {
 	}
 
 	log.Success(fmt.Sprintf("okteto manifest (%s) created", devPath))
-	return utils.LoadDev(devPath, namespace, k8sContext)
+	return loadDev(devPath, devName, namespace, k8sContext)
 }
 
-func loadDevOverrides(dev *model.Dev, forcePull bool, remote int, autoDeploy bool) error {
-	if remote > 0 {
-		dev.RemotePort = remote
+// loadDev loads the development environment 'devName' of the okteto manifest
+func loadDev(devPath, devName, namespace, k8sContext string) (*model.Dev, error) {
+	devs, err := utils.LoadDevs(devPath, namespace, k8sContext)
+	if err != nil {
+		return nil, err
+	}
+	return selectDev(devs, devName, term.IsTerminal(os.Stdin.Fd()))
+}
+
+// devOverrides are the values of the 'okteto up' flags that override the okteto manifest
+type devOverrides struct {
+	forcePull       bool
+	pullPolicies    []string
+	remote          int
+	autoDeploy      bool
+	syncMode        string
+	inheritEnv      []string
+	noInitContainer bool
+	podAnnotations  []string
+}
+
+func loadDevOverrides(dev *model.Dev, o *devOverrides) error {
+	if o.remote > 0 {
+		dev.RemotePort = o.remote
+	}
+
+	if o.syncMode != "" {
+		if err := model.ValidateSyncMode(o.syncMode); err != nil {
+			return err
+		}
+		dev.Sync.Mode = o.syncMode
+	}
+
+	if len(o.inheritEnv) > 0 {
+		if err := inheritEnvVars(dev, o.inheritEnv, os.Environ()); err != nil {
+			return err
+		}
 	}
 
 	if dev.RemoteModeEnabled() {
@@ -203,13 +541,26 @@ func loadDevOverrides(dev *model.Dev, forcePull bool, remote int, autoDeploy boo
 	}
 
 	if !dev.Autocreate {
-		dev.Autocreate = autoDeploy
+		dev.Autocreate = o.autoDeploy
 	}
 
-	if forcePull {
+	if o.forcePull {
 		dev.LoadForcePull()
 	}
 
+	if err := dev.LoadServicePullPolicies(o.pullPolicies); err != nil {
+		return err
+	}
+
+	if err := dev.LoadPodAnnotations(o.podAnnotations); err != nil {
+		return err
+	}
+
+	if o.noInitContainer {
+		enabled := false
+		dev.InitContainer.Enabled = &enabled
+	}
+
 	dev.Username = okteto.GetUsername()
 	if registryURL, err := okteto.GetRegistry(); err == nil {
 		dev.RegistryURL = registryURL
@@ -231,7 +582,20 @@ func (up *upContext) start(autoDeploy, build bool) error {
 	}
 
 	ctx := context.Background()
-	ns, err := namespaces.Get(ctx, up.Dev.Namespace, up.Client)
+	if up.clusterInfo != "" {
+		info, err := getClusterInfo(ctx, up.Client, up.Dev.Context, up.RestConfig.Host, up.Dev.Namespace)
+		if err != nil {
+			return err
+		}
+		printClusterInfo(info, os.Stdout)
+		if up.clusterInfo == clusterInfoOnly {
+			return nil
+		}
+	}
+
+	up.checkClockSkew(ctx)
+
+	ns, err := up.getNamespace(ctx)
 	if err != nil {
 		return err
 	}
@@ -242,6 +606,14 @@ func (up *upContext) start(autoDeploy, build bool) error {
 
 	up.isOktetoNamespace = namespaces.IsOktetoNamespace(ns)
 
+	if err := checkLimitRanges(ctx, up.Dev, up.Client); err != nil {
+		return err
+	}
+
+	if err := up.addServiceForwards(ctx); err != nil {
+		return err
+	}
+
 	if up.Dev.Divert != nil {
 		if err := diverts.Create(ctx, up.Dev, up.isOktetoNamespace, up.Client); err != nil {
 			return err
@@ -260,31 +632,107 @@ func (up *upContext) start(autoDeploy, build bool) error {
 
 	analytics.TrackUp(true, up.Dev.Name, up.getInteractive(), len(up.Dev.Services) == 0, up.isSwap, up.Dev.Divert != nil)
 
+	up.metrics.start = time.Now()
 	go up.activateLoop(autoDeploy, build)
 
+	return up.waitUntilExit(ctx, stop)
+}
+
+// getNamespace returns the namespace of the development container, waiting for it to be ready when '--wait-namespace-ready' is set
+func (up *upContext) getNamespace(ctx context.Context) (*apiv1.Namespace, error) {
+	if up.waitNamespaceReady <= 0 {
+		return namespaces.Get(ctx, up.Dev.Namespace, up.Client)
+	}
+
+	spinner := utils.NewSpinner(fmt.Sprintf("Waiting for namespace '%s' to be ready...", up.Dev.Namespace))
+	spinner.Start()
+	defer spinner.Stop()
+
+	return namespaces.WaitUntilReady(ctx, up.Dev.Namespace, up.Client, waitNamespaceReadyInterval, up.waitNamespaceReady)
+}
+
+// waitUntilExit blocks execution until a stop signal is sent or the activate loop exits.
+// The development container is deactivated afterwards when running a non-interactive command
+func (up *upContext) waitUntilExit(ctx context.Context, stop chan os.Signal) error {
+	var err error
 	select {
 	case <-stop:
 		log.Infof("CTRL+C received, starting shutdown sequence")
 		up.shutdown()
 		fmt.Println()
-	case err := <-up.Exit:
+	case err = <-up.Exit:
 		if err != nil {
 			log.Infof("exit signal received due to error: %s", err)
+		}
+	}
+
+	if up.deactivate != nil {
+		if dErr := up.deactivate(ctx); dErr != nil {
+			log.Infof("failed to deactivate the development container: %s", dErr)
+			log.Yellow("Couldn't deactivate your development container, run 'okteto down' to deactivate it")
+		}
+	}
+
+	up.printSessionSummary(err, os.Stdout)
+	return err
+}
+
+// deactivateDevContainer restores the original deployment, the same as 'okteto down' does
+func (up *upContext) deactivateDevContainer(ctx context.Context) error {
+	spinner := utils.NewSpinner("Deactivating your development container...")
+	spinner.Start()
+	defer spinner.Stop()
+
+	if up.Dev.Divert != nil {
+		if err := diverts.Delete(ctx, up.Dev, up.Client); err != nil {
 			return err
 		}
 	}
+
+	d, err := deployments.Get(ctx, up.Dev, up.Dev.Namespace, up.Client)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	trList, err := deployments.GetTranslations(ctx, up.Dev, d, false, up.Client)
+	if err != nil {
+		return err
+	}
+
+	if err := down.Run(up.Dev, d, trList, true, up.Client); err != nil {
+		return err
+	}
+
+	log.Success("Development container deactivated")
 	return nil
 }
 
 // activateLoop activates the development container in a retry loop
 func (up *upContext) activateLoop(autoDeploy, build bool) {
+	defer func() {
+		if !up.activationTimedOut() {
+			config.DeleteStateFile(up.Dev, up.saveStateFile)
+		}
+	}()
+
+	up.Exit <- up.retryActivate(func() error {
+		err := up.activate(autoDeploy, build)
+		if err != nil && up.activationTimedOut() {
+			return up.getActivationTimeoutError()
+		}
+		return err
+	})
+}
+
+// retryActivate calls activate until it succeeds or fails with a non-retryable error.
+// It gives up after more than '--max-reconnects' consecutive reconnects. The count is reset once a reconnect succeeds
+func (up *upContext) retryActivate(activate func() error) error {
 	isTransientError := false
 	t := time.NewTicker(1 * time.Second)
 	iter := 0
+	reconnects := 0
 	defer t.Stop()
 
-	defer config.DeleteStateFile(up.Dev)
-
 	for {
 		if up.isRetry || isTransientError {
 			log.Infof("waiting for shutdown sequence to finish")
@@ -292,32 +740,43 @@ func (up *upContext) activateLoop(autoDeploy, build bool) {
 			if iter == 0 {
 				log.Yellow("Connection lost to your development container, reconnecting...")
 			}
+			up.notifyDisconnected()
 			iter++
 			iter = iter % 10
 			if isTransientError {
 				<-t.C
 			}
 		}
-		err := up.activate(autoDeploy, build)
-		if err != nil {
-			log.Infof("activate failed with: %s", err)
+		up.connected = false
+		err := activate()
+		if err == nil {
+			return nil
+		}
 
-			if err == errors.ErrLostSyncthing {
-				isTransientError = false
-				iter = 0
-				continue
-			}
+		log.Infof("activate failed with: %s", err)
+		if err != errors.ErrLostSyncthing && !errors.IsTransient(err) {
+			return err
+		}
 
-			if errors.IsTransient(err) {
-				isTransientError = true
-				continue
+		if up.connected {
+			reconnects = 0
+		}
+		reconnects++
+		up.recordReconnect()
+		if up.maxReconnects > 0 && reconnects > up.maxReconnects {
+			return errors.UserError{
+				E:    fmt.Errorf("lost the connection to your development container after %d reconnects", up.maxReconnects),
+				Hint: "Check the status of your development container and your network connection, or increase the value of '--max-reconnects'",
 			}
+		}
 
-			up.Exit <- err
-			return
+		if err == errors.ErrLostSyncthing {
+			isTransientError = false
+			iter = 0
+			continue
 		}
-		up.Exit <- nil
-		return
+
+		isTransientError = true
 	}
 }
 
@@ -362,11 +821,20 @@ func (up *upContext) waitUntilExitOrInterrupt() error {
 		select {
 		case err := <-up.CommandResult:
 			fmt.Println()
+			if err == nil || !errors.IsTransient(err) {
+				up.waitUntilSyncIdle()
+			}
 			if err != nil {
 				log.Infof("command failed: %s", err)
 				if errors.IsTransient(err) {
 					return err
 				}
+				if up.Client != nil {
+					up.printCrashLogs(up.Client, os.Stdout)
+				}
+				if code, ok := errors.GetExitCode(err); ok && up.nonInteractive {
+					return errors.ExitCodeError{Code: code}
+				}
 				return errors.CommandError{
 					E:      errors.ErrCommandFailed,
 					Reason: err,
@@ -385,6 +853,26 @@ func (up *upContext) waitUntilExitOrInterrupt() error {
 	}
 }
 
+// waitUntilSyncIdle waits for the pending local changes to be synchronized when '--wait-for-sync-idle' is set
+func (up *upContext) waitUntilSyncIdle() {
+	if up.waitForSyncIdle <= 0 || up.Sy == nil {
+		return
+	}
+
+	spinner := utils.NewSpinner("Synchronizing your pending file changes...")
+	spinner.Start()
+	defer spinner.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), up.waitForSyncIdle)
+	defer cancel()
+	if err := up.Sy.WaitForIdle(ctx); err != nil {
+		log.Infof("failed to wait for syncthing to be idle: %s", err)
+		log.Yellow("Your pending file changes might not be synchronized")
+		return
+	}
+	log.Info("pending file changes synchronized")
+}
+
 func (up *upContext) buildDevImage(ctx context.Context, d *appsv1.Deployment, create bool) error {
 	if _, err := os.Stat(up.Dev.Image.Dockerfile); err != nil {
 		return errors.UserError{
@@ -506,6 +994,12 @@ func (up *upContext) shutdown() {
 	}
 
 	if up.Sy != nil {
+		if up.summaryOnExit != "" {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			up.recordTransferredBytes(ctx)
+			cancel()
+		}
+
 		log.Infof("stopping syncthing")
 		if err := up.Sy.SoftTerminate(); err != nil {
 			log.Infof("failed to stop syncthing during shutdown: %s", err.Error())
@@ -528,26 +1022,26 @@ func restoreCursor() {
 	}
 }
 
-func printDisplayContext(dev *model.Dev, divertURL string) {
+func printDisplayContext(dev *model.Dev, forwards []model.Forward, divertURL string) {
 	if dev.Context != "" {
 		log.Println(fmt.Sprintf("    %s   %s", log.BlueString("Context:"), dev.Context))
 	}
 	log.Println(fmt.Sprintf("    %s %s", log.BlueString("Namespace:"), dev.Namespace))
 	log.Println(fmt.Sprintf("    %s      %s", log.BlueString("Name:"), dev.Name))
 
-	if len(dev.Forward) > 0 {
-		if dev.Forward[0].Service {
-			log.Println(fmt.Sprintf("    %s   %d -> %s:%d", log.BlueString("Forward:"), dev.Forward[0].Local, dev.Forward[0].ServiceName, dev.Forward[0].Remote))
+	if len(forwards) > 0 {
+		if forwards[0].Service {
+			log.Println(fmt.Sprintf("    %s   %d -> %s:%d", log.BlueString("Forward:"), forwards[0].Local, forwards[0].ServiceName, forwards[0].Remote))
 		} else {
-			log.Println(fmt.Sprintf("    %s   %d -> %d", log.BlueString("Forward:"), dev.Forward[0].Local, dev.Forward[0].Remote))
+			log.Println(fmt.Sprintf("    %s   %d -> %d", log.BlueString("Forward:"), forwards[0].Local, forwards[0].Remote))
 		}
 
-		for i := 1; i < len(dev.Forward); i++ {
-			if dev.Forward[i].Service {
-				log.Println(fmt.Sprintf("           %d -> %s:%d", dev.Forward[i].Local, dev.Forward[i].ServiceName, dev.Forward[i].Remote))
+		for i := 1; i < len(forwards); i++ {
+			if forwards[i].Service {
+				log.Println(fmt.Sprintf("           %d -> %s:%d", forwards[i].Local, forwards[i].ServiceName, forwards[i].Remote))
 				continue
 			}
-			log.Println(fmt.Sprintf("               %d -> %d", dev.Forward[i].Local, dev.Forward[i].Remote))
+			log.Println(fmt.Sprintf("               %d -> %d", forwards[i].Local, forwards[i].Remote))
 		}
 	}
 
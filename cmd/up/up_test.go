@@ -14,11 +14,19 @@
 package up
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
 )
 
 func Test_waitUntilExitOrInterrupt(t *testing.T) {
@@ -47,6 +55,188 @@ func Test_waitUntilExitOrInterrupt(t *testing.T) {
 	}
 }
 
+func Test_waitUntilExitOrInterruptWaitForSyncIdle(t *testing.T) {
+	var pending int32 = 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/db/scan":
+		case "/rest/db/status":
+			fmt.Fprint(w, `{"state": "idle"}`)
+		case "/rest/db/completion":
+			needItems := atomic.LoadInt32(&pending)
+			if needItems > 0 {
+				atomic.AddInt32(&pending, -1)
+			}
+			fmt.Fprintf(w, `{"needBytes": %d, "needItems": %d}`, needItems*1024, needItems)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	up := upContext{waitForSyncIdle: 10 * time.Second}
+	up.Sy = &syncthing.Syncthing{
+		GUIAddress: strings.TrimPrefix(server.URL, "http://"),
+		Client:     syncthing.NewAPIClient(),
+		Folders:    []*syncthing.Folder{{Name: "1", LocalPath: "/local", RemotePath: "/app"}},
+	}
+	up.CommandResult = make(chan error, 1)
+	up.CommandResult <- nil
+	if err := up.waitUntilExitOrInterrupt(); err != nil {
+		t.Errorf("exited with error instead of nil: %s", err)
+	}
+	if p := atomic.LoadInt32(&pending); p != 0 {
+		t.Errorf("exited with %d pending changes", p)
+	}
+}
+
+type fakeExitError struct {
+	code int
+}
+
+func (e fakeExitError) Error() string {
+	return fmt.Sprintf("command terminated with exit code %d", e.code)
+}
+
+func (e fakeExitError) ExitStatus() int {
+	return e.code
+}
+
+func Test_waitUntilExitOrInterruptNonInteractive(t *testing.T) {
+	up := upContext{nonInteractive: true}
+	up.CommandResult = make(chan error, 1)
+	up.CommandResult <- fakeExitError{code: 3}
+	err := up.waitUntilExitOrInterrupt()
+	eErr, ok := err.(errors.ExitCodeError)
+	if !ok {
+		t.Fatalf("expected an exit code error, got %v", err)
+	}
+	if eErr.Code != 3 {
+		t.Errorf("expected exit code 3, got %d", eErr.Code)
+	}
+
+	up.CommandResult <- fmt.Errorf("custom-error")
+	err = up.waitUntilExitOrInterrupt()
+	if _, ok := err.(errors.CommandError); !ok {
+		t.Errorf("didn't translate the error: %s", err)
+	}
+}
+
+func Test_waitUntilExit(t *testing.T) {
+	var tests = []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "success",
+			err:  nil,
+		},
+		{
+			name: "exit-code",
+			err:  errors.ExitCodeError{Code: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deactivated := false
+			up := upContext{
+				Exit:           make(chan error, 1),
+				nonInteractive: true,
+				deactivate: func(context.Context) error {
+					deactivated = true
+					return nil
+				},
+			}
+			up.Exit <- tt.err
+
+			err := up.waitUntilExit(context.Background(), make(chan os.Signal, 1))
+			if err != tt.err {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if !deactivated {
+				t.Errorf("the development container wasn't deactivated")
+			}
+		})
+	}
+}
+
+func Test_retryActivate(t *testing.T) {
+	var tests = []struct {
+		name           string
+		maxReconnects  int
+		failures       int
+		connectedEvery int
+		expectedCalls  int
+		expectErr      bool
+	}{
+		{
+			name:          "unlimited",
+			maxReconnects: 0,
+			failures:      20,
+			expectedCalls: 21,
+		},
+		{
+			name:          "reconnected-within-limit",
+			maxReconnects: 3,
+			failures:      3,
+			expectedCalls: 4,
+		},
+		{
+			name:          "limit-exceeded",
+			maxReconnects: 3,
+			failures:      20,
+			expectedCalls: 4,
+			expectErr:     true,
+		},
+		{
+			name:           "reset-after-reconnect",
+			maxReconnects:  2,
+			failures:       10,
+			connectedEvery: 2,
+			expectedCalls:  11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := &upContext{
+				maxReconnects:     tt.maxReconnects,
+				ShutdownCompleted: make(chan bool, 1),
+			}
+
+			calls := 0
+			err := up.retryActivate(func() error {
+				calls++
+				up.isRetry = true
+				up.ShutdownCompleted <- true
+				if tt.connectedEvery > 0 && calls%tt.connectedEvery == 0 {
+					up.connected = true
+				}
+				if calls <= tt.failures {
+					return errors.ErrLostSyncthing
+				}
+				return nil
+			})
+
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d calls to activate, got %d", tt.expectedCalls, calls)
+			}
+
+			if tt.expectErr {
+				if _, ok := err.(errors.UserError); !ok {
+					t.Fatalf("expected a user error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 func Test_printDisplayContext(t *testing.T) {
 	var tests = []struct {
 		name string
@@ -95,8 +285,51 @@ func Test_printDisplayContext(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			printDisplayContext(tt.dev, "")
+			printDisplayContext(tt.dev, tt.dev.Forward, "")
 		})
 	}
 
 }
+
+func Test_checkWarnings(t *testing.T) {
+	var tests = []struct {
+		name          string
+		manifest      string
+		failOnWarning bool
+		expectErr     bool
+	}{
+		{
+			name:          "warnings",
+			manifest:      "name: deployment\nhealthchecks: true",
+			failOnWarning: false,
+			expectErr:     false,
+		},
+		{
+			name:          "warnings-fail-on-warning",
+			manifest:      "name: deployment\nhealthchecks: true",
+			failOnWarning: true,
+			expectErr:     true,
+		},
+		{
+			name:          "no-warnings-fail-on-warning",
+			manifest:      "name: deployment",
+			failOnWarning: true,
+			expectErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := model.Read([]byte(tt.manifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = checkWarnings(dev.Warnings, tt.failOnWarning)
+			if tt.expectErr && err == nil {
+				t.Error("didn't get the expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+		})
+	}
+}
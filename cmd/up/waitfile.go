@@ -0,0 +1,102 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const waitFileInterval = 1 * time.Second
+
+// fileExistsFunc returns true if the file exists in the development container
+type fileExistsFunc func(ctx context.Context, path string) (bool, error)
+
+// waitForReadyFile marks the development container as ready once the file of the '--wait-file' flag exists
+func (up *upContext) waitForReadyFile(ctx context.Context) {
+	log.Infof("waiting for '%s' to exist in the development container", up.waitFile)
+	err := waitForFile(ctx, up.fileExists, up.waitFile, waitFileInterval, up.waitFileTimeout)
+	if err == nil {
+		log.Infof("'%s' exists in the development container", up.waitFile)
+		err = up.setReady(ctx)
+	}
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+
+	select {
+	case up.Disconnect <- err:
+	case <-ctx.Done():
+	}
+}
+
+// fileExists checks if a file exists in the development container
+func (up *upContext) fileExists(ctx context.Context, path string) (bool, error) {
+	var out bytes.Buffer
+	cmd := fmt.Sprintf("[ -e %s ] && echo yes || echo no", shellescape.Quote(path))
+	err := exec.Exec(
+		ctx,
+		up.Client,
+		up.RestConfig,
+		up.Dev.Namespace,
+		up.Pod.Name,
+		up.Dev.Container,
+		false,
+		strings.NewReader(""),
+		&out,
+		os.Stderr,
+		[]string{"sh", "-c", cmd},
+	)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out.String()) == "yes", nil
+}
+
+// waitForFile checks every interval if the file exists until it does or the timeout expires
+func waitForFile(ctx context.Context, exists fileExistsFunc, path string, interval, timeout time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+
+	for {
+		ok, err := exists(ctx, path)
+		if err != nil {
+			log.Infof("failed to check if '%s' exists: %s", path, err)
+		} else if ok {
+			return nil
+		}
+
+		select {
+		case <-t.C:
+		case <-to.C:
+			return errors.UserError{
+				E:    fmt.Errorf("'%s' wasn't created in your development container after %s", path, timeout),
+				Hint: "Check the logs of your development command or increase the value of '--wait-file-timeout'",
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
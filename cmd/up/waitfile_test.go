@@ -0,0 +1,86 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+)
+
+func Test_waitForFile(t *testing.T) {
+	var tests = []struct {
+		name      string
+		results   []bool
+		errs      []error
+		expectErr bool
+	}{
+		{
+			name:    "exists",
+			results: []bool{true},
+			errs:    []error{nil},
+		},
+		{
+			name:    "missing-then-present",
+			results: []bool{false, false, true},
+			errs:    []error{nil, nil, nil},
+		},
+		{
+			name:    "exec-error-then-present",
+			results: []bool{false, true},
+			errs:    []error{fmt.Errorf("connection reset"), nil},
+		},
+		{
+			name:      "timeout",
+			results:   []bool{false},
+			errs:      []error{nil},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			exists := func(_ context.Context, path string) (bool, error) {
+				if path != "/tmp/ready" {
+					t.Fatalf("unexpected path '%s'", path)
+				}
+				i := calls
+				if i >= len(tt.results) {
+					i = len(tt.results) - 1
+				}
+				calls++
+				return tt.results[i], tt.errs[i]
+			}
+
+			err := waitForFile(context.Background(), exists, "/tmp/ready", 10*time.Millisecond, 200*time.Millisecond)
+			if tt.expectErr {
+				if _, ok := err.(errors.UserError); !ok {
+					t.Fatalf("expected a user error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if calls != len(tt.results) {
+				t.Errorf("expected %d checks, got %d", len(tt.results), calls)
+			}
+		})
+	}
+}
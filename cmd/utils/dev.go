@@ -16,11 +16,12 @@ package utils
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/joho/godotenv"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/client"
 	"github.com/okteto/okteto/pkg/log"
@@ -32,29 +33,191 @@ const (
 	//DefaultDevManifest default okteto manifest file
 	DefaultDevManifest   = "okteto.yml"
 	secondaryDevManifest = "okteto.yaml"
+
+	// namespaceFromNameEnvVar derives the namespace from the name of the okteto manifest when it isn't specified
+	namespaceFromNameEnvVar = "OKTETO_NAMESPACE_FROM_NAME"
+	maxNamespaceLength      = 63
 )
 
-//LoadDev loads an okteto manifest checking "yml" and "yaml"
-func LoadDev(devPath, namespace, k8sContext string) (*model.Dev, error) {
-	if !model.FileExists(devPath) {
-		if devPath == DefaultDevManifest {
-			if model.FileExists(secondaryDevManifest) {
-				return LoadDev(secondaryDevManifest, namespace, k8sContext)
-			}
-		}
+//LoadDev loads the development environment 'devName' of an okteto manifest checking "yml" and "yaml".
+//devName can be empty if the manifest defines a single development environment
+func LoadDev(devPath, devName, namespace, k8sContext string) (*model.Dev, error) {
+	dev, err := getDev(devPath, devName)
+	if err != nil {
+		return nil, err
+	}
+	loadContext(dev, k8sContext)
+	loadNamespace(dev, namespace)
+	return dev, nil
+}
+
+//LoadDevs loads the development environments of an okteto manifest checking "yml" and "yaml", indexed by name
+func LoadDevs(devPath, namespace, k8sContext string) (map[string]*model.Dev, error) {
+	devPath, err := getDevPath(devPath)
+	if err != nil {
+		return nil, err
+	}
+
+	devs, err := model.GetDevs(devPath)
+	if err != nil {
+		return nil, err
+	}
 
-		return nil, fmt.Errorf("'%s' does not exist. Generate it by executing 'okteto init'", devPath)
+	for _, dev := range devs {
+		loadContext(dev, k8sContext)
+		loadNamespace(dev, namespace)
 	}
+	return devs, nil
+}
 
-	dev, err := model.Get(devPath)
+//LoadDevFromSession loads an okteto manifest using the name, namespace and context recorded by 'okteto up' for it.
+//If devName is empty and the manifest defines several development environments, it loads the only one with a session
+func LoadDevFromSession(ctx context.Context, devPath, devName, namespace, k8sContext string) (*model.Dev, error) {
+	manifestPath, err := getDevPath(devPath)
 	if err != nil {
 		return nil, err
 	}
+
+	devs, err := model.GetDevs(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := loadSessionStoreForDevs(devs, namespace, k8sContext)
+	if err != nil {
+		return nil, err
+	}
+
+	dev, session, err := getDevFromSession(ctx, store, devPath, devName, devs)
+	if err != nil {
+		return nil, err
+	}
+
+	if session != nil {
+		log.Infof("using the session recorded for '%s': %s/%s", devPath, session.Namespace, session.Name)
+		dev.Name = session.Name
+		if namespace == "" {
+			dev.Namespace = session.Namespace
+		}
+		if k8sContext == "" && session.Context != "" {
+			dev.Context = session.Context
+		}
+	}
+
 	loadContext(dev, k8sContext)
 	loadNamespace(dev, namespace)
 	return dev, nil
 }
 
+func getDev(devPath, devName string) (*model.Dev, error) {
+	devPath, err := getDevPath(devPath)
+	if err != nil {
+		return nil, err
+	}
+
+	devs, err := model.GetDevs(devPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetDevByName(devs, devName)
+}
+
+// GetDevByName returns the development environment 'name' of an okteto manifest.
+// If name is empty, it returns the development environment of manifests that define a single one
+func GetDevByName(devs map[string]*model.Dev, name string) (*model.Dev, error) {
+	names := model.GetDevNames(devs)
+	if name == "" {
+		if len(names) == 1 {
+			return devs[names[0]], nil
+		}
+		return nil, errSeveralDevs(devs)
+	}
+
+	if dev, ok := devs[name]; ok {
+		return dev, nil
+	}
+	return nil, errors.UserError{
+		E:    fmt.Errorf("development environment '%s' is not defined in your okteto manifest", name),
+		Hint: fmt.Sprintf("Available development environments: %s", strings.Join(names, ", ")),
+	}
+}
+
+func errSeveralDevs(devs map[string]*model.Dev) error {
+	return errors.UserError{
+		E:    fmt.Errorf("your okteto manifest defines several development environments: %s", strings.Join(model.GetDevNames(devs), ", ")),
+		Hint: "Select one of them by name, run the command with '--help' for details",
+	}
+}
+
+// loadSessionStoreForDevs loads the session store using the context and namespace of the flags or, if not set, of the okteto manifest
+func loadSessionStoreForDevs(devs map[string]*model.Dev, namespace, k8sContext string) (config.SessionStore, error) {
+	for _, name := range model.GetDevNames(devs) {
+		if k8sContext == "" {
+			k8sContext = devs[name].Context
+		}
+		if namespace == "" {
+			namespace = devs[name].Namespace
+		}
+	}
+	return LoadSessionStore(k8sContext, namespace)
+}
+
+// getDevFromSession returns the development environment 'devName' and its session, if any.
+// If devName is empty and the manifest defines several development environments, it returns the only one with a session
+func getDevFromSession(ctx context.Context, store config.SessionStore, devPath, devName string, devs map[string]*model.Dev) (*model.Dev, *config.Session, error) {
+	if devName != "" || len(devs) == 1 {
+		dev, err := GetDevByName(devs, devName)
+		if err != nil {
+			return nil, nil, err
+		}
+		return dev, getSession(ctx, store, devPath, dev), nil
+	}
+
+	var found *model.Dev
+	var foundSession *config.Session
+	for _, name := range model.GetDevNames(devs) {
+		session := getSession(ctx, store, devPath, devs[name])
+		if session == nil {
+			continue
+		}
+		if found != nil {
+			return nil, nil, errSeveralDevs(devs)
+		}
+		found = devs[name]
+		foundSession = session
+	}
+
+	if found == nil {
+		return nil, nil, errSeveralDevs(devs)
+	}
+	return found, foundSession, nil
+}
+
+func getSession(ctx context.Context, store config.SessionStore, devPath string, dev *model.Dev) *config.Session {
+	session, err := config.GetSession(ctx, store, devPath, dev.ManifestKey())
+	if err != nil {
+		log.Infof("failed to load the session of '%s': %s", devPath, err)
+		return nil
+	}
+	return session
+}
+
+// getDevPath returns the path of the okteto manifest, falling back to "okteto.yaml" when "okteto.yml" doesn't exist
+func getDevPath(devPath string) (string, error) {
+	if !model.FileExists(devPath) {
+		if devPath == DefaultDevManifest {
+			if model.FileExists(secondaryDevManifest) {
+				return secondaryDevManifest, nil
+			}
+		}
+
+		return "", fmt.Errorf("'%s' does not exist. Generate it by executing 'okteto init'", devPath)
+	}
+
+	return devPath, nil
+}
+
 func loadContext(dev *model.Dev, k8sContext string) {
 	if k8sContext != "" {
 		dev.Context = k8sContext
@@ -74,14 +237,42 @@ func loadNamespace(dev *model.Dev, namespace string) {
 	if namespace != "" {
 		dev.Namespace = namespace
 	}
+	if dev.Namespace == "" && isNamespaceFromName() {
+		dev.Namespace = getNamespaceFromName(dev.Name)
+		if dev.Namespace != "" {
+			log.Infof("using namespace '%s' derived from the name of the okteto manifest", dev.Namespace)
+		}
+	}
 	if dev.Namespace == "" {
 		dev.Namespace = client.GetContextNamespace(dev.Context)
 	}
 }
 
-//LoadDevOrDefault loads an okteto manifest or a default one if does not exist
-func LoadDevOrDefault(devPath, name, namespace, k8sContext string) (*model.Dev, error) {
-	dev, err := LoadDev(devPath, namespace, k8sContext)
+func isNamespaceFromName() bool {
+	v := os.Getenv(namespaceFromNameEnvVar)
+	if v == "" {
+		return false
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Infof("invalid value '%s' for %s: %s", v, namespaceFromNameEnvVar, err)
+		return false
+	}
+	return enabled
+}
+
+// getNamespaceFromName returns name sanitized to be a valid namespace
+func getNamespaceFromName(name string) string {
+	namespace := model.ValidKubeNameRegex.ReplaceAllString(strings.ToLower(name), "-")
+	if len(namespace) > maxNamespaceLength {
+		namespace = namespace[:maxNamespaceLength]
+	}
+	return strings.Trim(namespace, "-")
+}
+
+//LoadDevOrDefault loads the development environment 'devName' of an okteto manifest or a default one if does not exist
+func LoadDevOrDefault(devPath, devName, name, namespace, k8sContext string) (*model.Dev, error) {
+	dev, err := LoadDev(devPath, devName, namespace, k8sContext)
 	if err == nil {
 		return dev, nil
 	}
@@ -141,20 +332,6 @@ func AskIfDeploy(name, namespace string) error {
 	return nil
 }
 
-//ParseURL validates a URL
-func ParseURL(u string) (string, error) {
-	url, err := url.Parse(u)
-	if err != nil {
-		return "", fmt.Errorf("%s is not a valid URL", u)
-	}
-
-	if url.Scheme == "" {
-		url.Scheme = "https"
-	}
-
-	return strings.TrimRight(url.String(), "/"), nil
-}
-
 //CheckIfDirectory checks if a path is a directory
 func CheckIfDirectory(path string) error {
 	fileInfo, err := os.Stat(path)
@@ -17,8 +17,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 )
@@ -63,7 +66,7 @@ func Test_loadDevOrDefault(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			def, err := LoadDevOrDefault("/tmp/a-path", tt.deployment, "namespace", "context")
+			def, err := LoadDevOrDefault("/tmp/a-path", "", tt.deployment, "namespace", "context")
 			if tt.expectErr {
 				if err == nil {
 					t.Fatal("expected error when loading")
@@ -99,7 +102,7 @@ func Test_loadDevOrDefault(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			loaded, err := LoadDevOrDefault(f.Name(), "foo", "namespace", "context")
+			loaded, err := LoadDevOrDefault(f.Name(), "", "foo", "namespace", "context")
 			if err != nil {
 				t.Fatalf("unexpected error when loading existing manifest: %s", err.Error())
 			}
@@ -115,7 +118,7 @@ func Test_loadDevOrDefault(t *testing.T) {
 		})
 	}
 	name := "demo-deployment"
-	def, err := LoadDevOrDefault("/tmp/bad-path", name, "namespace", "context")
+	def, err := LoadDevOrDefault("/tmp/bad-path", "", name, "namespace", "context")
 	if err != nil {
 		t.Fatal("default dev was not returned")
 	}
@@ -124,51 +127,167 @@ func Test_loadDevOrDefault(t *testing.T) {
 		t.Errorf("expected %s, got %s", name, def.Name)
 	}
 
-	_, err = LoadDevOrDefault("/tmp/bad-path", "", "namespace", "context")
+	_, err = LoadDevOrDefault("/tmp/bad-path", "", "", "namespace", "context")
 	if err == nil {
 		t.Error("expected error with empty deployment name")
 	}
 }
 
-func Test_ParseURL(t *testing.T) {
-	tests := []struct {
-		name    string
-		u       string
-		want    string
-		wantErr bool
+func Test_LoadDevFromSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	defer os.Unsetenv("OKTETO_FOLDER")
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(manifest, []byte("name: before\nimage: okteto/golang:1\nsync:\n  - .:/app\nnamespace: n1\ncontext: c1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := LoadDev(manifest, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveSession(context.Background(), config.NewFileSessionStore(), manifest, dev); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(manifest, []byte("name: after\nimage: okteto/golang:1\nsync:\n  - .:/app\nnamespace: n2\ncontext: c2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err = LoadDevFromSession(context.Background(), manifest, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Name != "before" || dev.Namespace != "n1" || dev.Context != "c1" {
+		t.Errorf("expected the recorded session 'c1/n1/before', got '%s/%s/%s'", dev.Context, dev.Namespace, dev.Name)
+	}
+
+	dev, err = LoadDevFromSession(context.Background(), manifest, "", "n3", "c3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Name != "before" || dev.Namespace != "n3" || dev.Context != "c3" {
+		t.Errorf("expected 'c3/n3/before', got '%s/%s/%s'", dev.Context, dev.Namespace, dev.Name)
+	}
+
+	if err := config.DeleteSession(context.Background(), config.NewFileSessionStore(), manifest, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err = LoadDevFromSession(context.Background(), manifest, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Name != "after" || dev.Namespace != "n2" || dev.Context != "c2" {
+		t.Errorf("expected the manifest values 'c2/n2/after', got '%s/%s/%s'", dev.Context, dev.Namespace, dev.Name)
+	}
+}
+
+func Test_LoadDevSeveralDevs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	defer os.Unsetenv("OKTETO_FOLDER")
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	content := "dev:\n  api:\n    image: okteto/golang:1\n    namespace: n1\n    sync:\n      - .:/app\n  frontend:\n    image: okteto/node:14\n    namespace: n1\n    sync:\n      - .:/app\n"
+	if err := ioutil.WriteFile(manifest, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadDev(manifest, "", "", ""); err == nil {
+		t.Error("expected an error loading a manifest with several development environments without name")
+	}
+
+	frontend, err := LoadDev(manifest, "frontend", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frontend.Name != "frontend" {
+		t.Errorf("expected 'frontend', got '%s'", frontend.Name)
+	}
+
+	if _, err := LoadDevFromSession(context.Background(), manifest, "", "", ""); err == nil {
+		t.Error("expected an error loading a manifest with several development environments without sessions")
+	}
+
+	if err := config.SaveSession(context.Background(), config.NewFileSessionStore(), manifest, frontend); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := LoadDevFromSession(context.Background(), manifest, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Name != "frontend" {
+		t.Errorf("expected the development environment with a session 'frontend', got '%s'", dev.Name)
+	}
+
+	dev, err = LoadDevFromSession(context.Background(), manifest, "api", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Name != "api" {
+		t.Errorf("expected 'api', got '%s'", dev.Name)
+	}
+}
+
+func Test_LoadDevNamespaceFromName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := filepath.Join(dir, "okteto.yml")
+	if err := ioutil.WriteFile(manifest, []byte("name: my-app\nimage: okteto/golang:1\nsync:\n  - .:/app\ncontext: c1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv(namespaceFromNameEnvVar, "true")
+	defer os.Unsetenv(namespaceFromNameEnvVar)
+
+	dev, err := LoadDev(manifest, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Namespace != "my-app" {
+		t.Errorf("expected namespace 'my-app', got '%s'", dev.Namespace)
+	}
+
+	dev, err = LoadDev(manifest, "", "n1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Namespace != "n1" {
+		t.Errorf("expected namespace 'n1', got '%s'", dev.Namespace)
+	}
+}
+
+func Test_getNamespaceFromName(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
 	}{
-		{
-			name: "full",
-			u:    "https://okteto.cloud.okteto.net",
-			want: "https://okteto.cloud.okteto.net",
-		},
-		{
-			name: "no-protocol",
-			u:    "okteto.cloud.okteto.net",
-			want: "https://okteto.cloud.okteto.net",
-		},
-		{
-			name: "trim-slash",
-			u:    "https://okteto.cloud.okteto.net/",
-			want: "https://okteto.cloud.okteto.net",
-		},
-		{
-			name: "ip",
-			u:    "https://192.168.0.1",
-			want: "https://192.168.0.1",
-		},
+		{name: "api", expected: "api"},
+		{name: "My App", expected: "my-app"},
+		{name: "_web.v2_", expected: "web-v2"},
+		{name: strings.Repeat("a", 70), expected: strings.Repeat("a", 63)},
 	}
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := ParseURL(tt.u)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseURL() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if got != tt.want {
-				t.Errorf("parseURL() = %v, want %v", got, tt.want)
-			}
-		})
+		if got := getNamespaceFromName(tt.name); got != tt.expected {
+			t.Errorf("getNamespaceFromName(%s): expected '%s', got '%s'", tt.name, tt.expected, got)
+		}
 	}
 }
 
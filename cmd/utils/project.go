@@ -0,0 +1,99 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/model"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// projectConfigFolder is the folder of the project config, relative to the okteto manifest
+	projectConfigFolder = ".okteto"
+	projectConfigFile   = "config.yml"
+)
+
+// ProjectConfig defines default flag values for 'okteto up', 'okteto exec' and 'okteto down'.
+// It's read from '.okteto/config.yml' in the folder of the okteto manifest.
+type ProjectConfig struct {
+	Namespace  string          `yaml:"namespace,omitempty"`
+	Context    string          `yaml:"context,omitempty"`
+	AutoDeploy *bool           `yaml:"autodeploy,omitempty"`
+	Forward    []model.Forward `yaml:"forward,omitempty"`
+}
+
+// GetProjectConfigPath returns the path of the project config of the okteto manifest in devPath
+func GetProjectConfigPath(devPath string) string {
+	return filepath.Join(filepath.Dir(devPath), projectConfigFolder, projectConfigFile)
+}
+
+// LoadProjectConfig loads the project config of the okteto manifest in devPath.
+// It returns an empty project config if the file doesn't exist.
+func LoadProjectConfig(devPath string) (*ProjectConfig, error) {
+	c := &ProjectConfig{}
+	path := GetProjectConfigPath(devPath)
+	if !model.FileExists(path) {
+		return c, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, fmt.Errorf("invalid project config '%s': %w", path, err)
+	}
+
+	return c, nil
+}
+
+// GetNamespace returns the namespace flag or the namespace of the project config if the flag is empty
+func (c *ProjectConfig) GetNamespace(namespace string) string {
+	if namespace != "" {
+		return namespace
+	}
+	return c.Namespace
+}
+
+// GetContext returns the context flag or the context of the project config if the flag is empty
+func (c *ProjectConfig) GetContext(k8sContext string) string {
+	if k8sContext != "" {
+		return k8sContext
+	}
+	return c.Context
+}
+
+// GetAutoDeploy returns the autodeploy flag if it was set or the autodeploy of the project config otherwise
+func (c *ProjectConfig) GetAutoDeploy(autoDeploy, changed bool) bool {
+	if changed || c.AutoDeploy == nil {
+		return autoDeploy
+	}
+	return *c.AutoDeploy
+}
+
+// LoadForward overrides the forwards of dev with the forwards of the project config
+func (c *ProjectConfig) LoadForward(dev *model.Dev) error {
+	if len(c.Forward) == 0 {
+		return nil
+	}
+	if err := dev.OverrideForwards(c.Forward); err != nil {
+		return fmt.Errorf("invalid project config: %w", err)
+	}
+	return nil
+}
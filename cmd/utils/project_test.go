@@ -0,0 +1,111 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeProjectConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, projectConfigFolder), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, projectConfigFolder, projectConfigFile), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, DefaultDevManifest)
+}
+
+func TestLoadProjectConfigPrecedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	devPath := writeProjectConfig(t, dir, `namespace: project-ns
+context: project-ctx
+autodeploy: true
+forward:
+  - 8080:3000`)
+
+	c, err := LoadProjectConfig(devPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		got      interface{}
+		expected interface{}
+	}{
+		{name: "namespace-flag", got: c.GetNamespace("flag-ns"), expected: "flag-ns"},
+		{name: "namespace-project", got: c.GetNamespace(""), expected: "project-ns"},
+		{name: "context-flag", got: c.GetContext("flag-ctx"), expected: "flag-ctx"},
+		{name: "context-project", got: c.GetContext(""), expected: "project-ctx"},
+		{name: "autodeploy-flag", got: c.GetAutoDeploy(false, true), expected: false},
+		{name: "autodeploy-project", got: c.GetAutoDeploy(false, false), expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %v, expected %v", tt.got, tt.expected)
+			}
+		})
+	}
+
+	if len(c.Forward) != 1 || c.Forward[0].Local != 8080 || c.Forward[0].Remote != 3000 {
+		t.Errorf("wrong forwards: %+v", c.Forward)
+	}
+}
+
+func TestLoadProjectConfigDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := LoadProjectConfig(filepath.Join(dir, DefaultDevManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ns := c.GetNamespace(""); ns != "" {
+		t.Errorf("got namespace '%s', expected the built-in default", ns)
+	}
+	if ctx := c.GetContext(""); ctx != "" {
+		t.Errorf("got context '%s', expected the built-in default", ctx)
+	}
+	if c.GetAutoDeploy(false, false) {
+		t.Errorf("got autodeploy enabled, expected the built-in default")
+	}
+}
+
+func TestLoadProjectConfigInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	devPath := writeProjectConfig(t, dir, "unknown: value")
+	if _, err := LoadProjectConfig(devPath); err == nil {
+		t.Errorf("didn't get an error for an unknown field")
+	}
+}
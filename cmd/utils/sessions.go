@@ -0,0 +1,56 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+)
+
+const (
+	// sessionStoreEnvVar selects where the sessions of 'okteto up' are stored: 'file' (default) or 'configmap'
+	sessionStoreEnvVar = "OKTETO_SESSION_STORE"
+	// sessionStoreNamespaceEnvVar is the namespace of the 'configmap' session store. It defaults to the namespace of the development container
+	sessionStoreNamespaceEnvVar = "OKTETO_SESSION_STORE_NAMESPACE"
+
+	fileSessionStore      = "file"
+	configMapSessionStore = "configmap"
+)
+
+// LoadSessionStore returns the session store selected by the OKTETO_SESSION_STORE environment variable
+// for the development container of the given context and namespace
+func LoadSessionStore(k8sContext, namespace string) (config.SessionStore, error) {
+	switch store := os.Getenv(sessionStoreEnvVar); store {
+	case "", fileSessionStore:
+		return config.NewFileSessionStore(), nil
+	case configMapSessionStore:
+		c, _, err := client.GetLocalWithContext(k8sContext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the '%s' session store: %s", configMapSessionStore, err)
+		}
+		if ns := os.Getenv(sessionStoreNamespaceEnvVar); ns != "" {
+			namespace = ns
+		}
+		if namespace == "" {
+			namespace = client.GetContextNamespace(k8sContext)
+		}
+		return configmaps.NewSessionStore(namespace, c), nil
+	default:
+		return nil, fmt.Errorf("'%s' is not a valid value for %s. Supported values are: '%s' and '%s'", store, sessionStoreEnvVar, fileSessionStore, configMapSessionStore)
+	}
+}
@@ -15,8 +15,11 @@ package utils
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/joho/godotenv"
+
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 )
@@ -64,3 +67,24 @@ func isDeprecatedExtension(stackPath string) bool {
 	}
 	return false
 }
+
+// LoadStackVariables sets the variables of a '--var-file' and the '--var' KEY=VALUE pairs
+// in the environment, so they are substituted when the stack manifest is loaded
+func LoadStackVariables(variables []string, varFile string) error {
+	if varFile != "" {
+		if err := godotenv.Overload(varFile); err != nil {
+			return fmt.Errorf("error loading '--var-file' %s: %s", varFile, err.Error())
+		}
+	}
+
+	for _, v := range variables {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return fmt.Errorf("invalid variable value '%s': must follow KEY=VALUE format", v)
+		}
+		if err := os.Setenv(kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -157,7 +157,7 @@ func destroyStack(ctx context.Context, oktetoPath, stackManifest, dir string) er
 
 func destroyStackWithVolumes(ctx context.Context, oktetoPath, stackManifest, dir string) error {
 	log.Printf("okteto stack destroy with volumes")
-	cmd := exec.Command(oktetoPath, "stack", "destroy", "-v", "-f", stackManifest)
+	cmd := exec.Command(oktetoPath, "stack", "destroy", "-v", "--yes", "-f", stackManifest)
 	cmd.Env = os.Environ()
 	cmd.Dir = dir
 	o, err := cmd.CombinedOutput()
@@ -27,6 +27,7 @@ import (
 	"github.com/okteto/okteto/cmd/stack"
 	"github.com/okteto/okteto/cmd/up"
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
@@ -91,19 +92,26 @@ func main() {
 	root.AddCommand(cmd.List(ctx))
 	root.AddCommand(cmd.Delete(ctx))
 	root.AddCommand(namespace.Namespace(ctx))
+	root.AddCommand(cmd.Kubeconfig(ctx))
 	root.AddCommand(pipeline.Pipeline(ctx))
 	root.AddCommand(stack.Stack(ctx))
 	root.AddCommand(initCMD.Init())
+	root.AddCommand(cmd.Migrate())
 	root.AddCommand(up.Up())
 	root.AddCommand(cmd.Down())
 	root.AddCommand(cmd.Push(ctx))
 	root.AddCommand(cmd.Status())
 	root.AddCommand(cmd.Doctor())
 	root.AddCommand(cmd.Exec())
+	root.AddCommand(cmd.Cp())
+	root.AddCommand(cmd.Debug())
+	root.AddCommand(cmd.Ps())
+	root.AddCommand(cmd.Logs())
 	root.AddCommand(cmd.Restart())
 	root.AddCommand(cmd.Update())
 
 	err := utils.RunWithRetry(root.Execute)
+	analytics.Flush()
 
 	if err != nil {
 		log.Fail(err.Error())
@@ -112,6 +120,12 @@ func main() {
 				log.Hint("    %s", uErr.Hint)
 			}
 		}
+		if err == errors.ErrExecTimeout {
+			os.Exit(errors.ExitCodeTimeout)
+		}
+		if eErr, ok := err.(errors.ExitCodeError); ok {
+			os.Exit(eErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -54,6 +54,7 @@ const (
 	namespaceEvent           = "Namespace"
 	namespaceCreateEvent     = "CreateNamespace"
 	namespaceDeleteEvent     = "DeleteNamespace"
+	kubeconfigEvent          = "Kubeconfig"
 	execEvent                = "Exec"
 	signupEvent              = "Signup"
 	disableEvent             = "Disable Analytics"
@@ -62,12 +63,13 @@ const (
 
 var (
 	mixpanelClient mixpanel.Mixpanel
+	httpClient     *http.Client
 	clusterType    string
 	clusterContext string
 )
 
 func init() {
-	c := &http.Client{
+	httpClient = &http.Client{
 		Timeout: time.Second * 5,
 		Transport: &http.Transport{
 			Dial: (&net.Dialer{
@@ -77,7 +79,7 @@ func init() {
 		},
 	}
 
-	mixpanelClient = mixpanel.NewFromClient(c, mixpanelToken, "")
+	mixpanelClient = mixpanel.NewFromClient(httpClient, mixpanelToken, os.Getenv(analyticsURLEnvVar))
 }
 
 // SetClusterType sets the cluster type for analytics
@@ -103,6 +105,11 @@ func TrackNamespace(success bool) {
 	track(namespaceEvent, success, nil)
 }
 
+// TrackKubeconfig sends a tracking event to mixpanel when the user writes the credentials of a namespace into a kubeconfig
+func TrackKubeconfig(success bool) {
+	track(kubeconfigEvent, success, nil)
+}
+
 // TrackCreateNamespace sends a tracking event to mixpanel when the creates a namespace
 func TrackCreateNamespace(success bool) {
 	track(namespaceCreateEvent, success, nil)
@@ -302,11 +309,14 @@ func track(event string, success bool, props map[string]interface{}) {
 		props["clusterContext"] = clusterContext
 	}
 
-	e := &mixpanel.Event{Properties: props}
-	trackID := getTrackID()
-	if err := mixpanelClient.Track(trackID, event, e); err != nil {
-		log.Infof("Failed to send analytics: %s", err)
-	}
+	// events are sent when the command exits, keep the time when they happened
+	props["time"] = time.Now().Unix()
+
+	queue.add(queuedEvent{
+		distinctID: getTrackID(),
+		name:       event,
+		event:      &mixpanel.Event{Properties: props},
+	})
 }
 
 func getFlagPath() string {
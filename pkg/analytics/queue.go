@@ -0,0 +1,145 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dukex/mixpanel"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// analyticsURLEnvVar overrides the endpoint where the analytics events are sent
+	analyticsURLEnvVar = "OKTETO_ANALYTICS_URL"
+
+	// maxQueuedEvents is the maximum number of events buffered by a command. Events are dropped when the queue is full
+	maxQueuedEvents = 100
+
+	// maxBatchSize is the maximum number of events accepted by a request to the mixpanel batch endpoint
+	maxBatchSize = 50
+
+	defaultAnalyticsURL = "https://api.mixpanel.com"
+)
+
+type queuedEvent struct {
+	distinctID string
+	name       string
+	event      *mixpanel.Event
+}
+
+// eventQueue buffers the analytics events of a command until they are flushed when the command exits
+type eventQueue struct {
+	mu      sync.Mutex
+	events  []queuedEvent
+	dropped int
+}
+
+var (
+	queue = &eventQueue{}
+
+	sendBatch = trackBatch
+)
+
+// add adds an event to the queue without blocking. The event is dropped if the queue is full
+func (q *eventQueue) add(e queuedEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.events) >= maxQueuedEvents {
+		q.dropped++
+		return
+	}
+	q.events = append(q.events, e)
+}
+
+// flush sends the buffered events and empties the queue
+func (q *eventQueue) flush() {
+	q.mu.Lock()
+	events := q.events
+	dropped := q.dropped
+	q.events = nil
+	q.dropped = 0
+	q.mu.Unlock()
+
+	if dropped > 0 {
+		log.Infof("dropped %d analytics events: the queue is full", dropped)
+	}
+
+	for len(events) > 0 {
+		n := len(events)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		if err := sendBatch(events[:n]); err != nil {
+			log.Infof("Failed to send analytics: %s", err)
+		}
+		events = events[n:]
+	}
+}
+
+// trackBatch sends a batch of events with a single request to the mixpanel track endpoint
+func trackBatch(events []queuedEvent) error {
+	batch := make([]map[string]interface{}, 0, len(events))
+	for _, e := range events {
+		props := map[string]interface{}{
+			"token":       mixpanelToken,
+			"distinct_id": e.distinctID,
+		}
+		for key, value := range e.event.Properties {
+			props[key] = value
+		}
+		batch = append(batch, map[string]interface{}{
+			"event":      e.name,
+			"properties": props,
+		})
+	}
+
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	endpoint := os.Getenv(analyticsURLEnvVar)
+	if endpoint == "" {
+		endpoint = defaultAnalyticsURL
+	}
+	endpoint = strings.TrimRight(endpoint, "/") + "/track?ip=1"
+
+	resp, err := httpClient.PostForm(endpoint, url.Values{"data": {base64.StdEncoding.EncodeToString(data)}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if b := strings.TrimSpace(string(body)); b != "1" {
+		return fmt.Errorf("the analytics endpoint returned '%s' with status %d", b, resp.StatusCode)
+	}
+	return nil
+}
+
+// Flush sends the analytics events buffered by the command. It must be called before the command exits
+func Flush() {
+	queue.flush()
+}
@@ -0,0 +1,152 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/dukex/mixpanel"
+)
+
+func Test_trackFlushesAtExit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("OKTETO_HOME", dir)
+	defer os.Unsetenv("OKTETO_HOME")
+
+	sent := []string{}
+	defaultSendBatch := sendBatch
+	sendBatch = func(events []queuedEvent) error {
+		for _, e := range events {
+			sent = append(sent, e.name)
+		}
+		return nil
+	}
+	defer func() { sendBatch = defaultSendBatch }()
+	queue = &eventQueue{}
+
+	TrackNamespace(true)
+	TrackKubeconfig(true)
+	if len(sent) > 0 {
+		t.Fatalf("events were sent before flushing: %v", sent)
+	}
+
+	Flush()
+	expected := []string{namespaceEvent, kubeconfigEvent}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Fatalf("expected events %v, got %v", expected, sent)
+	}
+
+	Flush()
+	if !reflect.DeepEqual(sent, expected) {
+		t.Fatalf("events were sent twice: %v", sent)
+	}
+
+	f, err := os.Create(getFlagPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	TrackNamespace(true)
+	Flush()
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("events were sent with analytics disabled: %v", sent)
+	}
+}
+
+func Test_eventQueueDropsWhenFull(t *testing.T) {
+	q := &eventQueue{}
+	for i := 0; i < maxQueuedEvents+10; i++ {
+		q.add(queuedEvent{name: namespaceEvent})
+	}
+
+	if len(q.events) != maxQueuedEvents {
+		t.Errorf("expected %d queued events, got %d", maxQueuedEvents, len(q.events))
+	}
+	if q.dropped != 10 {
+		t.Errorf("expected 10 dropped events, got %d", q.dropped)
+	}
+}
+
+func Test_flushSendsBatches(t *testing.T) {
+	sizes := []int{}
+	defaultSendBatch := sendBatch
+	sendBatch = func(events []queuedEvent) error {
+		sizes = append(sizes, len(events))
+		return nil
+	}
+	defer func() { sendBatch = defaultSendBatch }()
+
+	q := &eventQueue{}
+	for i := 0; i < maxQueuedEvents+10; i++ {
+		q.add(queuedEvent{name: namespaceEvent})
+	}
+	q.flush()
+
+	expected := []int{maxBatchSize, maxBatchSize}
+	if !reflect.DeepEqual(sizes, expected) {
+		t.Errorf("expected batches of %v events, got %v", expected, sizes)
+	}
+}
+
+func Test_trackBatch(t *testing.T) {
+	var received []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/track" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		data, err := base64.StdEncoding.DecodeString(r.FormValue("data"))
+		if err != nil {
+			t.Error(err)
+		}
+		if err := json.Unmarshal(data, &received); err != nil {
+			t.Error(err)
+		}
+		fmt.Fprint(w, "1")
+	}))
+	defer server.Close()
+	os.Setenv(analyticsURLEnvVar, server.URL)
+	defer os.Unsetenv(analyticsURLEnvVar)
+
+	events := []queuedEvent{
+		{distinctID: "id", name: namespaceEvent, event: &mixpanel.Event{Properties: map[string]interface{}{"success": true}}},
+		{distinctID: "id", name: kubeconfigEvent, event: &mixpanel.Event{Properties: map[string]interface{}{"success": false}}},
+	}
+	if err := trackBatch(events); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 events in the batch, got %d", len(received))
+	}
+	if received[0]["event"] != namespaceEvent || received[1]["event"] != kubeconfigEvent {
+		t.Errorf("unexpected events: %v", received)
+	}
+	props, _ := received[1]["properties"].(map[string]interface{})
+	if props["token"] != mixpanelToken || props["distinct_id"] != "id" || props["success"] != false {
+		t.Errorf("unexpected properties: %v", props)
+	}
+}
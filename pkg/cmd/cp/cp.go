@@ -0,0 +1,220 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// ExecFunc runs a command in the development container
+type ExecFunc func(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error
+
+// ParseArgs returns the local path, the remote path and if the copy is an upload from the arguments of the cp command.
+// Remote paths are prefixed with the name of the development container, like 'web:/app/file'
+func ParseArgs(devName, src, dst string) (string, string, bool, error) {
+	srcRemote, srcIsRemote := getRemotePath(devName, src)
+	dstRemote, dstIsRemote := getRemotePath(devName, dst)
+	switch {
+	case srcIsRemote && dstIsRemote:
+		return "", "", false, fmt.Errorf("copying files between paths of the development container is not supported")
+	case !srcIsRemote && !dstIsRemote:
+		return "", "", false, fmt.Errorf("one of the paths must be in the development container, like '%s:/path'", devName)
+	case dstIsRemote:
+		remote, err := validateRemotePath(dstRemote)
+		return src, remote, true, err
+	default:
+		remote, err := validateRemotePath(srcRemote)
+		return dst, remote, false, err
+	}
+}
+
+func getRemotePath(devName, arg string) (string, bool) {
+	prefix := devName + ":"
+	if !strings.HasPrefix(arg, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(arg, prefix), true
+}
+
+func validateRemotePath(p string) (string, error) {
+	if !path.IsAbs(p) {
+		return "", fmt.Errorf("'%s' must be an absolute path", p)
+	}
+	p = path.Clean(p)
+	if p == "/" {
+		return "", fmt.Errorf("copying the root folder of the development container is not supported")
+	}
+	return p, nil
+}
+
+// Upload copies the local file or folder to the remote path of the development container
+func Upload(ctx context.Context, exec ExecFunc, local, remote string) error {
+	if _, err := os.Stat(local); err != nil {
+		return fmt.Errorf("failed to read '%s': %s", local, err)
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(writeTar(w, local, path.Base(remote)))
+	}()
+
+	command := []string{"tar", "-xpf", "-", "-C", path.Dir(remote)}
+	if err := exec(ctx, command, r, ioutil.Discard); err != nil {
+		r.CloseWithError(err)
+		return fmt.Errorf("failed to copy '%s' to the development container: %s", local, err)
+	}
+	return nil
+}
+
+// Download copies the remote file or folder of the development container to the local path
+func Download(ctx context.Context, exec ExecFunc, remote, local string) error {
+	r, w := io.Pipe()
+	readErr := make(chan error, 1)
+	go func() {
+		err := readTar(r, path.Base(remote), local)
+		if err != nil {
+			r.CloseWithError(err)
+		} else {
+			// drain the padding of the archive so the remote tar can exit
+			_, _ = io.Copy(ioutil.Discard, r)
+		}
+		readErr <- err
+	}()
+
+	command := []string{"tar", "-cf", "-", "-C", path.Dir(remote), path.Base(remote)}
+	err := exec(ctx, command, strings.NewReader(""), w)
+	w.CloseWithError(err)
+	if rErr := <-readErr; rErr != nil && err == nil {
+		err = rErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy '%s' from the development container: %s", remote, err)
+	}
+	return nil
+}
+
+// writeTar writes to w an archive of src with its entries rooted at name
+func writeTar(w io.Writer, src, name string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(p)
+			if err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// readTar extracts to dst the entries of the archive rooted at name
+func readTar(r io.Reader, name, dst string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := path.Clean(hdr.Name)
+		if entry != name && !strings.HasPrefix(entry, name+"/") {
+			return fmt.Errorf("unexpected path '%s' in the archive", hdr.Name)
+		}
+		target := filepath.Join(dst, filepath.FromSlash(strings.TrimPrefix(entry, name)))
+		mode := os.FileMode(hdr.Mode).Perm()
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode); err != nil {
+				return err
+			}
+			if err := os.Chmod(target, mode); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, mode, tr); err != nil {
+				return err
+			}
+		default:
+			log.Yellow("Skipping '%s': only files and folders are copied", hdr.Name)
+		}
+	}
+}
+
+func writeFile(target string, mode os.FileMode, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(target, mode)
+}
@@ -0,0 +1,210 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeExec runs the tar commands of the cp command against a local folder that simulates the development container
+func fakeExec(root string) ExecFunc {
+	return func(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error {
+		switch {
+		case len(command) == 5 && command[1] == "-xpf":
+			tr := tar.NewReader(stdin)
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				target := filepath.Join(root, filepath.FromSlash(command[4]), filepath.FromSlash(hdr.Name))
+				mode := os.FileMode(hdr.Mode).Perm()
+				if hdr.Typeflag == tar.TypeDir {
+					if err := os.MkdirAll(target, mode); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := writeFile(target, mode, tr); err != nil {
+					return err
+				}
+			}
+		case len(command) == 6 && command[1] == "-cf":
+			src := filepath.Join(root, filepath.FromSlash(command[4]), command[5])
+			return writeTar(stdout, src, command[5])
+		}
+		return fmt.Errorf("unexpected command: %v", command)
+	}
+}
+
+func TestUploadAndDownload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	remoteRoot := filepath.Join(dir, "remote")
+	if err := os.MkdirAll(filepath.Join(remoteRoot, "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	local := filepath.Join(dir, "run.sh")
+	if err := ioutil.WriteFile(local, []byte("echo hello"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	exec := fakeExec(remoteRoot)
+	if err := Upload(ctx, exec, local, "/app/start.sh"); err != nil {
+		t.Fatal(err)
+	}
+
+	remote := filepath.Join(remoteRoot, "app", "start.sh")
+	if _, err := os.Stat(remote); err != nil {
+		t.Fatalf("file wasn't uploaded: %s", err)
+	}
+
+	downloaded := filepath.Join(dir, "downloaded", "run.sh")
+	if err := Download(ctx, exec, "/app/start.sh", downloaded); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(downloaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "echo hello" {
+		t.Errorf("wrong content after round trip: %s", string(b))
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(downloaded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0700 {
+			t.Errorf("mode wasn't preserved: %s", info.Mode().Perm())
+		}
+	}
+}
+
+func TestUploadAndDownloadFolder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	remoteRoot := filepath.Join(dir, "remote")
+	if err := os.MkdirAll(remoteRoot, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	local := filepath.Join(dir, "src")
+	if err := os.MkdirAll(filepath.Join(local, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(local, "sub", "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	exec := fakeExec(remoteRoot)
+	if err := Upload(ctx, exec, local, "/data"); err != nil {
+		t.Fatal(err)
+	}
+
+	downloaded := filepath.Join(dir, "dst")
+	if err := Download(ctx, exec, "/data", downloaded); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(downloaded, "sub", "a.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "a" {
+		t.Errorf("wrong content after round trip: %s", string(b))
+	}
+}
+
+func Test_readTarRejectsUnexpectedPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	r, w := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(w)
+		tw.WriteHeader(&tar.Header{Name: "file/../../evil", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+		tw.Write([]byte("x"))
+		w.CloseWithError(tw.Close())
+	}()
+
+	if err := readTar(r, "file", filepath.Join(dir, "file")); err == nil {
+		t.Fatal("expected error for a path outside of the copied file")
+	}
+	r.Close()
+}
+
+func TestParseArgs(t *testing.T) {
+	var tests = []struct {
+		name    string
+		src     string
+		dst     string
+		local   string
+		remote  string
+		upload  bool
+		wantErr bool
+	}{
+		{name: "upload", src: "file", dst: "web:/app/file", local: "file", remote: "/app/file", upload: true},
+		{name: "download", src: "web:/app/dir/", dst: "dir", local: "dir", remote: "/app/dir", upload: false},
+		{name: "both-local", src: "a", dst: "b", wantErr: true},
+		{name: "both-remote", src: "web:/a", dst: "web:/b", wantErr: true},
+		{name: "relative-remote", src: "a", dst: "web:app/a", wantErr: true},
+		{name: "root", src: "web:/", dst: "b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local, remote, upload, err := ParseArgs("web", tt.src, tt.dst)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if local != tt.local || remote != tt.remote || upload != tt.upload {
+				t.Errorf("got (%s, %s, %t), expected (%s, %s, %t)", local, remote, upload, tt.local, tt.remote, tt.upload)
+			}
+		})
+	}
+}
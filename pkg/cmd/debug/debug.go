@@ -0,0 +1,121 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	"github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// DefaultImage is the image of the debug container when '--image' is not set
+	DefaultImage = "busybox"
+
+	debugContainerPrefix = "okteto-debug"
+)
+
+// Options represents the options of the debug command
+type Options struct {
+	Image   string
+	Timeout time.Duration
+}
+
+// Run adds an ephemeral debug container to the dev pod and executes a shell in it
+func Run(ctx context.Context, pod *apiv1.Pod, container string, opts *Options, c *kubernetes.Clientset, config *rest.Config, stdin io.Reader, stdout, stderr io.Writer) error {
+	ec, err := c.CoreV1().Pods(pod.Namespace).GetEphemeralContainers(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return errors.UserError{
+				E:    fmt.Errorf("ephemeral containers are not enabled in your cluster"),
+				Hint: "Enable the 'EphemeralContainers' feature gate of your cluster and try again",
+			}
+		}
+		return fmt.Errorf("failed to get the ephemeral containers of pod '%s': %s", pod.Name, err)
+	}
+
+	name := fmt.Sprintf("%s-%s", debugContainerPrefix, utilrand.String(5))
+	ec.EphemeralContainers = append(ec.EphemeralContainers, getEphemeralContainer(name, opts.Image, container))
+	if _, err := c.CoreV1().Pods(pod.Namespace).UpdateEphemeralContainers(ctx, pod.Name, ec, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to add the debug container to pod '%s': %s", pod.Name, err)
+	}
+	log.Infof("added ephemeral container '%s' to pod '%s'", name, pod.Name)
+
+	if err := waitUntilRunning(ctx, pod, name, opts.Timeout, c); err != nil {
+		return err
+	}
+
+	return exec.Exec(ctx, c, config, pod.Namespace, pod.Name, name, true, stdin, stdout, stderr, []string{"sh"})
+}
+
+// getEphemeralContainer returns the spec of a debug container that shares the process namespace of the target container
+func getEphemeralContainer(name, image, target string) apiv1.EphemeralContainer {
+	return apiv1.EphemeralContainer{
+		EphemeralContainerCommon: apiv1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			ImagePullPolicy:          apiv1.PullIfNotPresent,
+			Command:                  []string{"sh"},
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: apiv1.TerminationMessageFallbackToLogsOnError,
+		},
+		TargetContainerName: target,
+	}
+}
+
+func waitUntilRunning(ctx context.Context, pod *apiv1.Pod, name string, timeout time.Duration, c kubernetes.Interface) error {
+	t := time.NewTicker(500 * time.Millisecond)
+	defer t.Stop()
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+	for {
+		p, err := c.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get pod '%s': %s", pod.Name, err)
+		}
+		for _, s := range p.Status.EphemeralContainerStatuses {
+			if s.Name != name {
+				continue
+			}
+			if s.State.Running != nil {
+				return nil
+			}
+			if s.State.Terminated != nil {
+				return fmt.Errorf("debug container exited: %s", s.State.Terminated.Reason)
+			}
+			if s.State.Waiting != nil {
+				log.Infof("debug container is waiting: %s", s.State.Waiting.Reason)
+			}
+		}
+
+		select {
+		case <-t.C:
+		case <-to.C:
+			return fmt.Errorf("debug container '%s' didn't start after %s", name, timeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
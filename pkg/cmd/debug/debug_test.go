@@ -0,0 +1,74 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_getEphemeralContainer(t *testing.T) {
+	ec := getEphemeralContainer("okteto-debug-abcde", "nicolaka/netshoot", "dev")
+
+	if ec.Name != "okteto-debug-abcde" {
+		t.Errorf("wrong name: %s", ec.Name)
+	}
+	if ec.Image != "nicolaka/netshoot" {
+		t.Errorf("wrong image: %s", ec.Image)
+	}
+	if ec.TargetContainerName != "dev" {
+		t.Errorf("the debug container doesn't target the process namespace of the dev container: '%s'", ec.TargetContainerName)
+	}
+	if !ec.Stdin || !ec.TTY {
+		t.Errorf("the debug container must keep its stdin and tty open")
+	}
+	if len(ec.Command) != 1 || ec.Command[0] != "sh" {
+		t.Errorf("wrong command: %v", ec.Command)
+	}
+}
+
+func Test_waitUntilRunning(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-123", Namespace: "test"},
+		Status: apiv1.PodStatus{
+			EphemeralContainerStatuses: []apiv1.ContainerStatus{
+				{
+					Name:  "okteto-debug-running",
+					State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+				},
+				{
+					Name:  "okteto-debug-exited",
+					State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: "Error"}},
+				},
+			},
+		},
+	}
+	c := fake.NewSimpleClientset(pod)
+	ctx := context.Background()
+
+	if err := waitUntilRunning(ctx, pod, "okteto-debug-running", time.Second, c); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := waitUntilRunning(ctx, pod, "okteto-debug-exited", time.Second, c); err == nil {
+		t.Errorf("expected error for an exited debug container")
+	}
+	if err := waitUntilRunning(ctx, pod, "okteto-debug-missing", time.Second, c); err == nil {
+		t.Errorf("expected timeout for a missing debug container")
+	}
+}
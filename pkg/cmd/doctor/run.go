@@ -93,8 +93,8 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clie
 		files = append(files, deploymentLogsPath)
 	}
 
-	if model.FileExists(syncthing.GetLogFile(dev.Namespace, dev.Name)) {
-		files = append(files, syncthing.GetLogFile(dev.Namespace, dev.Name))
+	if model.FileExists(syncthing.GetLogFile(dev)) {
+		files = append(files, syncthing.GetLogFile(dev))
 	}
 	if podPath != "" {
 		files = append(files, podPath)
@@ -0,0 +1,51 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// RemoveManifestArtifacts removes the okteto manifest and the '.stignore' file next to it if they were generated by okteto.
+// Files without the generated marker are never removed. It returns the paths of the removed files
+func RemoveManifestArtifacts(devPath string) ([]string, error) {
+	candidates := []string{devPath, filepath.Join(filepath.Dir(devPath), ".stignore")}
+
+	removed := []string{}
+	for _, path := range candidates {
+		generated, err := model.IsGeneratedFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, fmt.Errorf("failed to read '%s': %s", path, err)
+		}
+
+		if !generated {
+			log.Infof("'%s' wasn't generated by okteto, keeping it", path)
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove '%s': %s", path, err)
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func TestRemoveManifestArtifacts(t *testing.T) {
+	var tests = []struct {
+		name            string
+		manifest        []byte
+		stignore        []byte
+		expectedRemoved []string
+	}{
+		{
+			name:            "generated",
+			manifest:        model.MarkAsGenerated([]byte("name: api\n"), "#"),
+			stignore:        model.MarkAsGenerated([]byte(".git\n"), "//"),
+			expectedRemoved: []string{"okteto.yml", ".stignore"},
+		},
+		{
+			name:            "user-authored-manifest",
+			manifest:        []byte("name: api\n"),
+			stignore:        model.MarkAsGenerated([]byte(".git\n"), "//"),
+			expectedRemoved: []string{".stignore"},
+		},
+		{
+			name:            "marker-not-in-first-line",
+			manifest:        append([]byte("name: api\n"), model.MarkAsGenerated(nil, "#")...),
+			stignore:        []byte(".git\n"),
+			expectedRemoved: []string{},
+		},
+		{
+			name:            "missing-stignore",
+			manifest:        model.MarkAsGenerated([]byte("name: api\n"), "#"),
+			expectedRemoved: []string{"okteto.yml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			devPath := filepath.Join(dir, "okteto.yml")
+			if err := ioutil.WriteFile(devPath, tt.manifest, 0600); err != nil {
+				t.Fatal(err)
+			}
+			if tt.stignore != nil {
+				if err := ioutil.WriteFile(filepath.Join(dir, ".stignore"), tt.stignore, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			removed, err := RemoveManifestArtifacts(devPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []string{}
+			for _, name := range tt.expectedRemoved {
+				expected = append(expected, filepath.Join(dir, name))
+			}
+			if !reflect.DeepEqual(removed, expected) {
+				t.Errorf("expected removed files %v, got %v", expected, removed)
+			}
+
+			if _, err := os.Stat(devPath); err == nil && contains(removed, devPath) {
+				t.Error("the generated manifest wasn't removed")
+			}
+			if _, err := os.Stat(devPath); err != nil && !contains(removed, devPath) {
+				t.Error("the user-authored manifest was removed")
+			}
+		})
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/shirou/gopsutil/process"
+)
+
+var (
+	portsReleaseTimeout  = 10 * time.Second
+	portsReleaseInterval = 200 * time.Millisecond
+)
+
+// CleanupOrphanForwards terminates the 'okteto up' process recorded in the session if it is still running,
+// and verifies that the local ports of its forwards are released
+func CleanupOrphanForwards(s *config.Session) error {
+	if s == nil || len(s.Ports) == 0 {
+		return nil
+	}
+
+	if err := terminateSessionProcess(s); err != nil {
+		return err
+	}
+
+	busy := waitForPortsRelease(s.Interface, s.Ports)
+	if len(busy) == 0 {
+		return nil
+	}
+
+	ports := make([]string, len(busy))
+	for i := range busy {
+		ports[i] = strconv.Itoa(busy[i])
+	}
+
+	return errors.UserError{
+		E:    fmt.Errorf("local ports %s are still in use", strings.Join(ports, ", ")),
+		Hint: "Stop the processes listening on them before running 'okteto up' again",
+	}
+}
+
+func terminateSessionProcess(s *config.Session) error {
+	if s.PID == 0 || s.PID == os.Getpid() {
+		return nil
+	}
+
+	exists, err := process.PidExists(int32(s.PID))
+	if err != nil {
+		return fmt.Errorf("failed to check if process %d is running: %s", s.PID, err)
+	}
+	if !exists {
+		log.Infof("process %d recorded in the session is not running", s.PID)
+		return nil
+	}
+
+	p, err := process.NewProcess(int32(s.PID))
+	if err != nil {
+		return fmt.Errorf("failed to get process %d: %s", s.PID, err)
+	}
+
+	exe, err := p.Exe()
+	if err != nil {
+		log.Infof("failed to get the executable of process %d: %s", s.PID, err)
+		return nil
+	}
+
+	if !isSameExecutable(exe, s.Executable) {
+		log.Infof("process %d is not owned by okteto, skipping it: %s", s.PID, exe)
+		return nil
+	}
+
+	startTime, err := p.CreateTime()
+	if err != nil {
+		log.Infof("failed to get the start time of process %d: %s", s.PID, err)
+		return nil
+	}
+	if s.StartTime == 0 || startTime != s.StartTime {
+		log.Infof("process %d is not the process recorded in the session, skipping it", s.PID)
+		return nil
+	}
+
+	log.Infof("terminating orphan okteto process %d", s.PID)
+	if err := p.Terminate(); err != nil {
+		return fmt.Errorf("failed to terminate process %d: %s", s.PID, err)
+	}
+
+	if waitUntilNotRunning(p) {
+		return nil
+	}
+
+	if err := p.Kill(); err != nil {
+		return fmt.Errorf("failed to kill process %d: %s", s.PID, err)
+	}
+
+	waitUntilNotRunning(p)
+	return nil
+}
+
+func isSameExecutable(exe, recorded string) bool {
+	if exe == "" || recorded == "" {
+		return false
+	}
+
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	if resolved, err := filepath.EvalSymlinks(recorded); err == nil {
+		recorded = resolved
+	}
+
+	return filepath.Clean(exe) == filepath.Clean(recorded)
+}
+
+func waitUntilNotRunning(p *process.Process) bool {
+	tick := time.NewTicker(portsReleaseInterval)
+	defer tick.Stop()
+
+	timeout := time.Now().Add(portsReleaseTimeout)
+	for {
+		isRunning, err := p.IsRunning()
+		if err != nil {
+			log.Infof("failed to check if process %d is running: %s", p.Pid, err)
+			return false
+		}
+
+		if !isRunning {
+			return true
+		}
+
+		if time.Now().After(timeout) {
+			return false
+		}
+
+		<-tick.C
+	}
+}
+
+func waitForPortsRelease(iface string, ports []int) []int {
+	if iface == "" {
+		iface = model.Localhost
+	}
+
+	tick := time.NewTicker(portsReleaseInterval)
+	defer tick.Stop()
+
+	timeout := time.Now().Add(portsReleaseTimeout)
+	for {
+		busy := []int{}
+		for _, port := range ports {
+			if !model.IsPortAvailable(iface, port) {
+				busy = append(busy, port)
+			}
+		}
+
+		if len(busy) == 0 || time.Now().After(timeout) {
+			return busy
+		}
+
+		<-tick.C
+	}
+}
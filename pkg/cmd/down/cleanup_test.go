@@ -0,0 +1,157 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/shirou/gopsutil/process"
+)
+
+const helperListenerEnv = "OKTETO_TEST_HELPER_LISTENER"
+
+// TestHelperListener is not a real test, it's the stale listener spawned by TestCleanupOrphanForwards
+func TestHelperListener(t *testing.T) {
+	address := os.Getenv(helperListenerEnv)
+	if address == "" {
+		return
+	}
+
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		os.Exit(1)
+	}
+	defer l.Close()
+
+	time.Sleep(1 * time.Minute)
+	os.Exit(0)
+}
+
+// startHelperListener starts a process that listens on a local port, like the forwards of an orphan 'okteto up'
+func startHelperListener(t *testing.T) (*exec.Cmd, string, int) {
+	t.Helper()
+	port, err := model.GetAvailablePort(model.Localhost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(exe, "-test.run=TestHelperListener")
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s:%d", helperListenerEnv, model.Localhost, port))
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	go cmd.Wait()
+
+	for i := 0; i < 100; i++ {
+		if !model.IsPortAvailable(model.Localhost, port) {
+			return cmd, exe, port
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	cmd.Process.Kill()
+	t.Fatalf("the helper process didn't listen on port %d", port)
+	return nil, "", 0
+}
+
+func getStartTime(t *testing.T, pid int) int64 {
+	t.Helper()
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	startTime, err := p.CreateTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return startTime
+}
+
+func TestCleanupOrphanForwards(t *testing.T) {
+	cmd, exe, port := startHelperListener(t)
+	defer cmd.Process.Kill()
+
+	s := &config.Session{
+		PID:        cmd.Process.Pid,
+		Executable: exe,
+		StartTime:  getStartTime(t, cmd.Process.Pid),
+		Interface:  model.Localhost,
+		Ports:      []int{port},
+	}
+
+	if err := CleanupOrphanForwards(s); err != nil {
+		t.Fatal(err)
+	}
+
+	if !model.IsPortAvailable(model.Localhost, port) {
+		t.Errorf("port %d wasn't released", port)
+	}
+}
+
+func TestCleanupOrphanForwardsReusedPID(t *testing.T) {
+	cmd, exe, port := startHelperListener(t)
+	defer cmd.Process.Kill()
+
+	portsReleaseTimeout = 100 * time.Millisecond
+	defer func() { portsReleaseTimeout = 10 * time.Second }()
+
+	s := &config.Session{
+		PID:        cmd.Process.Pid,
+		Executable: exe,
+		StartTime:  getStartTime(t, cmd.Process.Pid) - 1000,
+		Interface:  model.Localhost,
+		Ports:      []int{port},
+	}
+
+	if err := CleanupOrphanForwards(s); err == nil {
+		t.Fatal("expected an error for a port held by a process that isn't the one of the session")
+	}
+
+	if model.IsPortAvailable(model.Localhost, port) {
+		t.Errorf("the process that isn't the one of the session was terminated")
+	}
+}
+
+func TestCleanupOrphanForwardsPortsInUse(t *testing.T) {
+	l, err := net.Listen("tcp", fmt.Sprintf("%s:0", model.Localhost))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	portsReleaseTimeout = 100 * time.Millisecond
+	defer func() { portsReleaseTimeout = 10 * time.Second }()
+
+	s := &config.Session{
+		PID:        os.Getpid(),
+		Executable: "/usr/local/bin/okteto",
+		Interface:  model.Localhost,
+		Ports:      []int{l.Addr().(*net.TCPAddr).Port},
+	}
+
+	if err := CleanupOrphanForwards(s); err == nil {
+		t.Fatal("expected an error for a port that is still in use")
+	}
+}
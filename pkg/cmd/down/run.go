@@ -52,6 +52,10 @@ func Run(dev *model.Dev, d *appsv1.Deployment, trList map[string]*model.Translat
 		return err
 	}
 
+	if err := secrets.DestroyPullSecret(ctx, dev, c); err != nil {
+		return err
+	}
+
 	stopSyncthing(dev)
 
 	if err := ssh.RemoveEntry(dev.Name); err != nil {
@@ -0,0 +1,191 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+const (
+	// WideOutput is the value of the output flag that shows all the columns
+	WideOutput = "wide"
+)
+
+// DevEnvironment represents the stored state of an active development container
+type DevEnvironment struct {
+	Name       string
+	Namespace  string
+	Folder     string
+	State      config.UpState
+	Pod        string
+	GUIAddress string
+}
+
+// ValidateOutput returns an error if the output format is not supported
+func ValidateOutput(output string) error {
+	if output == "" || output == WideOutput {
+		return nil
+	}
+	return fmt.Errorf("output format '%s' is not supported. Supported values are: '%s'", output, WideOutput)
+}
+
+// Run runs the "okteto list" sequence.
+// If namespace is set only the development containers of that namespace are listed
+func Run(w io.Writer, output, namespace string, allNamespaces bool) error {
+	envs, err := getDevEnvironments(config.GetOktetoHome())
+	if err != nil {
+		return err
+	}
+	envs = FilterByNamespace(envs, namespace)
+	return Print(w, envs, output == WideOutput, allNamespaces)
+}
+
+// FilterByNamespace returns the development containers of namespace, or all of them if namespace is empty
+func FilterByNamespace(envs []DevEnvironment, namespace string) []DevEnvironment {
+	if namespace == "" {
+		return envs
+	}
+	result := []DevEnvironment{}
+	for _, env := range envs {
+		if env.Namespace == namespace {
+			result = append(result, env)
+		}
+	}
+	return result
+}
+
+func getDevEnvironments(home string) ([]DevEnvironment, error) {
+	namespaces, err := ioutil.ReadDir(home)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", home, err)
+	}
+
+	result := []DevEnvironment{}
+	for _, ns := range namespaces {
+		if !ns.IsDir() {
+			continue
+		}
+		names, err := ioutil.ReadDir(filepath.Join(home, ns.Name()))
+		if err != nil {
+			log.Infof("failed to read %s: %s", ns.Name(), err)
+			continue
+		}
+		for _, name := range names {
+			if !name.IsDir() {
+				continue
+			}
+			for _, dev := range getDevs(home, ns.Name(), name.Name()) {
+				result = append(result, getDevEnvironment(dev))
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// getDevs returns the dev environments of a deployment with a state file, including the ones stored in a container home
+func getDevs(home, namespace, name string) []*model.Dev {
+	result := []*model.Dev{}
+	deploymentHome := filepath.Join(home, namespace, name)
+	if model.FileExists(filepath.Join(deploymentHome, config.StateFile)) {
+		result = append(result, &model.Dev{Namespace: namespace, Name: name})
+	}
+
+	containers, err := ioutil.ReadDir(deploymentHome)
+	if err != nil {
+		log.Infof("failed to read %s: %s", deploymentHome, err)
+		return result
+	}
+	for _, c := range containers {
+		if c.IsDir() && model.FileExists(filepath.Join(deploymentHome, c.Name(), config.StateFile)) {
+			result = append(result, &model.Dev{Namespace: namespace, Name: name, ManifestContainer: c.Name()})
+		}
+	}
+	return result
+}
+
+func getDevEnvironment(dev *model.Dev) DevEnvironment {
+	env := DevEnvironment{
+		Name:      dev.Name,
+		Namespace: dev.Namespace,
+	}
+
+	state, err := config.GetState(dev)
+	if err != nil {
+		log.Infof("failed to read state of %s/%s: %s", dev.Namespace, dev.Name, err)
+	}
+	env.State = state
+
+	sy, err := syncthing.Load(dev)
+	if err != nil {
+		log.Infof("failed to load syncthing info of %s/%s: %s", dev.Namespace, dev.Name, err)
+		return env
+	}
+	if len(sy.Folders) > 0 {
+		env.Folder = sy.Folders[0].LocalPath
+	}
+	env.Pod = sy.Pod
+	env.GUIAddress = sy.GUIAddress
+	return env
+}
+
+// Print writes the development containers as aligned columns.
+// The namespace column is always included in the wide output, and in the compact output if showNamespace is set
+func Print(w io.Writer, envs []DevEnvironment, wide, showNamespace bool) error {
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	switch {
+	case wide:
+		fmt.Fprintf(tw, "Name\tFolder\tStatus\tNamespace\tPod\tSyncthing\n")
+	case showNamespace:
+		fmt.Fprintf(tw, "Namespace\tName\tFolder\tStatus\n")
+	default:
+		fmt.Fprintf(tw, "Name\tFolder\tStatus\n")
+	}
+
+	for _, env := range envs {
+		switch {
+		case wide:
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", env.Name, valueOrDash(env.Folder), valueOrDash(string(env.State)), env.Namespace, valueOrDash(env.Pod), valueOrDash(env.GUIAddress))
+		case showNamespace:
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", env.Namespace, env.Name, valueOrDash(env.Folder), valueOrDash(string(env.State)))
+		default:
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", env.Name, valueOrDash(env.Folder), valueOrDash(string(env.State)))
+		}
+	}
+
+	return tw.Flush()
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func TestPrint(t *testing.T) {
+	envs := []DevEnvironment{
+		{
+			Name:       "api",
+			Namespace:  "cindy",
+			Folder:     "/home/cindy/api",
+			State:      config.Ready,
+			Pod:        "api-okteto-5d8f7b9c4-x2x7z",
+			GUIAddress: "localhost:45601",
+		},
+		{
+			Name:      "frontend",
+			Namespace: "staging",
+			State:     config.Activating,
+		},
+	}
+
+	var tests = []struct {
+		name          string
+		wide          bool
+		showNamespace bool
+		expected      string
+	}{
+		{
+			name: "compact",
+			wide: false,
+			expected: `Name      Folder           Status
+api       /home/cindy/api  ready
+frontend  -                activating
+`,
+		},
+		{
+			name: "wide",
+			wide: true,
+			expected: `Name      Folder           Status      Namespace  Pod                         Syncthing
+api       /home/cindy/api  ready       cindy      api-okteto-5d8f7b9c4-x2x7z  localhost:45601
+frontend  -                activating  staging    -                           -
+`,
+		},
+		{
+			name:          "all-namespaces",
+			showNamespace: true,
+			expected: `Namespace  Name      Folder           Status
+cindy      api       /home/cindy/api  ready
+staging    frontend  -                activating
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := Print(&b, envs, tt.wide, tt.showNamespace); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	if err := ValidateOutput(""); err != nil {
+		t.Errorf("unexpected error for default output: %s", err)
+	}
+	if err := ValidateOutput(WideOutput); err != nil {
+		t.Errorf("unexpected error for wide output: %s", err)
+	}
+	if err := ValidateOutput("json"); err == nil {
+		t.Error("expected error for unsupported output")
+	}
+}
+
+func TestFilterByNamespace(t *testing.T) {
+	envs := []DevEnvironment{
+		{Name: "api", Namespace: "cindy"},
+		{Name: "frontend", Namespace: "staging"},
+		{Name: "worker", Namespace: "cindy"},
+	}
+
+	var tests = []struct {
+		name      string
+		namespace string
+		expected  []string
+	}{
+		{
+			name:      "all",
+			namespace: "",
+			expected:  []string{"api", "frontend", "worker"},
+		},
+		{
+			name:      "cindy",
+			namespace: "cindy",
+			expected:  []string{"api", "worker"},
+		},
+		{
+			name:      "staging",
+			namespace: "staging",
+			expected:  []string{"frontend"},
+		},
+		{
+			name:      "unknown",
+			namespace: "production",
+			expected:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := []string{}
+			for _, env := range FilterByNamespace(envs, tt.namespace) {
+				result = append(result, env.Name)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_getDevs(t *testing.T) {
+	home, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	for _, p := range []string{
+		filepath.Join(home, "ns", "app"),
+		filepath.Join(home, "ns", "app", "api"),
+		filepath.Join(home, "ns", "app", "worker"),
+	} {
+		if err := os.MkdirAll(p, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range []string{
+		filepath.Join(home, "ns", "app", config.StateFile),
+		filepath.Join(home, "ns", "app", "api", config.StateFile),
+	} {
+		if err := ioutil.WriteFile(p, []byte(config.Ready), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []*model.Dev{
+		{Namespace: "ns", Name: "app"},
+		{Namespace: "ns", Name: "app", ManifestContainer: "api"},
+	}
+	if got := getDevs(home, "ns", "app"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
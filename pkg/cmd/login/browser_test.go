@@ -0,0 +1,43 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+func TestAuthorizationURLWithOktetoURL(t *testing.T) {
+	os.Setenv("OKTETO_URL", "okteto.example.com")
+	defer os.Unsetenv("OKTETO_URL")
+
+	oktetoURL, err := okteto.GetOktetoURL("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := StartWithBrowser(context.Background(), oktetoURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "https://okteto.example.com/auth/authorization-code?"
+	if got := h.AuthorizationURL(); !strings.HasPrefix(got, expected) {
+		t.Errorf("expected authorization URL to start with '%s', got '%s'", expected, got)
+	}
+}
@@ -39,9 +39,9 @@ func WithEnvVarIfAvailable(ctx context.Context) error {
 			return nil
 		}
 	}
-	oktetoURL := os.Getenv("OKTETO_URL")
-	if oktetoURL == "" {
-		oktetoURL = okteto.CloudURL
+	oktetoURL, err := okteto.GetOktetoURL("")
+	if err != nil {
+		return err
 	}
 	if _, err := WithToken(ctx, oktetoURL, oktetoToken); err != nil {
 		return fmt.Errorf("error executing auto-login with 'OKTETO_TOKEN': %s", err)
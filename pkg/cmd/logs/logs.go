@@ -0,0 +1,119 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxLineSize is the maximum size of a log line filtered by '--grep'
+const maxLineSize = 1024 * 1024
+
+// Options represents the filters of the logs command
+type Options struct {
+	Since  string
+	Grep   string
+	Tail   int64
+	Follow bool
+}
+
+// Run writes the logs of the development container to w
+func Run(ctx context.Context, dev *model.Dev, podName string, opts *Options, c kubernetes.Interface, w io.Writer) error {
+	logOpts, err := getLogOptions(dev.Container, opts, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var re *regexp.Regexp
+	if opts.Grep != "" {
+		re, err = regexp.Compile(opts.Grep)
+		if err != nil {
+			return fmt.Errorf("invalid value for '--grep': %s", err)
+		}
+	}
+
+	stream, err := c.CoreV1().Pods(dev.Namespace).GetLogs(podName, logOpts).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the logs of your development container: %s", err)
+	}
+	defer stream.Close()
+
+	return filterLines(stream, w, re)
+}
+
+// getLogOptions translates the options of the logs command to the kubernetes log options
+func getLogOptions(container string, opts *Options, now time.Time) (*apiv1.PodLogOptions, error) {
+	logOpts := &apiv1.PodLogOptions{
+		Container: container,
+		Follow:    opts.Follow,
+	}
+
+	if opts.Tail >= 0 {
+		tail := opts.Tail
+		logOpts.TailLines = &tail
+	}
+
+	if opts.Since == "" {
+		return logOpts, nil
+	}
+
+	if d, err := time.ParseDuration(opts.Since); err == nil {
+		if d <= 0 {
+			return nil, fmt.Errorf("'--since' must be a positive duration like '5m' or '1h'")
+		}
+		seconds := int64(math.Ceil(d.Seconds()))
+		logOpts.SinceSeconds = &seconds
+		return logOpts, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, opts.Since)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for '--since': it must be a duration like '5m' or a RFC3339 time like '%s'", now.UTC().Format(time.RFC3339))
+	}
+	since := metav1.NewTime(t)
+	logOpts.SinceTime = &since
+	return logOpts, nil
+}
+
+// filterLines copies to w the lines of r that match re. All the lines are copied if re is nil
+func filterLines(r io.Reader, w io.Writer, re *regexp.Regexp) error {
+	if re == nil {
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !re.MatchString(line) {
+			continue
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
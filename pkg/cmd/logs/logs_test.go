@@ -0,0 +1,164 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_getLogOptions(t *testing.T) {
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		name         string
+		opts         *Options
+		sinceSeconds int64
+		sinceTime    *time.Time
+		tail         int64
+		expectErr    bool
+	}{
+		{
+			name: "no-filters",
+			opts: &Options{Tail: -1},
+			tail: -1,
+		},
+		{
+			name:         "since-duration",
+			opts:         &Options{Since: "5m", Tail: 10, Follow: true},
+			sinceSeconds: 300,
+			tail:         10,
+		},
+		{
+			name:         "since-duration-rounds-up",
+			opts:         &Options{Since: "1500ms", Tail: -1},
+			sinceSeconds: 2,
+			tail:         -1,
+		},
+		{
+			name:      "since-time",
+			opts:      &Options{Since: "2021-06-01T09:30:00Z", Tail: -1},
+			sinceTime: timePtr(time.Date(2021, 6, 1, 9, 30, 0, 0, time.UTC)),
+			tail:      -1,
+		},
+		{
+			name:      "since-negative",
+			opts:      &Options{Since: "-5m", Tail: -1},
+			expectErr: true,
+		},
+		{
+			name:      "since-invalid",
+			opts:      &Options{Since: "yesterday", Tail: -1},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logOpts, err := getLogOptions("dev", tt.opts, now)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if logOpts.Container != "dev" {
+				t.Errorf("expected container 'dev', got '%s'", logOpts.Container)
+			}
+			if logOpts.Follow != tt.opts.Follow {
+				t.Errorf("expected follow %t, got %t", tt.opts.Follow, logOpts.Follow)
+			}
+
+			if tt.tail < 0 {
+				if logOpts.TailLines != nil {
+					t.Errorf("expected no tail, got %d", *logOpts.TailLines)
+				}
+			} else if logOpts.TailLines == nil || *logOpts.TailLines != tt.tail {
+				t.Errorf("expected tail %d, got %v", tt.tail, logOpts.TailLines)
+			}
+
+			if tt.sinceSeconds == 0 {
+				if logOpts.SinceSeconds != nil {
+					t.Errorf("expected no since seconds, got %d", *logOpts.SinceSeconds)
+				}
+			} else if logOpts.SinceSeconds == nil || *logOpts.SinceSeconds != tt.sinceSeconds {
+				t.Errorf("expected since seconds %d, got %v", tt.sinceSeconds, logOpts.SinceSeconds)
+			}
+
+			if tt.sinceTime == nil {
+				if logOpts.SinceTime != nil {
+					t.Errorf("expected no since time, got %s", logOpts.SinceTime)
+				}
+			} else if logOpts.SinceTime == nil || !logOpts.SinceTime.Time.Equal(*tt.sinceTime) {
+				t.Errorf("expected since time %s, got %v", tt.sinceTime, logOpts.SinceTime)
+			}
+		})
+	}
+}
+
+func Test_filterLines(t *testing.T) {
+	stream := "starting server\nGET /healthz 200\nERROR connection refused\nGET /api 500\nshutting down"
+	var tests = []struct {
+		name     string
+		grep     string
+		expected string
+	}{
+		{
+			name:     "no-grep",
+			expected: stream,
+		},
+		{
+			name:     "grep",
+			grep:     "GET",
+			expected: "GET /healthz 200\nGET /api 500\n",
+		},
+		{
+			name:     "grep-regex",
+			grep:     "(?i)error| 5[0-9]{2}$",
+			expected: "ERROR connection refused\nGET /api 500\n",
+		},
+		{
+			name:     "no-match",
+			grep:     "panic",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var re *regexp.Regexp
+			if tt.grep != "" {
+				re = regexp.MustCompile(tt.grep)
+			}
+
+			var out bytes.Buffer
+			if err := filterLines(strings.NewReader(stream), &out, re); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Process represents a process running in the development container
+type Process struct {
+	PID     string
+	PPID    string
+	User    string
+	Time    string
+	Command string
+}
+
+// Run lists the processes running in the development container
+func Run(ctx context.Context, dev *model.Dev, podName string, c *kubernetes.Clientset, config *rest.Config) ([]Process, error) {
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	err := exec.Exec(
+		ctx,
+		c,
+		config,
+		dev.Namespace,
+		podName,
+		dev.Container,
+		false,
+		strings.NewReader(""),
+		&out,
+		&stderr,
+		[]string{"ps", "-ef"},
+	)
+	if err != nil {
+		log.Infof("failed to execute 'ps -ef': %s: %s", err, stderr.String())
+		return nil, fmt.Errorf("failed to list the processes of your development container: 'ps' must be available in your development image")
+	}
+
+	return parse(out.String())
+}
+
+// parse returns the processes of the output of 'ps'. Columns are located by their header,
+// since procps and busybox don't print the same columns. The command column can contain spaces
+func parse(output string) ([]Process, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+		return nil, fmt.Errorf("empty 'ps' output")
+	}
+
+	header := strings.Fields(lines[0])
+	last := header[len(header)-1]
+	if last != "CMD" && last != "COMMAND" {
+		return nil, fmt.Errorf("unexpected 'ps' header: '%s'", lines[0])
+	}
+
+	result := []Process{}
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < len(header) {
+			return nil, fmt.Errorf("unexpected 'ps' line: '%s'", line)
+		}
+
+		p := Process{
+			Command: strings.Join(fields[len(header)-1:], " "),
+		}
+		for i, column := range header[:len(header)-1] {
+			switch column {
+			case "PID":
+				p.PID = fields[i]
+			case "PPID":
+				p.PPID = fields[i]
+			case "UID", "USER":
+				p.User = fields[i]
+			case "TIME":
+				p.Time = fields[i]
+			}
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ps
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parse(t *testing.T) {
+	var tests = []struct {
+		name      string
+		output    string
+		expected  []Process
+		expectErr bool
+	}{
+		{
+			name: "procps",
+			output: `UID          PID    PPID  C STIME TTY          TIME CMD
+root           1       0  0 10:01 ?        00:00:00 /var/okteto/bin/remote -r
+root          12       1  0 10:01 pts/0    00:00:02 go run main.go --port 8080
+root          57      12  0 10:02 pts/0    00:00:00 ps -ef
+`,
+			expected: []Process{
+				{PID: "1", PPID: "0", User: "root", Time: "00:00:00", Command: "/var/okteto/bin/remote -r"},
+				{PID: "12", PPID: "1", User: "root", Time: "00:00:02", Command: "go run main.go --port 8080"},
+				{PID: "57", PPID: "12", User: "root", Time: "00:00:00", Command: "ps -ef"},
+			},
+		},
+		{
+			name: "busybox",
+			output: `PID   USER     TIME  COMMAND
+    1 root      0:00 /var/okteto/bin/remote -r
+   23 root      0:01 sh
+`,
+			expected: []Process{
+				{PID: "1", User: "root", Time: "0:00", Command: "/var/okteto/bin/remote -r"},
+				{PID: "23", User: "root", Time: "0:01", Command: "sh"},
+			},
+		},
+		{
+			name:      "empty",
+			output:    "",
+			expectErr: true,
+		},
+		{
+			name:      "unknown-header",
+			output:    "sh: ps: not found",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parse(tt.output)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
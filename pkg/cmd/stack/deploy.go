@@ -18,6 +18,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"time"
 
@@ -43,7 +44,7 @@ import (
 )
 
 // Deploy deploys a stack
-func Deploy(ctx context.Context, s *model.Stack, forceBuild, wait, noCache bool) error {
+func Deploy(ctx context.Context, s *model.Stack, forceBuild, wait, noCache bool, waitTimeout time.Duration) error {
 	if s.Namespace == "" {
 		s.Namespace = client.GetContextNamespace("")
 	}
@@ -65,7 +66,7 @@ func Deploy(ctx context.Context, s *model.Stack, forceBuild, wait, noCache bool)
 		return err
 	}
 
-	err = deploy(ctx, s, wait, c, config)
+	err = deploy(ctx, s, wait, waitTimeout, c, config)
 	if err != nil {
 		output = fmt.Sprintf("%s\nStack '%s' deployment failed: %s", output, s.Name, err.Error())
 		cfg.Data[statusField] = errorStatus
@@ -83,7 +84,7 @@ func Deploy(ctx context.Context, s *model.Stack, forceBuild, wait, noCache bool)
 	return err
 }
 
-func deploy(ctx context.Context, s *model.Stack, wait bool, c *kubernetes.Clientset, config *rest.Config) error {
+func deploy(ctx context.Context, s *model.Stack, wait bool, waitTimeout time.Duration, c *kubernetes.Clientset, config *rest.Config) error {
 	DisplayWarnings(s)
 	spinner := utils.NewSpinner(fmt.Sprintf("Deploying stack '%s'...", s.Name))
 	spinner.Start()
@@ -162,7 +163,7 @@ func deploy(ctx context.Context, s *model.Stack, wait bool, c *kubernetes.Client
 	}
 
 	spinner.Update("Waiting for services to be ready...")
-	return waitForPodsToBeRunning(ctx, s, c)
+	return waitForPodsToBeRunning(ctx, s, c, waitTimeout)
 }
 
 func deploySvc(ctx context.Context, stack *model.Stack, svcName string, client kubernetes.Interface, spinner *utils.Spinner) error {
@@ -475,36 +476,67 @@ func deployIngress(ctx context.Context, ingressName string, s *model.Stack, c *i
 	return c.Update(ctx, iModel)
 }
 
-func waitForPodsToBeRunning(ctx context.Context, s *model.Stack, c *kubernetes.Clientset) error {
-	var numPods int32 = 0
-	for _, svc := range s.Services {
-		numPods += svc.Replicas
-	}
-
+func waitForPodsToBeRunning(ctx context.Context, s *model.Stack, c kubernetes.Interface, waitTimeout time.Duration) error {
 	ticker := time.NewTicker(100 * time.Millisecond)
-	timeout := time.Now().Add(300 * time.Second)
+	timeout := time.Now().Add(waitTimeout)
 
-	selector := map[string]string{model.StackNameLabel: s.Name}
+	var notReady []string
 	for time.Now().Before(timeout) {
 		<-ticker.C
-		pendingPods := numPods
-		podList, err := pods.ListBySelector(ctx, s.Namespace, selector, c)
+		var err error
+		notReady, err = getServicesNotReady(ctx, s, c)
 		if err != nil {
 			return err
 		}
-		for i := range podList {
-			if podList[i].Status.Phase == apiv1.PodRunning || podList[i].Status.Phase == apiv1.PodSucceeded {
-				pendingPods--
-			}
-			if podList[i].Status.Phase == apiv1.PodFailed {
-				return fmt.Errorf("Service '%s' has failed. Please check for errors and try again", podList[i].Labels[model.StackServiceNameLabel])
-			}
-		}
-		if pendingPods == 0 {
+		if len(notReady) == 0 {
 			return nil
 		}
 	}
-	return fmt.Errorf("kubernetes is taking too long to create your stack. Please check for errors and try again")
+	return fmt.Errorf("Services didn't reach their minimum number of ready containers after %s: %s", waitTimeout.String(), strings.Join(notReady, ", "))
+}
+
+// getServicesNotReady returns the services of the stack with less ready containers than their 'minReady' value
+func getServicesNotReady(ctx context.Context, s *model.Stack, c kubernetes.Interface) ([]string, error) {
+	selector := map[string]string{model.StackNameLabel: s.Name}
+	podList, err := pods.ListBySelector(ctx, s.Namespace, selector, c)
+	if err != nil {
+		return nil, err
+	}
+
+	ready := map[string]int32{}
+	for i := range podList {
+		svcName := podList[i].Labels[model.StackServiceNameLabel]
+		if podList[i].Status.Phase == apiv1.PodFailed {
+			return nil, fmt.Errorf("Service '%s' has failed. Please check for errors and try again", svcName)
+		}
+		if isPodReady(&podList[i]) {
+			ready[svcName]++
+		}
+	}
+
+	notReady := []string{}
+	for name, svc := range s.Services {
+		if ready[name] < svc.MinReady {
+			notReady = append(notReady, fmt.Sprintf("'%s' (%d/%d ready)", name, ready[name], svc.MinReady))
+		}
+	}
+	sort.Strings(notReady)
+	return notReady, nil
+}
+
+func isPodReady(p *apiv1.Pod) bool {
+	if p.Status.Phase == apiv1.PodSucceeded {
+		return true
+	}
+	if p.Status.Phase != apiv1.PodRunning {
+		return false
+	}
+	for _, c := range p.Status.Conditions {
+		if c.Type == apiv1.PodReady {
+			return c.Status == apiv1.ConditionTrue
+		}
+	}
+	return false
 }
 
 func DisplayWarnings(s *model.Stack) {
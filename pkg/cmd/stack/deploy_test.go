@@ -15,6 +15,7 @@ package stack
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/okteto/okteto/cmd/utils"
@@ -213,3 +214,71 @@ func Test_deployJob(t *testing.T) {
 		t.Fatal("Not deployed correctly")
 	}
 }
+
+func newStackPod(name, svcName string, phase v1.PodPhase, ready bool) *v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			Labels: map[string]string{
+				model.StackNameLabel:        "stack-test",
+				model.StackServiceNameLabel: svcName,
+			},
+		},
+		Status: v1.PodStatus{
+			Phase: phase,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodReady, Status: status},
+			},
+		},
+	}
+}
+
+func Test_getServicesNotReady(t *testing.T) {
+	ctx := context.Background()
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Services: map[string]*model.Service{
+			"api":    {Replicas: 2, MinReady: 2},
+			"worker": {Replicas: 3, MinReady: 1},
+			"db":     {Replicas: 1, MinReady: 1},
+		},
+	}
+	client := fake.NewSimpleClientset(
+		newStackPod("api-1", "api", v1.PodRunning, true),
+		newStackPod("api-2", "api", v1.PodRunning, false),
+		newStackPod("worker-1", "worker", v1.PodRunning, true),
+		newStackPod("worker-2", "worker", v1.PodPending, false),
+	)
+
+	notReady, err := getServicesNotReady(ctx, stack, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"'api' (1/2 ready)", "'db' (0/1 ready)"}
+	if !reflect.DeepEqual(notReady, expected) {
+		t.Fatalf("expected %v, got %v", expected, notReady)
+	}
+
+	stack.Services["api"].MinReady = 1
+	stack.Services["db"].MinReady = 0
+	notReady, err = getServicesNotReady(ctx, stack, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notReady) != 0 {
+		t.Fatalf("expected all services to be ready, got %v", notReady)
+	}
+
+	if _, err := client.CoreV1().Pods("ns").Create(ctx, newStackPod("db-1", "db", v1.PodFailed, false), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getServicesNotReady(ctx, stack, client); err == nil {
+		t.Fatal("expected error for failed pod")
+	}
+}
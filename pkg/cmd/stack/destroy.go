@@ -39,6 +39,42 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// helmClient is the subset of helm operations used to destroy a stack
+type helmClient interface {
+	ReleaseExists(name string) (bool, error)
+	Uninstall(name string) error
+}
+
+type actionClient struct {
+	cfg *action.Configuration
+}
+
+func newHelmClient(namespace string, spinner *utils.Spinner) (helmClient, error) {
+	settings := cli.New()
+	settings.KubeContext = os.Getenv(client.OktetoContextVariableName)
+
+	actionConfig := new(action.Configuration)
+
+	if err := actionConfig.Init(settings.RESTClientGetter(), namespace, helmDriver, func(format string, v ...interface{}) {
+		message := strings.TrimSuffix(fmt.Sprintf(format, v...), "\n")
+		spinner.Update(fmt.Sprintf("%s...", message))
+	}); err != nil {
+		return nil, fmt.Errorf("error initializing stack client: %s", err)
+	}
+	return &actionClient{cfg: actionConfig}, nil
+}
+
+// ReleaseExists returns if a helm release exists in the namespace of the client
+func (a *actionClient) ReleaseExists(name string) (bool, error) {
+	return helmReleaseExist(action.NewList(a.cfg), name)
+}
+
+// Uninstall uninstalls a helm release
+func (a *actionClient) Uninstall(name string) error {
+	_, err := action.NewUninstall(a.cfg).Run(name)
+	return err
+}
+
 // Destroy destroys a stack
 func Destroy(ctx context.Context, s *model.Stack, removeVolumes bool, timeout time.Duration) error {
 	if s.Namespace == "" {
@@ -55,7 +91,14 @@ func Destroy(ctx context.Context, s *model.Stack, removeVolumes bool, timeout ti
 		return err
 	}
 
-	err := destroy(ctx, s, removeVolumes, c, timeout)
+	spinner := utils.NewSpinner(fmt.Sprintf("Destroying stack '%s'...", s.Name))
+	spinner.Start()
+	h, err := newHelmClient(s.Namespace, spinner)
+	if err == nil {
+		err = destroy(ctx, spinner, s, removeVolumes, c, h, timeout)
+	}
+	spinner.Stop()
+
 	if err != nil {
 		output = fmt.Sprintf("%s\nStack '%s' destruction failed: %s", output, s.Name, err.Error())
 		cfg.Data[statusField] = errorStatus
@@ -69,12 +112,8 @@ func Destroy(ctx context.Context, s *model.Stack, removeVolumes bool, timeout ti
 	return err
 }
 
-func destroy(ctx context.Context, s *model.Stack, removeVolumes bool, c *kubernetes.Clientset, timeout time.Duration) error {
-	spinner := utils.NewSpinner(fmt.Sprintf("Destroying stack '%s'...", s.Name))
-	spinner.Start()
-	defer spinner.Stop()
-
-	if err := destroyHelmRelease(ctx, spinner, s); err != nil {
+func destroy(ctx context.Context, spinner *utils.Spinner, s *model.Stack, removeVolumes bool, c kubernetes.Interface, h helmClient, timeout time.Duration) error {
+	if err := destroyHelmRelease(spinner, h, s); err != nil {
 		return err
 	}
 
@@ -113,33 +152,25 @@ func helmReleaseExist(c *action.List, name string) (bool, error) {
 	return false, nil
 }
 
-func destroyHelmRelease(ctx context.Context, spinner *utils.Spinner, s *model.Stack) error {
-	settings := cli.New()
-	settings.KubeContext = os.Getenv(client.OktetoContextVariableName)
-
-	actionConfig := new(action.Configuration)
-
-	if err := actionConfig.Init(settings.RESTClientGetter(), s.Namespace, helmDriver, func(format string, v ...interface{}) {
-		message := strings.TrimSuffix(fmt.Sprintf(format, v...), "\n")
-		spinner.Update(fmt.Sprintf("%s...", message))
-	}); err != nil {
-		return fmt.Errorf("error initializing stack client: %s", err)
-	}
-
-	exists, err := helmReleaseExist(action.NewList(actionConfig), s.Name)
+func destroyHelmRelease(spinner *utils.Spinner, h helmClient, s *model.Stack) error {
+	exists, err := h.ReleaseExists(s.Name)
 	if err != nil {
 		return fmt.Errorf("error listing stacks: %s", err)
 	}
-	if exists {
-		uClient := action.NewUninstall(actionConfig)
-		if _, err := uClient.Run(s.Name); err != nil {
-			return fmt.Errorf("error destroying stack '%s': %s", s.Name, err.Error())
-		}
+	if !exists {
+		return nil
 	}
+
+	if err := h.Uninstall(s.Name); err != nil {
+		return fmt.Errorf("error destroying stack '%s': %s", s.Name, err.Error())
+	}
+	spinner.Stop()
+	log.Success("Uninstalled release '%s'", s.Name)
+	spinner.Start()
 	return nil
 }
 
-func destroyServicesNotInStack(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c *kubernetes.Clientset) error {
+func destroyServicesNotInStack(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c kubernetes.Interface) error {
 	if err := destroyDeployments(ctx, spinner, s, c); err != nil {
 		return err
 	}
@@ -160,7 +191,7 @@ func destroyServicesNotInStack(ctx context.Context, spinner *utils.Spinner, s *m
 	return nil
 }
 
-func destroyDeployments(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c *kubernetes.Clientset) error {
+func destroyDeployments(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c kubernetes.Interface) error {
 	dList, err := deployments.List(ctx, s.Namespace, s.GetLabelSelector(), c)
 	if err != nil {
 		return err
@@ -182,7 +213,7 @@ func destroyDeployments(ctx context.Context, spinner *utils.Spinner, s *model.St
 	return nil
 }
 
-func destroyStatefulsets(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c *kubernetes.Clientset) error {
+func destroyStatefulsets(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c kubernetes.Interface) error {
 	sfsList, err := statefulsets.List(ctx, s.Namespace, s.GetLabelSelector(), c)
 	if err != nil {
 		return err
@@ -203,7 +234,7 @@ func destroyStatefulsets(ctx context.Context, spinner *utils.Spinner, s *model.S
 	}
 	return nil
 }
-func destroyJobs(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c *kubernetes.Clientset) error {
+func destroyJobs(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c kubernetes.Interface) error {
 	jobsList, err := jobs.List(ctx, s.Namespace, s.GetLabelSelector(), c)
 	if err != nil {
 		return err
@@ -225,7 +256,7 @@ func destroyJobs(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c
 	return nil
 }
 
-func destroyIngresses(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c *kubernetes.Clientset) error {
+func destroyIngresses(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c kubernetes.Interface) error {
 	iClient, err := ingresses.GetClient(ctx, c)
 	if err != nil {
 		return fmt.Errorf("error getting ingress client: %s", err.Error())
@@ -293,7 +324,7 @@ func isAutocreatedVolume(s *model.Stack, volumeName string) bool {
 	return false
 }
 
-func waitForPodsToBeDestroyed(ctx context.Context, s *model.Stack, c *kubernetes.Clientset) error {
+func waitForPodsToBeDestroyed(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	timeout := time.Now().Add(300 * time.Second)
 
@@ -311,7 +342,7 @@ func waitForPodsToBeDestroyed(ctx context.Context, s *model.Stack, c *kubernetes
 	return fmt.Errorf("kubernetes is taking too long to destroy your stack. Please check for errors and try again")
 }
 
-func destroyStackVolumes(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c *kubernetes.Clientset, timeout time.Duration) error {
+func destroyStackVolumes(ctx context.Context, spinner *utils.Spinner, s *model.Stack, c kubernetes.Interface, timeout time.Duration) error {
 	vList, err := volumes.List(ctx, s.Namespace, s.GetLabelSelector(), c)
 	if err != nil {
 		return err
@@ -0,0 +1,93 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeHelmClient struct {
+	releases map[string]bool
+}
+
+func (f *fakeHelmClient) ReleaseExists(name string) (bool, error) {
+	return f.releases[name], nil
+}
+
+func (f *fakeHelmClient) Uninstall(name string) error {
+	delete(f.releases, name)
+	return nil
+}
+
+func Test_destroy(t *testing.T) {
+	var tests = []struct {
+		name          string
+		removeVolumes bool
+	}{
+		{
+			name:          "keep-volumes",
+			removeVolumes: false,
+		},
+		{
+			name:          "remove-volumes",
+			removeVolumes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pvc := &apiv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "data",
+					Namespace: "ns",
+					Labels:    map[string]string{model.StackNameLabel: "stack-test"},
+				},
+			}
+			c := fake.NewSimpleClientset(pvc)
+			c.Resources = []*metav1.APIResourceList{
+				{
+					GroupVersion: "networking.k8s.io/v1",
+					APIResources: []metav1.APIResource{{Kind: "Ingress"}},
+				},
+			}
+			h := &fakeHelmClient{releases: map[string]bool{"stack-test": true}}
+			s := &model.Stack{Name: "stack-test", Namespace: "ns"}
+
+			if err := destroy(ctx, utils.NewSpinner("destroying"), s, tt.removeVolumes, c, h, 5*time.Second); err != nil {
+				t.Fatal(err)
+			}
+
+			if h.releases["stack-test"] {
+				t.Errorf("the helm release wasn't uninstalled")
+			}
+
+			_, err := c.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data", metav1.GetOptions{})
+			if tt.removeVolumes && err == nil {
+				t.Errorf("the volume wasn't removed")
+			}
+			if !tt.removeVolumes && err != nil {
+				t.Errorf("the volume was removed without the volumes flag: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// logsPollInterval is the interval to look for new pods of the stack when following its logs
+	logsPollInterval = 2 * time.Second
+
+	// maxLogLineSize is the maximum size of a log line
+	maxLogLineSize = 1024 * 1024
+)
+
+var logColors = []color.Attribute{color.FgCyan, color.FgGreen, color.FgMagenta, color.FgYellow, color.FgBlue, color.FgHiRed}
+
+// LogsOptions represents the options of the stack logs command
+type LogsOptions struct {
+	Tail   int64
+	Follow bool
+}
+
+// logWriter multiplexes the log lines of several pods, prefixing each line with its service and pod
+type logWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	colors map[string]*color.Color
+}
+
+func newLogWriter(w io.Writer) *logWriter {
+	return &logWriter{w: w, colors: map[string]*color.Color{}}
+}
+
+// prefix returns the prefix of the log lines of a pod, with a different color for each service
+func (lw *logWriter) prefix(service, pod string) string {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	c, ok := lw.colors[service]
+	if !ok {
+		c = color.New(logColors[len(lw.colors)%len(logColors)])
+		lw.colors[service] = c
+	}
+	return c.Sprintf("%s/%s |", service, pod)
+}
+
+// writeLines copies the lines of r to the writer, prefixed with the service and pod
+func (lw *logWriter) writeLines(r io.Reader, service, pod string) error {
+	prefix := lw.prefix(service, pod)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		lw.mu.Lock()
+		_, err := fmt.Fprintf(lw.w, "%s %s\n", prefix, scanner.Text())
+		lw.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Logs writes to w the logs of the pods of all the services of a stack.
+// When following the logs, new pods of the stack are streamed as they are created
+func Logs(ctx context.Context, s *model.Stack, opts *LogsOptions, c kubernetes.Interface, w io.Writer) error {
+	lw := newLogWriter(w)
+	var wg sync.WaitGroup
+	streaming := map[string]bool{}
+	tail := opts.Tail
+	ticker := time.NewTicker(logsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		podList, err := c.CoreV1().Pods(s.Namespace).List(ctx, metav1.ListOptions{LabelSelector: s.GetLabelSelector()})
+		if err != nil {
+			return fmt.Errorf("failed to list the pods of stack '%s': %s", s.Name, err)
+		}
+
+		if len(streaming) == 0 && len(podList.Items) == 0 && !opts.Follow {
+			return errors.UserError{
+				E:    fmt.Errorf("no pods found for stack '%s' in namespace '%s'", s.Name, s.Namespace),
+				Hint: "Run 'okteto stack deploy' to deploy your stack and try again",
+			}
+		}
+
+		current := map[string]bool{}
+		for i := range podList.Items {
+			p := &podList.Items[i]
+			current[p.Name] = true
+			if p.Status.Phase == apiv1.PodPending {
+				continue
+			}
+
+			if streaming[p.Name] {
+				continue
+			}
+			streaming[p.Name] = true
+
+			wg.Add(1)
+			go func(p *apiv1.Pod, tail int64) {
+				defer wg.Done()
+				if err := streamPodLogs(ctx, p, tail, opts.Follow, c, lw); err != nil {
+					log.Infof("failed to get the logs of pod '%s': %s", p.Name, err)
+				}
+			}(p, tail)
+		}
+
+		if !opts.Follow {
+			wg.Wait()
+			return nil
+		}
+
+		for name := range streaming {
+			if !current[name] {
+				delete(streaming, name)
+			}
+		}
+
+		// pods created after the first iteration show all their logs
+		tail = -1
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func streamPodLogs(ctx context.Context, p *apiv1.Pod, tail int64, follow bool, c kubernetes.Interface, lw *logWriter) error {
+	logOpts := &apiv1.PodLogOptions{
+		Container: p.Spec.Containers[0].Name,
+		Follow:    follow,
+	}
+	if tail >= 0 {
+		logOpts.TailLines = &tail
+	}
+
+	stream, err := c.CoreV1().Pods(p.Namespace).GetLogs(p.Name, logOpts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	service := p.Labels[model.StackServiceNameLabel]
+	if service == "" {
+		service = p.Name
+	}
+	return lw.writeLines(stream, service, p.Name)
+}
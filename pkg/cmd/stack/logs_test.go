@@ -0,0 +1,120 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_logWriter(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	var b bytes.Buffer
+	lw := newLogWriter(&b)
+	streams := map[string]string{
+		"api":    "starting api\nlistening on 8080\n",
+		"worker": "starting worker\nconnected to queue\nprocessing jobs\n",
+	}
+
+	var wg sync.WaitGroup
+	for service, stream := range streams {
+		wg.Add(1)
+		go func(service, stream string) {
+			defer wg.Done()
+			if err := lw.writeLines(strings.NewReader(stream), service, service+"-1"); err != nil {
+				t.Error(err)
+			}
+		}(service, stream)
+	}
+	wg.Wait()
+
+	lines := map[string][]string{}
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		parts := strings.SplitN(line, " | ", 2)
+		if len(parts) != 2 {
+			t.Fatalf("line without prefix: %q", line)
+		}
+		lines[parts[0]] = append(lines[parts[0]], parts[1])
+	}
+
+	for service, stream := range streams {
+		expected := strings.TrimSuffix(stream, "\n")
+		got := strings.Join(lines[service+"/"+service+"-1"], "\n")
+		if got != expected {
+			t.Errorf("expected lines of '%s' to be %q, got %q", service, expected, got)
+		}
+	}
+}
+
+func TestLogs(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	ctx := context.Background()
+	s := &model.Stack{Name: "stack", Namespace: "test"}
+	newPod := func(name, service string, phase apiv1.PodPhase) *apiv1.Pod {
+		return &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels: map[string]string{
+					model.StackNameLabel:        "stack",
+					model.StackServiceNameLabel: service,
+				},
+			},
+			Spec:   apiv1.PodSpec{Containers: []apiv1.Container{{Name: service}}},
+			Status: apiv1.PodStatus{Phase: phase},
+		}
+	}
+
+	c := fake.NewSimpleClientset(
+		newPod("api-1", "api", apiv1.PodRunning),
+		newPod("worker-1", "worker", apiv1.PodRunning),
+		newPod("worker-2", "worker", apiv1.PodPending),
+	)
+
+	var b bytes.Buffer
+	if err := Logs(ctx, s, &LogsOptions{Tail: -1}, c, &b); err != nil {
+		t.Fatal(err)
+	}
+
+	output := b.String()
+	for _, prefix := range []string{"api/api-1 | ", "worker/worker-1 | "} {
+		if !strings.Contains(output, prefix) {
+			t.Errorf("output doesn't contain the logs of '%s': %q", prefix, output)
+		}
+	}
+	if strings.Contains(output, "worker-2") {
+		t.Errorf("output contains the logs of a pending pod: %q", output)
+	}
+
+	err := Logs(ctx, &model.Stack{Name: "other", Namespace: "test"}, &LogsOptions{Tail: -1}, c, &b)
+	if _, ok := err.(errors.UserError); !ok {
+		t.Errorf("expected a user error, got %v", err)
+	}
+}
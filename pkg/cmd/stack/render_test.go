@@ -0,0 +1,84 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func TestRender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := fmt.Sprintf(`name: voting
+services:
+  vote:
+    image: okteto/vote:${VOTE_TAG}
+    environment:
+      - OPTION_A=${OPTION_A:-cats}
+    env_file: %s
+    ports:
+      - 8080
+`, filepath.Join(dir, ".env.vote"))
+	stackPath := filepath.Join(dir, "okteto-stack.yml")
+	if err := ioutil.WriteFile(stackPath, []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, ".env.vote"), []byte("OPTION_B=dogs\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("VOTE_TAG", "1.0.0")
+	defer os.Unsetenv("VOTE_TAG")
+	os.Unsetenv("OPTION_A")
+
+	s, err := model.GetStack("", stackPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := Render(context.Background(), s, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	rendered := out.String()
+	expected := []string{
+		"name: voting",
+		"image: okteto/vote:1.0.0",
+		"OPTION_A=cats",
+		"OPTION_B=dogs",
+		"replicas: 1",
+	}
+	for _, e := range expected {
+		if !strings.Contains(rendered, e) {
+			t.Errorf("rendered stack doesn't contain '%s':\n%s", e, rendered)
+		}
+	}
+	if strings.Contains(rendered, "env_file") {
+		t.Errorf("rendered stack contains env files:\n%s", rendered)
+	}
+}
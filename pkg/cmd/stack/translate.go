@@ -69,6 +69,10 @@ func translate(ctx context.Context, s *model.Stack, forceBuild, noCache bool) er
 
 func translateStackEnvVars(ctx context.Context, s *model.Stack) error {
 	isOktetoNamespace := namespaces.IsOktetoNamespaceFromName(ctx, s.Namespace)
+	return translateEnvFiles(ctx, s, isOktetoNamespace)
+}
+
+func translateEnvFiles(ctx context.Context, s *model.Stack, isOktetoNamespace bool) error {
 	for svcName, svc := range s.Services {
 		for _, envFilepath := range svc.EnvFiles {
 			if err := translateServiceEnvFile(ctx, svc, svcName, envFilepath, isOktetoNamespace); err != nil {
@@ -0,0 +1,198 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const listeningTimeout = 500 * time.Millisecond
+
+// Info represents the state displayed by "okteto status"
+type Info struct {
+	Progress        float64       `json:"progress"`
+	SyncAvailable   bool          `json:"syncAvailable"`
+	LocalConnected  bool          `json:"localConnected"`
+	RemoteConnected bool          `json:"remoteConnected"`
+	Pod             string        `json:"pod,omitempty"`
+	PodPhase        string        `json:"podPhase,omitempty"`
+	Forwards        []ForwardInfo `json:"forwards,omitempty"`
+	Ready           bool          `json:"ready"`
+}
+
+// ForwardInfo represents the state of a local port forwarded by "okteto up"
+type ForwardInfo struct {
+	Local     int    `json:"local"`
+	Remote    int    `json:"remote"`
+	Service   string `json:"service,omitempty"`
+	Listening bool   `json:"listening"`
+}
+
+// GetInfo reads the synchronization, connection and pod state of the development container
+func GetInfo(ctx context.Context, dev *model.Dev, sy *syncthing.Syncthing, c *kubernetes.Clientset) *Info {
+	info := &Info{
+		LocalConnected:  sy.Ping(ctx, true),
+		RemoteConnected: sy.Ping(ctx, false),
+	}
+
+	progress, err := Run(ctx, dev, sy)
+	if err == nil {
+		info.Progress = progress
+		info.SyncAvailable = true
+	}
+
+	pod, err := pods.GetDevPod(ctx, dev, c, false)
+	if err != nil {
+		log.Infof("error getting the development pod: %s", err)
+	}
+	if pod != nil {
+		info.Pod = pod.Name
+		info.PodPhase = string(pod.Status.Phase)
+	}
+
+	info.Forwards = getForwardsInfo(dev)
+	info.Ready = info.SyncAvailable && info.Progress == 100 && info.PodPhase == string(apiv1.PodRunning)
+	return info
+}
+
+// getForwardsInfo checks if the local ports of the tcp forwards of the development container are listening
+func getForwardsInfo(dev *model.Dev) []ForwardInfo {
+	result := []ForwardInfo{}
+	for _, f := range dev.Forward {
+		if f.Protocol != "" && !strings.EqualFold(f.Protocol, "tcp") {
+			continue
+		}
+		result = append(result, ForwardInfo{
+			Local:     f.Local,
+			Remote:    f.Remote,
+			Service:   f.ServiceName,
+			Listening: isListening(f.GetDialAddress(dev.Interface)),
+		})
+	}
+	return result
+}
+
+func isListening(address string) bool {
+	conn, err := net.DialTimeout("tcp", address, listeningTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// Render returns the lines displayed for info, truncated to the terminal width
+func Render(info *Info, width int) []string {
+	sync := "unavailable"
+	if info.SyncAvailable {
+		sync = fmt.Sprintf("%.2f%%", info.Progress)
+		if info.Progress == 100 {
+			sync = fmt.Sprintf("%s (files synchronized)", sync)
+		}
+	}
+
+	connection := "healthy"
+	switch {
+	case !info.LocalConnected && !info.RemoteConnected:
+		connection = "lost"
+	case !info.LocalConnected:
+		connection = "local syncthing is not responding"
+	case !info.RemoteConnected:
+		connection = "remote syncthing is not responding"
+	}
+
+	pod := "not found"
+	if info.Pod != "" {
+		pod = fmt.Sprintf("%s (%s)", info.Pod, info.PodPhase)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Synchronization status: %s", sync),
+		fmt.Sprintf("Connection:             %s", connection),
+		fmt.Sprintf("Pod:                    %s", pod),
+	}
+	if len(info.Forwards) > 0 {
+		forwards := []string{}
+		for _, f := range info.Forwards {
+			state := "listening"
+			if !f.Listening {
+				state = "not listening"
+			}
+			forwards = append(forwards, fmt.Sprintf("%d -> %d (%s)", f.Local, f.Remote, state))
+		}
+		lines = append(lines, fmt.Sprintf("Forwards:               %s", strings.Join(forwards, ", ")))
+	}
+	for i := range lines {
+		lines[i] = truncate(lines[i], width)
+	}
+	return lines
+}
+
+func truncate(line string, width int) string {
+	if width <= 4 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	return string([]rune(line)[:width-4]) + "..."
+}
+
+// Screen redraws a block of lines in place
+type Screen struct {
+	out   io.Writer
+	lines []string
+}
+
+// NewScreen returns a new Screen writing to out
+func NewScreen(out io.Writer) *Screen {
+	return &Screen{out: out}
+}
+
+// Draw replaces the lines written by the previous call with lines.
+// A width <= 0 means that out is not a terminal and lines are just appended.
+func (s *Screen) Draw(lines []string, width int) {
+	if width > 0 && len(s.lines) > 0 {
+		fmt.Fprintf(s.out, "\033[%dA\r\033[J", rows(s.lines, width))
+	}
+	for _, l := range lines {
+		fmt.Fprintln(s.out, l)
+	}
+	s.lines = lines
+}
+
+// rows returns the number of terminal rows used by lines, taking into account
+// that previous lines wrap if the terminal was resized to a smaller width
+func rows(lines []string, width int) int {
+	result := 0
+	for _, l := range lines {
+		n := utf8.RuneCountInString(l)
+		if n == 0 {
+			result++
+			continue
+		}
+		result += (n + width - 1) / width
+	}
+	return result
+}
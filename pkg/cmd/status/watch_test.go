@@ -0,0 +1,169 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func TestRender(t *testing.T) {
+	var tests = []struct {
+		name     string
+		info     *Info
+		width    int
+		expected []string
+	}{
+		{
+			name: "synchronized",
+			info: &Info{
+				Progress:        100,
+				SyncAvailable:   true,
+				LocalConnected:  true,
+				RemoteConnected: true,
+				Pod:             "api-6d8f9c5b7-x2k4p",
+				PodPhase:        "Running",
+			},
+			width: 80,
+			expected: []string{
+				"Synchronization status: 100.00% (files synchronized)",
+				"Connection:             healthy",
+				"Pod:                    api-6d8f9c5b7-x2k4p (Running)",
+			},
+		},
+		{
+			name: "synchronizing",
+			info: &Info{
+				Progress:        42.5,
+				SyncAvailable:   true,
+				LocalConnected:  true,
+				RemoteConnected: false,
+				Pod:             "api-6d8f9c5b7-x2k4p",
+				PodPhase:        "Pending",
+			},
+			width: 0,
+			expected: []string{
+				"Synchronization status: 42.50%",
+				"Connection:             remote syncthing is not responding",
+				"Pod:                    api-6d8f9c5b7-x2k4p (Pending)",
+			},
+		},
+		{
+			name: "forwards",
+			info: &Info{
+				Progress:        100,
+				SyncAvailable:   true,
+				LocalConnected:  true,
+				RemoteConnected: true,
+				Pod:             "api-6d8f9c5b7-x2k4p",
+				PodPhase:        "Running",
+				Forwards:        []ForwardInfo{{Local: 8080, Remote: 8080, Listening: true}, {Local: 9229, Remote: 9229}},
+			},
+			width: 0,
+			expected: []string{
+				"Synchronization status: 100.00% (files synchronized)",
+				"Connection:             healthy",
+				"Pod:                    api-6d8f9c5b7-x2k4p (Running)",
+				"Forwards:               8080 -> 8080 (listening), 9229 -> 9229 (not listening)",
+			},
+		},
+		{
+			name:  "disconnected",
+			info:  &Info{},
+			width: 30,
+			expected: []string{
+				"Synchronization status: un...",
+				"Connection:             lost",
+				"Pod:                    no...",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Render(tt.info, tt.width)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("wrong render.\nActual:   %q\nExpected: %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScreenDraw(t *testing.T) {
+	out := &bytes.Buffer{}
+	s := NewScreen(out)
+
+	s.Draw([]string{"a", "b"}, 80)
+	if out.String() != "a\nb\n" {
+		t.Fatalf("wrong first draw: %q", out.String())
+	}
+
+	out.Reset()
+	s.Draw([]string{"c", "d"}, 80)
+	if out.String() != "\033[2A\r\033[Jc\nd\n" {
+		t.Fatalf("wrong redraw: %q", out.String())
+	}
+
+	out.Reset()
+	s.Draw([]string{"0123456789", "e"}, 10)
+	s.Draw([]string{"f"}, 5)
+	if out.String() != "\033[2A\r\033[J0123456789\ne\n\033[3A\r\033[Jf\n" {
+		t.Fatalf("wrong redraw after resize: %q", out.String())
+	}
+
+	out.Reset()
+	s = NewScreen(out)
+	s.Draw([]string{"a"}, 0)
+	s.Draw([]string{"b"}, 0)
+	if out.String() != "a\nb\n" {
+		t.Fatalf("wrong draw without terminal: %q", out.String())
+	}
+}
+
+func Test_getForwardsInfo(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listening := l.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	notListening := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+	defer l.Close()
+
+	dev := &model.Dev{
+		Interface: "127.0.0.1",
+		Forward: []model.Forward{
+			{Local: listening, Remote: 8080},
+			{Local: notListening, Remote: 9229},
+			{Local: 5353, Remote: 53, Protocol: "udp"},
+		},
+	}
+
+	expected := []ForwardInfo{
+		{Local: listening, Remote: 8080, Listening: true},
+		{Local: notListening, Remote: 9229, Listening: false},
+	}
+	if result := getForwardsInfo(dev); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+}
@@ -27,7 +27,11 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-// UpState represents the state of the up command
+// UpState represents the state of the up command. It is written to the state file of the development container,
+// and to the path of '--save-state-file' if set. A regular activation goes through:
+// activating -> starting -> [attaching | pulling]* -> startingSync -> synchronizing -> ready.
+// The sequence starts again from activating when 'okteto up' reconnects, and the state file is deleted when 'okteto up' exits.
+// The state is failed when the activation times out, and the state file is kept so scripts can read it
 type UpState string
 
 const (
@@ -47,8 +51,9 @@ const (
 	//Ready up finished
 	Ready UpState = "ready"
 	//Failed up failed
-	Failed    UpState = "failed"
-	stateFile         = "okteto.state"
+	Failed UpState = "failed"
+	//StateFile name of the file that stores the state of the up command
+	StateFile = "okteto.state"
 )
 
 // VersionString the version of the cli
@@ -108,8 +113,28 @@ func GetDeploymentHome(namespace, name string) string {
 	return d
 }
 
-// UpdateStateFile updates the state file of a given dev environment
-func UpdateStateFile(dev *model.Dev, state UpState) error {
+// GetContainerHome returns the path of the folder of a container of a deployment
+func GetContainerHome(namespace, name, container string) string {
+	d := filepath.Join(GetDeploymentHome(namespace, name), container)
+
+	if err := os.MkdirAll(d, 0700); err != nil {
+		log.Fatalf("failed to create %s: %s", d, err)
+	}
+
+	return d
+}
+
+// GetDevHome returns the folder of a development container, where its state and syncthing files are stored.
+// It includes the container when it's set in the okteto manifest, so manifests for different containers of the same deployment don't share them
+func GetDevHome(dev *model.Dev) string {
+	if dev.ManifestContainer == "" {
+		return GetDeploymentHome(dev.Namespace, dev.Name)
+	}
+	return GetContainerHome(dev.Namespace, dev.Name, dev.ManifestContainer)
+}
+
+// UpdateStateFile updates the state file of a given dev environment, and extraStateFile if not empty
+func UpdateStateFile(dev *model.Dev, state UpState, extraStateFile string) error {
 	if dev.Namespace == "" {
 		return fmt.Errorf("can't update state file, namespace is empty")
 	}
@@ -118,16 +143,46 @@ func UpdateStateFile(dev *model.Dev, state UpState) error {
 		return fmt.Errorf("can't update state file, name is empty")
 	}
 
-	s := filepath.Join(GetDeploymentHome(dev.Namespace, dev.Name), stateFile)
-	if err := ioutil.WriteFile(s, []byte(state), 0644); err != nil {
+	s := filepath.Join(GetDevHome(dev), StateFile)
+	if err := writeFileAtomic(s, []byte(state), 0644); err != nil {
 		return fmt.Errorf("failed to update state file: %s", err)
 	}
 
+	if extraStateFile != "" {
+		if err := writeFileAtomic(extraStateFile, []byte(state), 0644); err != nil {
+			return fmt.Errorf("failed to update state file '%s': %s", extraStateFile, err)
+		}
+	}
+
 	return nil
 }
 
-// DeleteStateFile deletes the state file of a given dev environment
-func DeleteStateFile(dev *model.Dev) error {
+// writeFileAtomic writes data to a temporary file and renames it to path, so readers never see a partial content
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), fmt.Sprintf(".%s-*", filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(f.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// DeleteStateFile deletes the state file of a given dev environment, and extraStateFile if not empty
+func DeleteStateFile(dev *model.Dev, extraStateFile string) error {
 	if dev.Namespace == "" {
 		return fmt.Errorf("can't delete state file, namespace is empty")
 	}
@@ -136,7 +191,13 @@ func DeleteStateFile(dev *model.Dev) error {
 		return fmt.Errorf("can't delete state file, name is empty")
 	}
 
-	s := filepath.Join(GetDeploymentHome(dev.Namespace, dev.Name), stateFile)
+	if extraStateFile != "" {
+		if err := os.Remove(extraStateFile); err != nil && !os.IsNotExist(err) {
+			log.Infof("failed to delete state file '%s': %s", extraStateFile, err)
+		}
+	}
+
+	s := filepath.Join(GetDevHome(dev), StateFile)
 	return os.Remove(s)
 }
 
@@ -151,7 +212,7 @@ func GetState(dev *model.Dev) (UpState, error) {
 		return Failed, fmt.Errorf("can't update state file, name is empty")
 	}
 
-	statePath := filepath.Join(GetDeploymentHome(dev.Namespace, dev.Name), stateFile)
+	statePath := filepath.Join(GetDevHome(dev), StateFile)
 	stateBytes, err := ioutil.ReadFile(statePath)
 	if err != nil {
 		log.Infof("error reading state file: %s", err.Error())
@@ -17,7 +17,10 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
 )
 
 func TestGetUserHomeDir(t *testing.T) {
@@ -146,3 +149,139 @@ func TestGetDeploymentHome(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+func TestGetDevHome(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	defer os.Unsetenv("OKTETO_FOLDER")
+
+	var tests = []struct {
+		name     string
+		dev      *model.Dev
+		expected string
+	}{
+		{
+			name:     "no-container",
+			dev:      &model.Dev{Namespace: "ns", Name: "dp"},
+			expected: filepath.Join(dir, "ns", "dp"),
+		},
+		{
+			name:     "container",
+			dev:      &model.Dev{Namespace: "ns", Name: "dp", ManifestContainer: "api"},
+			expected: filepath.Join(dir, "ns", "dp", "api"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetDevHome(tt.dev); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+
+			if err := UpdateStateFile(tt.dev, Ready, ""); err != nil {
+				t.Fatal(err)
+			}
+			if !model.FileExists(filepath.Join(tt.expected, StateFile)) {
+				t.Errorf("the state file wasn't written in %s", tt.expected)
+			}
+			if state, err := GetState(tt.dev); err != nil || state != Ready {
+				t.Errorf("expected state '%s', got '%s': %v", Ready, state, err)
+			}
+			if err := DeleteStateFile(tt.dev, ""); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestUpdateStateFileExtraPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	defer os.Unsetenv("OKTETO_FOLDER")
+
+	stateDir := filepath.Join(dir, "ide")
+	if err := os.MkdirAll(stateDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	statePath := filepath.Join(stateDir, "okteto.state")
+
+	dev := &model.Dev{Name: "dev", Namespace: "ns"}
+	states := []UpState{Activating, Starting, Attaching, Pulling, StartingSync, Synchronizing, Ready}
+	valid := map[string]bool{}
+	for _, s := range states {
+		valid[string(s)] = true
+	}
+
+	done := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			b, err := ioutil.ReadFile(statePath)
+			if err != nil {
+				continue
+			}
+			if !valid[string(b)] {
+				t.Errorf("read a partial state: '%s'", string(b))
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		for _, s := range states {
+			if err := UpdateStateFile(dev, s, statePath); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	b, err := ioutil.ReadFile(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if UpState(b) != Ready {
+		t.Errorf("expected state '%s', got '%s'", Ready, string(b))
+	}
+
+	state, err := GetState(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != Ready {
+		t.Errorf("expected default state '%s', got '%s'", Ready, state)
+	}
+
+	files, err := ioutil.ReadDir(stateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("temporary files were left behind: %d files", len(files))
+	}
+
+	if err := DeleteStateFile(dev, statePath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Errorf("the state file wasn't deleted: %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/shirou/gopsutil/process"
+	"gopkg.in/yaml.v2"
+)
+
+const sessionsFolderName = ".sessions"
+
+// Session represents the development container started by 'okteto up' from a manifest
+type Session struct {
+	Manifest  string `yaml:"manifest"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+	Context   string `yaml:"context,omitempty"`
+
+	// Hostname is the machine where 'okteto up' runs. The process, ports and env file of the session are only used on it
+	Hostname string `yaml:"hostname,omitempty"`
+
+	// PID, Executable and StartTime identify the 'okteto up' process that owns the local forwards.
+	// StartTime is the creation time of the process in milliseconds since the epoch, so a reused pid isn't mistaken for it
+	PID        int    `yaml:"pid,omitempty"`
+	Executable string `yaml:"executable,omitempty"`
+	StartTime  int64  `yaml:"startTime,omitempty"`
+	Interface  string `yaml:"interface,omitempty"`
+	Ports      []int  `yaml:"ports,omitempty"`
+
+	// EnvFile is the dotenv file with the forwarded endpoints written by 'okteto up --write-env'
+	EnvFile string `yaml:"envFile,omitempty"`
+}
+
+// SessionStore stores the sessions of the development containers started by 'okteto up', indexed by a key computed from the manifest path
+type SessionStore interface {
+	// Get returns the session stored for key, or nil if there is none
+	Get(ctx context.Context, key string) (*Session, error)
+	Save(ctx context.Context, key string, s *Session) error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewFileSessionStore returns the default session store, which stores each session in a file of the okteto home
+func NewFileSessionStore() SessionStore {
+	return &fileSessionStore{}
+}
+
+// GetSessionKey returns the key of the session of a development environment of a manifest.
+// devKey is the key of the development environment in manifests that define several ones, and empty otherwise
+func GetSessionKey(manifestPath, devKey string) (string, error) {
+	abs, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the absolute path of '%s': %s", manifestPath, err)
+	}
+
+	if devKey != "" {
+		// NUL can't be part of a path, so the key of a development environment never matches another manifest
+		abs = abs + "\x00" + devKey
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(abs))), nil
+}
+
+// SaveSession records the name, namespace and context of the development container started from a manifest,
+// along with the current process and the local ports it listens on
+func SaveSession(ctx context.Context, store SessionStore, manifestPath string, dev *model.Dev) error {
+	key, err := GetSessionKey(manifestPath, dev.ManifestKey())
+	if err != nil {
+		return err
+	}
+
+	abs, _ := filepath.Abs(manifestPath)
+	s := &Session{
+		Manifest:  abs,
+		Name:      dev.Name,
+		Namespace: dev.Namespace,
+		Context:   dev.Context,
+		PID:       os.Getpid(),
+		Interface: dev.Interface,
+		Ports:     getLocalPorts(dev),
+	}
+
+	if exe, err := os.Executable(); err == nil {
+		s.Executable = exe
+	}
+	if p, err := process.NewProcess(int32(s.PID)); err == nil {
+		if startTime, err := p.CreateTime(); err == nil {
+			s.StartTime = startTime
+		}
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		s.Hostname = hostname
+	}
+
+	return store.Save(ctx, key, s)
+}
+
+// GetSession returns the session recorded for a development environment of a manifest, or nil if there is none.
+// The process, ports and env file of sessions recorded in other machines are cleared
+func GetSession(ctx context.Context, store SessionStore, manifestPath, devKey string) (*Session, error) {
+	key, err := GetSessionKey(manifestPath, devKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == nil || s.Name == "" || s.Namespace == "" {
+		return nil, nil
+	}
+
+	if !s.isLocal() {
+		s.PID = 0
+		s.Executable = ""
+		s.StartTime = 0
+		s.Ports = nil
+		s.EnvFile = ""
+	}
+
+	return s, nil
+}
+
+// isLocal returns true if the session was recorded in this machine. Sessions without hostname were recorded by older versions in the okteto home
+func (s *Session) isLocal() bool {
+	if s.Hostname == "" {
+		return true
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return false
+	}
+	return s.Hostname == hostname
+}
+
+// SetSessionEnvFile records the dotenv file written by 'okteto up --write-env' in the session of a development environment
+func SetSessionEnvFile(ctx context.Context, store SessionStore, manifestPath, devKey, envFile string) error {
+	key, err := GetSessionKey(manifestPath, devKey)
+	if err != nil {
+		return err
+	}
+
+	s, err := store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if s == nil {
+		return fmt.Errorf("session of '%s' not found", manifestPath)
+	}
+
+	s.EnvFile = envFile
+	return store.Save(ctx, key, s)
+}
+
+// DeleteSession deletes the session recorded for a development environment of a manifest
+func DeleteSession(ctx context.Context, store SessionStore, manifestPath, devKey string) error {
+	key, err := GetSessionKey(manifestPath, devKey)
+	if err != nil {
+		return err
+	}
+
+	return store.Delete(ctx, key)
+}
+
+// fileSessionStore stores each session in a file of the okteto home
+type fileSessionStore struct{}
+
+func (*fileSessionStore) path(key string) string {
+	return filepath.Join(GetOktetoHome(), sessionsFolderName, key)
+}
+
+func (f *fileSessionStore) Get(_ context.Context, key string) (*Session, error) {
+	b, err := ioutil.ReadFile(f.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the session file: %s", err)
+	}
+
+	s := &Session{}
+	if err := yaml.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the session file: %s", err)
+	}
+
+	return s, nil
+}
+
+func (f *fileSessionStore) Save(_ context.Context, key string, s *Session) error {
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the session: %s", err)
+	}
+
+	p := f.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return fmt.Errorf("failed to create the sessions folder: %s", err)
+	}
+
+	if err := ioutil.WriteFile(p, b, 0600); err != nil {
+		return fmt.Errorf("failed to write the session file: %s", err)
+	}
+
+	return nil
+}
+
+func (f *fileSessionStore) Delete(_ context.Context, key string) error {
+	if err := os.Remove(f.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete the session file: %s", err)
+	}
+
+	return nil
+}
+
+func getLocalPorts(dev *model.Dev) []int {
+	ports := []int{}
+	for _, f := range dev.Forward {
+		ports = append(ports, f.Local)
+	}
+	if dev.RemotePort > 0 {
+		ports = append(ports, dev.RemotePort)
+	}
+	return ports
+}
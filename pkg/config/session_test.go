@@ -0,0 +1,214 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+type memorySessionStore struct {
+	sessions map[string]Session
+}
+
+func (m *memorySessionStore) Get(_ context.Context, key string) (*Session, error) {
+	s, ok := m.sessions[key]
+	if !ok {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+func (m *memorySessionStore) Save(_ context.Context, key string, s *Session) error {
+	m.sessions[key] = *s
+	return nil
+}
+
+func (m *memorySessionStore) Delete(_ context.Context, key string) error {
+	delete(m.sessions, key)
+	return nil
+}
+
+func testSessionStore(t *testing.T, store SessionStore) {
+	ctx := context.Background()
+
+	manifest := filepath.Join("project", "okteto.yml")
+	s, err := GetSession(ctx, store, manifest, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil {
+		t.Fatalf("expected no session, got %+v", s)
+	}
+
+	dev := &model.Dev{
+		Name:      "api",
+		Namespace: "cindy",
+		Context:   "minikube",
+		Forward:   []model.Forward{{Local: 8080, Remote: 80}},
+	}
+	if err := SaveSession(ctx, store, manifest, dev); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = GetSession(ctx, store, manifest, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abs, _ := filepath.Abs(manifest)
+	expected := &Session{
+		Manifest:   abs,
+		Name:       "api",
+		Namespace:  "cindy",
+		Context:    "minikube",
+		PID:        os.Getpid(),
+		Executable: s.Executable,
+		Hostname:   s.Hostname,
+		Ports:      []int{8080},
+	}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("wrong session.\nActual:   %+v\nExpected: %+v", s, expected)
+	}
+
+	if err := SetSessionEnvFile(ctx, store, manifest, "", "/project/.env"); err != nil {
+		t.Fatal(err)
+	}
+	s, err = GetSession(ctx, store, manifest, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.EnvFile != "/project/.env" {
+		t.Errorf("expected env file '/project/.env', got '%s'", s.EnvFile)
+	}
+
+	if err := SetSessionEnvFile(ctx, store, filepath.Join("other", "okteto.yml"), "", "/other/.env"); err == nil {
+		t.Error("setting the env file of a missing session didn't fail")
+	}
+
+	other, err := GetSession(ctx, store, filepath.Join("other", "okteto.yml"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other != nil {
+		t.Errorf("expected no session for another manifest, got %+v", other)
+	}
+
+	if err := DeleteSession(ctx, store, manifest, ""); err != nil {
+		t.Fatal(err)
+	}
+	s, err = GetSession(ctx, store, manifest, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil {
+		t.Errorf("the session wasn't deleted: %+v", s)
+	}
+
+	if err := DeleteSession(ctx, store, manifest, ""); err != nil {
+		t.Errorf("deleting a missing session failed: %s", err)
+	}
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	testSessionStore(t, &memorySessionStore{sessions: map[string]Session{}})
+}
+
+func TestFileSessionStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	defer os.Unsetenv("OKTETO_FOLDER")
+
+	testSessionStore(t, &fileSessionStore{})
+}
+
+func TestGetSessionIgnoresIncompleteSessions(t *testing.T) {
+	store := &memorySessionStore{sessions: map[string]Session{}}
+
+	key, err := GetSessionKey("okteto.yml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.sessions[key] = Session{Name: "api"}
+	ctx := context.Background()
+
+	s, err := GetSession(ctx, store, "okteto.yml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil {
+		t.Errorf("expected no session, got %+v", s)
+	}
+}
+
+func TestGetSessionClearsStateOfOtherHosts(t *testing.T) {
+	store := &memorySessionStore{sessions: map[string]Session{}}
+
+	key, err := GetSessionKey("okteto.yml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.sessions[key] = Session{
+		Name:       "api",
+		Namespace:  "cindy",
+		Hostname:   "another-host.invalid",
+		PID:        1,
+		Executable: "/usr/local/bin/okteto",
+		Ports:      []int{8080},
+		EnvFile:    "/project/.env",
+	}
+
+	s, err := GetSession(context.Background(), store, "okteto.yml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == nil {
+		t.Fatal("expected a session")
+	}
+	if s.PID != 0 || s.Executable != "" || len(s.Ports) > 0 || s.EnvFile != "" {
+		t.Errorf("the local state of another host wasn't cleared: %+v", s)
+	}
+	if s.Name != "api" || s.Namespace != "cindy" {
+		t.Errorf("wrong session: %+v", s)
+	}
+}
+
+func TestGetSessionKey(t *testing.T) {
+	manifest, err := GetSessionKey("okteto.yml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	api, err := GetSessionKey("okteto.yml", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	frontend, err := GetSessionKey("okteto.yml", "frontend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if manifest == api || api == frontend {
+		t.Errorf("development environments of the same manifest share their session key: '%s', '%s', '%s'", manifest, api, frontend)
+	}
+}
@@ -41,6 +41,30 @@ func (u CommandError) Error() string {
 	return fmt.Sprintf("%s: %s", u.E.Error(), strings.ToLower(u.Reason.Error()))
 }
 
+// ExitCodeTimeout is the exit code returned when a command exceeds its timeout
+const ExitCodeTimeout = 124
+
+// ExitCodeError is raised when a non-interactive command finishes with a non-zero exit code
+type ExitCodeError struct {
+	Code int
+}
+
+// Error returns the error message
+func (e ExitCodeError) Error() string {
+	return fmt.Sprintf("Command exited with code %d", e.Code)
+}
+
+// GetExitCode returns the exit code of a failed command, either remote (ssh, kubernetes exec) or local
+func GetExitCode(err error) (int, bool) {
+	switch e := err.(type) {
+	case interface{ ExitStatus() int }:
+		return e.ExitStatus(), true
+	case interface{ ExitCode() int }:
+		return e.ExitCode(), true
+	}
+	return 0, false
+}
+
 var (
 	// ErrCommandFailed is raised when the command execution failed
 	ErrCommandFailed = errors.New("Command execution failed")
@@ -78,12 +102,18 @@ var (
 	// ErrLostSyncthing is raised when we lose connectivity with syncthing
 	ErrLostSyncthing = fmt.Errorf("synchronization service is disconnected")
 
+	// ErrLostConnection is raised when we lose connectivity with the development container while running a non-interactive command
+	ErrLostConnection = fmt.Errorf("lost connection to your development container while running the command")
+
 	// ErrNotInDevMode is raised when the deployment is not in dev mode
 	ErrNotInDevMode = fmt.Errorf("Deployment is not in development mode anymore")
 
 	// ErrDevPodDeleted raised if dev pod is deleted in the middle of the "okteto up" sequence
 	ErrDevPodDeleted = fmt.Errorf("development container has been removed")
 
+	// ErrExecTimeout is raised when a command executed in the development container exceeds its timeout
+	ErrExecTimeout = fmt.Errorf("the command didn't finish before the timeout")
+
 	//ErrDivertNotSupported raised if the divert feature is not supported in the current cluster
 	ErrDivertNotSupported = fmt.Errorf("the 'divert' field is only supported in namespaces managed by Okteto")
 )
@@ -93,6 +123,16 @@ func IsNotFound(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "not found")
 }
 
+// IsForbidden returns true if err is of the type forbidden
+func IsForbidden(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "forbidden")
+}
+
+// IsAlreadyExists returns true if err is of the type already exists
+func IsAlreadyExists(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "already exists")
+}
+
 // IsNotExist returns true if err is of the type does not exist
 func IsNotExist(err error) bool {
 	if err == nil {
@@ -15,6 +15,7 @@ package client
 
 import (
 	"log"
+	"net"
 	"net/url"
 	"os"
 	"strings"
@@ -39,7 +40,16 @@ const (
 
 var (
 	sessionContext string
-	localClusters  = []string{"127.", "172.", "192.", "169.", model.Localhost, "::1", "fe80::", "fc00::"}
+	localClusters  = []string{
+		"127.0.0.0/8",    // IPv4 loopback
+		"10.0.0.0/8",     // RFC1918
+		"172.16.0.0/12",  // RFC1918
+		"192.168.0.0/16", // RFC1918
+		"169.254.0.0/16", // IPv4 link-local
+		"::1/128",        // IPv6 loopback
+		"fe80::/10",      // IPv6 link-local
+		"fc00::/7",       // IPv6 unique local addresses
+	}
 )
 
 // GetLocal returns a kubernetes client with the local configuration. It will detect if KUBECONFIG is defined.
@@ -125,18 +135,42 @@ func setAnalytics(clusterContext, clusterHost string) {
 		return
 	}
 
-	u, err := url.Parse(clusterHost)
-	host := ""
-	if err == nil {
-		host = u.Hostname()
-	} else {
-		host = clusterHost
+	analytics.SetClusterType(getClusterType(clusterHost))
+}
+
+func getClusterType(clusterHost string) string {
+	host := getHostname(clusterHost)
+	if host == model.Localhost {
+		return localClusterType
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return remoteClusterType
 	}
-	for _, l := range localClusters {
-		if strings.HasPrefix(host, l) {
-			analytics.SetClusterType(localClusterType)
-			return
+	for _, cidr := range localClusters {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
 		}
+		if ipNet.Contains(ip) {
+			return localClusterType
+		}
+	}
+	return remoteClusterType
+}
+
+// getHostname returns the host of a cluster url without port, IPv6 brackets or zone
+func getHostname(clusterHost string) string {
+	host := clusterHost
+	if u, err := url.Parse(clusterHost); err == nil && u.Host != "" {
+		host = u.Hostname()
+	} else if h, _, err := net.SplitHostPort(clusterHost); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if i := strings.Index(host, "%"); i != -1 {
+		host = host[:i]
 	}
-	analytics.SetClusterType(remoteClusterType)
+	return strings.ToLower(host)
 }
@@ -25,3 +25,36 @@ func TestInCluster(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func Test_getClusterType(t *testing.T) {
+	var tests = []struct {
+		host     string
+		expected string
+	}{
+		{host: "https://localhost:6443", expected: localClusterType},
+		{host: "https://127.0.0.1:6443", expected: localClusterType},
+		{host: "https://10.0.12.4:6443", expected: localClusterType},
+		{host: "https://172.17.0.2:6443", expected: localClusterType},
+		{host: "https://172.32.0.2:6443", expected: remoteClusterType},
+		{host: "https://192.168.64.2:8443", expected: localClusterType},
+		{host: "https://192.0.2.1", expected: remoteClusterType},
+		{host: "https://169.254.10.1", expected: localClusterType},
+		{host: "https://[::1]:6443", expected: localClusterType},
+		{host: "https://[fe80::1%25eth0]:6443", expected: localClusterType},
+		{host: "https://[fd12:3456:789a::1]:6443", expected: localClusterType},
+		{host: "https://[2001:db8::1]:6443", expected: remoteClusterType},
+		{host: "[::1]:6443", expected: localClusterType},
+		{host: "10.1.1.1:6443", expected: localClusterType},
+		{host: "10.1.1.1", expected: localClusterType},
+		{host: "https://35.180.10.1", expected: remoteClusterType},
+		{host: "https://cluster.example.com", expected: remoteClusterType},
+		{host: "https://192.cluster.example.com", expected: remoteClusterType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := getClusterType(tt.host); got != tt.expected {
+				t.Errorf("getClusterType(%s) = %s, expected %s", tt.host, got, tt.expected)
+			}
+		})
+	}
+}
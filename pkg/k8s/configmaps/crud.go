@@ -65,7 +65,7 @@ func Deploy(ctx context.Context, cf *apiv1.ConfigMap, namespace string, c *kuber
 }
 
 // Destroy deletes a configmap in a space
-func Destroy(ctx context.Context, name, namespace string, c *kubernetes.Clientset) error {
+func Destroy(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
 	err := c.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil && !errors.IsNotFound(err) {
 		return err
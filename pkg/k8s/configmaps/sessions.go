@@ -0,0 +1,141 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configmaps
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/errors"
+	"gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const sessionsConfigMapName = "okteto-sessions"
+
+// SessionStore stores the sessions of 'okteto up' in a configmap, so they survive the local okteto home of environments like CI containers.
+// The sessions are indexed by the hostname too, so hosts with the same manifest path don't share them
+type SessionStore struct {
+	namespace string
+	hostname  string
+	c         kubernetes.Interface
+}
+
+// NewSessionStore returns a session store backed by a configmap of namespace
+func NewSessionStore(namespace string, c kubernetes.Interface) *SessionStore {
+	hostname, _ := os.Hostname()
+	return &SessionStore{namespace: namespace, hostname: hostname, c: c}
+}
+
+// dataKey returns the key of the configmap data where the session of key is stored in this host
+func (s *SessionStore) dataKey(key string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(s.hostname+"\x00"+key)))
+}
+
+// Get returns the session stored for key, or nil if there is none
+func (s *SessionStore) Get(ctx context.Context, key string) (*config.Session, error) {
+	cm, err := Get(ctx, sessionsConfigMapName, s.namespace, s.c)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the sessions configmap: %s", err)
+	}
+
+	value, ok := cm.Data[s.dataKey(key)]
+	if !ok {
+		return nil, nil
+	}
+
+	session := &config.Session{}
+	if err := yaml.Unmarshal([]byte(value), session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the session: %s", err)
+	}
+	return session, nil
+}
+
+// Save stores the session of key
+func (s *SessionStore) Save(ctx context.Context, key string, session *config.Session) error {
+	b, err := yaml.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the session: %s", err)
+	}
+
+	err = retryOnConflict(func() error {
+		cm, err := Get(ctx, sessionsConfigMapName, s.namespace, s.c)
+		if err != nil {
+			if !k8sErrors.IsNotFound(err) {
+				return err
+			}
+			cm = &apiv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      sessionsConfigMapName,
+					Namespace: s.namespace,
+				},
+				Data: map[string]string{s.dataKey(key): string(b)},
+			}
+			_, err = s.c.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[s.dataKey(key)] = string(b)
+		_, err = s.c.CoreV1().ConfigMaps(s.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save the session in the sessions configmap: %s", err)
+	}
+	return nil
+}
+
+// Delete deletes the session of key
+func (s *SessionStore) Delete(ctx context.Context, key string) error {
+	err := retryOnConflict(func() error {
+		cm, err := Get(ctx, sessionsConfigMapName, s.namespace, s.c)
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		if _, ok := cm.Data[s.dataKey(key)]; !ok {
+			return nil
+		}
+		delete(cm.Data, s.dataKey(key))
+		_, err = s.c.CoreV1().ConfigMaps(s.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete the session from the sessions configmap: %s", err)
+	}
+	return nil
+}
+
+// retryOnConflict retries fn when the sessions configmap is modified or created concurrently by another okteto process
+func retryOnConflict(fn func() error) error {
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return k8sErrors.IsConflict(err) || k8sErrors.IsAlreadyExists(err)
+	}, fn)
+}
@@ -0,0 +1,150 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configmaps
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestSessionStore(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset()
+	store := NewSessionStore("ci", clientset)
+
+	s, err := store.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil {
+		t.Fatalf("expected no session, got %+v", s)
+	}
+
+	session := &config.Session{Manifest: "/app/okteto.yml", Name: "api", Namespace: "cindy", Ports: []int{8080}}
+	if err := store.Save(ctx, "key", session); err != nil {
+		t.Fatal(err)
+	}
+	other := &config.Session{Manifest: "/web/okteto.yml", Name: "web", Namespace: "cindy"}
+	if err := store.Save(ctx, "other", other); err != nil {
+		t.Fatal(err)
+	}
+
+	cm, err := clientset.CoreV1().ConfigMaps("ci").Get(ctx, sessionsConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cm.Data) != 2 {
+		t.Errorf("expected 2 sessions in the configmap, got %d", len(cm.Data))
+	}
+
+	s, err = store.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, session) {
+		t.Errorf("wrong session.\nActual:   %+v\nExpected: %+v", s, session)
+	}
+
+	if err := store.Delete(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	s, err = store.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil {
+		t.Errorf("the session wasn't deleted: %+v", s)
+	}
+	s, err = store.Get(ctx, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, other) {
+		t.Errorf("wrong session.\nActual:   %+v\nExpected: %+v", s, other)
+	}
+}
+
+func TestSessionStoreRetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: sessionsConfigMapName, Namespace: "ci"},
+		Data:       map[string]string{"other": "name: web"},
+	})
+	conflicts := 0
+	clientset.PrependReactor("update", "configmaps", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if conflicts > 0 {
+			return false, nil, nil
+		}
+		conflicts++
+		return true, nil, k8sErrors.NewConflict(apiv1.Resource("configmaps"), sessionsConfigMapName, fmt.Errorf("the object has been modified"))
+	})
+
+	store := NewSessionStore("ci", clientset)
+	session := &config.Session{Manifest: "/app/okteto.yml", Name: "api", Namespace: "cindy"}
+	if err := store.Save(ctx, "key", session); err != nil {
+		t.Fatal(err)
+	}
+	if conflicts != 1 {
+		t.Errorf("expected 1 conflict, got %d", conflicts)
+	}
+
+	s, err := store.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, session) {
+		t.Errorf("wrong session.\nActual:   %+v\nExpected: %+v", s, session)
+	}
+}
+
+func TestSessionStoreHosts(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset()
+	first := &SessionStore{namespace: "ci", hostname: "runner-1", c: clientset}
+	second := &SessionStore{namespace: "ci", hostname: "runner-2", c: clientset}
+
+	session := &config.Session{Manifest: "/app/okteto.yml", Name: "api", Namespace: "cindy"}
+	if err := first.Save(ctx, "key", session); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := second.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil {
+		t.Errorf("the session of another host was returned: %+v", s)
+	}
+
+	if err := second.Delete(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	s, err = first.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, session) {
+		t.Errorf("wrong session.\nActual:   %+v\nExpected: %+v", s, session)
+	}
+}
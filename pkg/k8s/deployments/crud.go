@@ -154,6 +154,13 @@ func getResourceLimitError(errorMessage string, dev *model.Dev) error {
 	return fmt.Errorf(strings.TrimSpace(errorToReturn))
 }
 
+func getPullSecretName(dev *model.Dev) string {
+	if dev.PullSecret == nil {
+		return ""
+	}
+	return dev.GetPullSecretName()
+}
+
 //GetTranslations fills all the deployments pointed by a development container
 func GetTranslations(ctx context.Context, dev *model.Dev, d *appsv1.Deployment, reset bool, c kubernetes.Interface) (map[string]*model.Translation, error) {
 	result := map[string]*model.Translation{}
@@ -175,15 +182,18 @@ func GetTranslations(ctx context.Context, dev *model.Dev, d *appsv1.Deployment,
 
 		rule := dev.ToTranslationRule(dev, reset)
 		result[d.Name] = &model.Translation{
-			Interactive: true,
-			Name:        dev.Name,
-			Version:     model.TranslationVersion,
-			Deployment:  d,
-			Annotations: dev.Annotations,
-			Tolerations: dev.Tolerations,
-			Replicas:    replicas,
-			Strategy:    strategy,
-			Rules:       []*model.TranslationRule{rule},
+			Interactive:    true,
+			Name:           dev.Name,
+			Version:        model.TranslationVersion,
+			Deployment:     d,
+			Annotations:    dev.Annotations,
+			PodLabels:      dev.PodLabels,
+			PodAnnotations: dev.PodAnnotations,
+			PullSecret:     getPullSecretName(dev),
+			Tolerations:    dev.Tolerations,
+			Replicas:       replicas,
+			Strategy:       strategy,
+			Rules:          []*model.TranslationRule{rule},
 		}
 		if dev.Docker.Enabled {
 			result[d.Name].Annotations[model.OktetoInjectTokenAnnotation] = "true"
@@ -208,17 +218,32 @@ func loadServiceTranslations(ctx context.Context, dev *model.Dev, reset bool, re
 
 		if _, ok := result[d.Name]; ok {
 			result[d.Name].Rules = append(result[d.Name].Rules, rule)
+			if result[d.Name].DevReplicas == nil {
+				result[d.Name].DevReplicas = s.Replicas
+			}
 			continue
 		}
 
+		replicas := *d.Spec.Replicas
+		trRulesJSON := annotations.Get(d.Spec.Template.GetObjectMeta(), model.TranslationAnnotation)
+		if trRulesJSON != "" {
+			trRules := &model.Translation{}
+			if err := json.Unmarshal([]byte(trRulesJSON), trRules); err != nil {
+				return fmt.Errorf("malformed tr rules: %s", err)
+			}
+			replicas = trRules.Replicas
+		}
+
 		result[d.Name] = &model.Translation{
 			Name:        dev.Name,
 			Interactive: false,
 			Version:     model.TranslationVersion,
 			Deployment:  d,
 			Annotations: dev.Annotations,
+			PullSecret:  getPullSecretName(dev),
 			Tolerations: dev.Tolerations,
-			Replicas:    *d.Spec.Replicas,
+			Replicas:    replicas,
+			DevReplicas: s.Replicas,
 			Rules:       []*model.TranslationRule{rule},
 		}
 
@@ -354,20 +379,22 @@ func UpdateDeployments(ctx context.Context, trList map[string]*model.Translation
 	return nil
 }
 
-//TranslateDevModeOff reverses the dev mode translation
+//TranslateDevModeOff reverses the dev mode translation.
+//The original deployment stored when the development container was activated is restored verbatim.
+//The translation rules are only reversed for deployments activated by okteto versions that didn't store it.
 func TranslateDevModeOff(d *appsv1.Deployment) (*appsv1.Deployment, error) {
+	dOrig, err := GetOriginalDeploymentFromAnnotation(d)
+	if err != nil {
+		return nil, err
+	}
+	if dOrig != nil {
+		return dOrig, nil
+	}
+
 	trRulesJSON := annotations.Get(d.Spec.Template.GetObjectMeta(), model.TranslationAnnotation)
 	if trRulesJSON == "" {
-		dManifest := annotations.Get(d.GetObjectMeta(), oktetoDeploymentAnnotation)
-		if dManifest == "" {
-			log.Infof("%s/%s is not a development container", d.Namespace, d.Name)
-			return d, nil
-		}
-		dOrig := &appsv1.Deployment{}
-		if err := json.Unmarshal([]byte(dManifest), dOrig); err != nil {
-			return nil, fmt.Errorf("malformed manifest: %s", err)
-		}
-		return dOrig, nil
+		log.Infof("%s/%s is not a development container", d.Namespace, d.Name)
+		return d, nil
 	}
 	trRules := &model.Translation{}
 	if err := json.Unmarshal([]byte(trRulesJSON), trRules); err != nil {
@@ -377,11 +404,21 @@ func TranslateDevModeOff(d *appsv1.Deployment) (*appsv1.Deployment, error) {
 	d.Spec.Strategy = trRules.Strategy
 	annotations := d.GetObjectMeta().GetAnnotations()
 	delete(annotations, oktetoVersionAnnotation)
+	delete(annotations, model.OktetoDevHashAnnotation)
 	deleteUserAnnotations(annotations, trRules)
 	d.GetObjectMeta().SetAnnotations(annotations)
 	annotations = d.Spec.Template.GetObjectMeta().GetAnnotations()
 	delete(annotations, model.TranslationAnnotation)
 	delete(annotations, model.OktetoRestartAnnotation)
+	for key := range trRules.PodAnnotations {
+		delete(annotations, key)
+	}
+	for key, value := range trRules.PrevPodAnnotations {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[key] = value
+	}
 	d.Spec.Template.GetObjectMeta().SetAnnotations(annotations)
 	labels := d.GetObjectMeta().GetLabels()
 	delete(labels, model.DevLabel)
@@ -391,10 +428,36 @@ func TranslateDevModeOff(d *appsv1.Deployment) (*appsv1.Deployment, error) {
 	labels = d.Spec.Template.GetObjectMeta().GetLabels()
 	delete(labels, model.InteractiveDevLabel)
 	delete(labels, model.DetachedDevLabel)
+	for key := range trRules.PodLabels {
+		delete(labels, key)
+	}
+	for key, value := range trRules.PrevPodLabels {
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[key] = value
+	}
 	d.Spec.Template.GetObjectMeta().SetLabels(labels)
+	removePullSecret(&d.Spec.Template.Spec, trRules.PullSecret)
 	return d, nil
 }
 
+func removePullSecret(spec *apiv1.PodSpec, name string) {
+	if name == "" {
+		return
+	}
+	pullSecrets := []apiv1.LocalObjectReference{}
+	for _, s := range spec.ImagePullSecrets {
+		if s.Name != name {
+			pullSecrets = append(pullSecrets, s)
+		}
+	}
+	if len(pullSecrets) == 0 {
+		pullSecrets = nil
+	}
+	spec.ImagePullSecrets = pullSecrets
+}
+
 func deleteUserAnnotations(annotations map[string]string, tr *model.Translation) {
 	for key := range tr.Annotations {
 		delete(annotations, key)
@@ -16,6 +16,7 @@ package deployments
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/errors"
@@ -190,3 +191,193 @@ func TestCheckConditionErrors(t *testing.T) {
 	}
 
 }
+
+func TestServiceReplicasDevModeOnOff(t *testing.T) {
+	var originalReplicas int32 = 3
+	var customReplicas int32 = 2
+
+	tests := []struct {
+		name             string
+		replicas         *int32
+		expectedReplicas int32
+	}{
+		{
+			name:             "default-single-replica",
+			replicas:         nil,
+			expectedReplicas: 1,
+		},
+		{
+			name:             "custom-replicas",
+			replicas:         &customReplicas,
+			expectedReplicas: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			d := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "worker",
+					Namespace:   "test",
+					Labels:      map[string]string{},
+					Annotations: map[string]string{},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &originalReplicas,
+					Template: apiv1.PodTemplateSpec{
+						Spec: apiv1.PodSpec{
+							Containers: []apiv1.Container{
+								{
+									Name:  "worker",
+									Image: "worker:1.0",
+								},
+							},
+						},
+					},
+				},
+			}
+			clientset := fake.NewSimpleClientset(d)
+
+			dev := &model.Dev{
+				Name:        "api",
+				Namespace:   "test",
+				Annotations: model.Annotations{},
+				Services: []*model.Dev{
+					{
+						Name:     "worker",
+						Replicas: tt.replicas,
+					},
+				},
+			}
+
+			trList, err := GetTranslations(ctx, dev, nil, false, clientset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := TranslateDevMode(trList, nil, false); err != nil {
+				t.Fatal(err)
+			}
+			if err := UpdateDeployments(ctx, trList, clientset); err != nil {
+				t.Fatal(err)
+			}
+
+			devD, err := clientset.AppsV1().Deployments("test").Get(ctx, "worker", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *devD.Spec.Replicas != tt.expectedReplicas {
+				t.Fatalf("wrong dev replicas. Got %d, expected %d", *devD.Spec.Replicas, tt.expectedReplicas)
+			}
+
+			restored, err := TranslateDevModeOff(devD)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *restored.Spec.Replicas != originalReplicas {
+				t.Fatalf("wrong restored replicas. Got %d, expected %d", *restored.Spec.Replicas, originalReplicas)
+			}
+		})
+	}
+}
+
+func TestDevModeOffRestoresOriginalDeployment(t *testing.T) {
+	ctx := context.Background()
+	var replicas int32 = 3
+	var gracePeriod int64 = 45
+	original := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "test",
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{"team": "backend"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "web"},
+					Annotations: map[string]string{"prometheus.io/scrape": "true"},
+				},
+				Spec: apiv1.PodSpec{
+					TerminationGracePeriodSeconds: &gracePeriod,
+					ImagePullSecrets:              []apiv1.LocalObjectReference{{Name: "registry"}},
+					Containers: []apiv1.Container{
+						{
+							Name:    "web",
+							Image:   "web:1.0",
+							Command: []string{"./run_web.sh"},
+							Env:     []apiv1.EnvVar{{Name: "ENV", Value: "production"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	clientset := fake.NewSimpleClientset(original.DeepCopy())
+
+	manifest := []byte(`name: web
+namespace: test
+image: web:dev
+command: ["bash"]
+annotations:
+  okteto: "true"
+environment:
+  - ENV=development`)
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := Get(ctx, dev, dev.Namespace, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trList, err := GetTranslations(ctx, dev, d, false, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := TranslateDevMode(trList, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateDeployments(ctx, trList, clientset); err != nil {
+		t.Fatal(err)
+	}
+
+	devD, err := clientset.AppsV1().Deployments("test").Get(ctx, "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsDevModeOn(devD) {
+		t.Fatal("deployment not in dev mode")
+	}
+
+	// translation rules written by a different okteto version must not be used to revert the deployment
+	var driftedReplicas int32 = 7
+	if err := setTranslationAsAnnotation(devD.Spec.Template.GetObjectMeta(), &model.Translation{Replicas: driftedReplicas}); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := TranslateDevModeOff(devD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Update(ctx, restored, clientset); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := clientset.AppsV1().Deployments("test").Get(ctx, "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Labels, original.Labels) {
+		t.Errorf("wrong labels. Got %+v, expected %+v", result.Labels, original.Labels)
+	}
+	if !reflect.DeepEqual(result.Annotations, original.Annotations) {
+		t.Errorf("wrong annotations. Got %+v, expected %+v", result.Annotations, original.Annotations)
+	}
+	if !reflect.DeepEqual(result.Spec, original.Spec) {
+		t.Errorf("wrong spec. Got %+v, expected %+v", result.Spec, original.Spec)
+	}
+}
@@ -14,16 +14,17 @@
 package deployments
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/okteto/okteto/pkg/k8s/annotations"
 	"github.com/okteto/okteto/pkg/k8s/labels"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/shell"
 
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
@@ -41,11 +42,18 @@ const (
 	OktetoBinName = "okteto-bin"
 	//OktetoInitVolumeContainerName name of the okteto init container that initializes the persistent colume from image content
 	OktetoInitVolumeContainerName = "okteto-init-volume"
+	//OktetoMountFromImageContainerName name of the okteto init container that initializes a volume from the content of another image
+	OktetoMountFromImageContainerName = "okteto-mount-from-image"
 
 	//syncthing
 	oktetoSyncSecretVolume = "okteto-sync-secret" // skipcq GSC-G101  not a secret
 	oktetoDevSecretVolume  = "okteto-dev-secret"  // skipcq GSC-G101  not a secret
 	oktetoSecretTemplate   = "okteto-%s"
+
+	oktetoServiceAccountTokenVolumeTemplate = "okteto-sa-token-%d"
+
+	// initCopyScript creates the folders and copies the files passed as arguments into the destination folder $0
+	initCopyScript = `for f; do if [ -d "$f" ] && [ ! -L "$f" ]; then mkdir -p "$0/$f"; else cp -Pp "$f" "$0/$f" && echo "$f"; fi; done`
 )
 
 var (
@@ -63,18 +71,21 @@ func translate(t *model.Translation, c *kubernetes.Clientset, isOktetoNamespace
 		rule.Container = devContainer.Name
 	}
 
-	manifest := annotations.Get(t.Deployment.GetObjectMeta(), oktetoDeploymentAnnotation)
-	if manifest != "" {
-		dOrig := &appsv1.Deployment{}
-		if err := json.Unmarshal([]byte(manifest), dOrig); err != nil {
-			return err
-		}
+	dOrig, err := GetOriginalDeploymentFromAnnotation(t.Deployment)
+	if err != nil {
+		return err
+	}
+	if dOrig != nil {
 		t.Deployment = dOrig
 	}
 	dAnnotations := t.Deployment.GetObjectMeta().GetAnnotations()
 	delete(dAnnotations, revisionAnnotation)
 	t.Deployment.GetObjectMeta().SetAnnotations(dAnnotations)
 
+	if err := setOriginalDeploymentAsAnnotation(t.Deployment); err != nil {
+		return err
+	}
+
 	if c != nil && isOktetoNamespace {
 		c := os.Getenv("OKTETO_CLIENTSIDE_TRANSLATION")
 		if c == "" {
@@ -85,19 +96,11 @@ func translate(t *model.Translation, c *kubernetes.Clientset, isOktetoNamespace
 		log.Infof("using clientside translation")
 	}
 
-	t.Deployment.Status = appsv1.DeploymentStatus{}
-	delete(t.Deployment.Annotations, oktetoDeploymentAnnotation)
-	manifestBytes, err := json.Marshal(t.Deployment)
-	if err != nil {
-		return err
-	}
-	annotations.Set(t.Deployment.GetObjectMeta(), oktetoDeploymentAnnotation, string(manifestBytes))
-
 	commonTranslation(t)
 	labels.Set(t.Deployment.Spec.Template.GetObjectMeta(), model.DevLabel, "true")
 	TranslateDevAnnotations(t.Deployment.Spec.Template.GetObjectMeta(), t.Annotations)
 	TranslateDevTolerations(&t.Deployment.Spec.Template.Spec, t.Tolerations)
-	t.Deployment.Spec.Template.Spec.TerminationGracePeriodSeconds = &devTerminationGracePeriodSeconds
+	terminationGracePeriod := t.Deployment.Spec.Template.Spec.TerminationGracePeriodSeconds
 
 	if t.Interactive {
 		TranslateOktetoSyncSecret(&t.Deployment.Spec.Template.Spec, t.Name)
@@ -122,11 +125,17 @@ func translate(t *model.Translation, c *kubernetes.Clientset, isOktetoNamespace
 		if rule.IsMainDevContainer() {
 			TranslateOktetoBinVolumeMounts(devContainer)
 			TranslateOktetoInitBinContainer(rule.InitContainer, &t.Deployment.Spec.Template.Spec)
+			TranslateOktetoMountFromImageContainer(&t.Deployment.Spec.Template.Spec, rule)
 			TranslateOktetoInitFromImageContainer(&t.Deployment.Spec.Template.Spec, rule)
 			TranslateDinDContainer(&t.Deployment.Spec.Template.Spec, rule)
+			if err := TranslateSidecarContainers(&t.Deployment.Spec.Template.Spec, rule); err != nil {
+				return err
+			}
+			TranslateServiceAccountTokens(&t.Deployment.Spec.Template.Spec, devContainer, rule.ServiceAccountTokens)
 			TranslateOktetoBinVolume(&t.Deployment.Spec.Template.Spec)
 		}
 	}
+	TranslateTerminationGracePeriod(&t.Deployment.Spec.Template.Spec, terminationGracePeriod)
 	return nil
 }
 
@@ -135,6 +144,11 @@ func commonTranslation(t *model.Translation) {
 	annotations.Set(t.Deployment.GetObjectMeta(), oktetoVersionAnnotation, model.Version)
 	labels.Set(t.Deployment.GetObjectMeta(), model.DevLabel, "true")
 
+	t.PrevPodLabels = getPreviousValues(t.Deployment.Spec.Template.GetLabels(), t.PodLabels)
+	TranslateDevPodLabels(t.Deployment, t.PodLabels)
+	t.PrevPodAnnotations = getPreviousValues(t.Deployment.Spec.Template.GetAnnotations(), t.PodAnnotations)
+	TranslateDevAnnotations(t.Deployment.Spec.Template.GetObjectMeta(), t.PodAnnotations)
+	TranslatePullSecret(&t.Deployment.Spec.Template.Spec, t.PullSecret)
 	if t.Interactive {
 		labels.Set(t.Deployment.Spec.Template.GetObjectMeta(), model.InteractiveDevLabel, t.Name)
 	} else {
@@ -142,6 +156,9 @@ func commonTranslation(t *model.Translation) {
 	}
 
 	t.Deployment.Spec.Replicas = &devReplicas
+	if t.DevReplicas != nil {
+		t.Deployment.Spec.Replicas = t.DevReplicas
+	}
 	t.Deployment.Spec.Strategy = appsv1.DeploymentStrategy{
 		Type: appsv1.RecreateDeploymentStrategyType,
 	}
@@ -169,6 +186,48 @@ func TranslateDevAnnotations(o metav1.Object, annotationsToAdd map[string]string
 	}
 }
 
+// getPreviousValues returns the values of the keys overridden by the dev mode translation, so they can be restored
+func getPreviousValues(values, overrides map[string]string) map[string]string {
+	var previous map[string]string
+	for key := range overrides {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		if previous == nil {
+			previous = map[string]string{}
+		}
+		previous[key] = value
+	}
+	return previous
+}
+
+//TranslateDevPodLabels sets the user provided labels in the pod template, except the labels of the deployment selector
+func TranslateDevPodLabels(d *appsv1.Deployment, labelsToAdd map[string]string) {
+	for key, value := range labelsToAdd {
+		if d.Spec.Selector != nil {
+			if _, ok := d.Spec.Selector.MatchLabels[key]; ok {
+				log.Infof("ignoring pod label '%s': it's part of the selector of deployment '%s'", key, d.Name)
+				continue
+			}
+		}
+		labels.Set(d.Spec.Template.GetObjectMeta(), key, value)
+	}
+}
+
+//TranslatePullSecret attaches the pull secret of the okteto manifest to the pod spec
+func TranslatePullSecret(spec *apiv1.PodSpec, name string) {
+	if name == "" {
+		return
+	}
+	for _, s := range spec.ImagePullSecrets {
+		if s.Name == name {
+			return
+		}
+	}
+	spec.ImagePullSecrets = append(spec.ImagePullSecrets, apiv1.LocalObjectReference{Name: name})
+}
+
 //TranslateDevTolerations sets the user provided toleretions
 func TranslateDevTolerations(spec *apiv1.PodSpec, tolerations []apiv1.Toleration) {
 	spec.Tolerations = append(spec.Tolerations, tolerations...)
@@ -227,7 +286,7 @@ func TranslateDinDContainer(spec *apiv1.PodSpec, rule *model.TranslationRule) {
 		return
 	}
 	c := apiv1.Container{
-		Name:  "dind",
+		Name:  model.DinDContainerName,
 		Image: rule.Docker.Image,
 		Env: []apiv1.EnvVar{
 			{
@@ -259,6 +318,38 @@ func TranslateDinDContainer(spec *apiv1.PodSpec, rule *model.TranslationRule) {
 	spec.Containers = append(spec.Containers, c)
 }
 
+//TranslateSidecarContainers translates the sidecar containers
+func TranslateSidecarContainers(spec *apiv1.PodSpec, rule *model.TranslationRule) error {
+	names := map[string]string{}
+	ports := map[int]string{}
+	for _, c := range spec.Containers {
+		owner := fmt.Sprintf("the container '%s'", c.Name)
+		names[c.Name] = owner
+		for _, p := range c.Ports {
+			ports[int(p.ContainerPort)] = owner
+		}
+	}
+	if err := model.ValidateSidecars(rule.Sidecars, names, ports); err != nil {
+		return err
+	}
+
+	for _, s := range rule.Sidecars {
+		c := apiv1.Container{
+			Name:    s.Name,
+			Image:   s.Image,
+			Command: s.Command.Values,
+		}
+		for _, e := range s.Environment {
+			c.Env = append(c.Env, apiv1.EnvVar{Name: e.Name, Value: e.Value})
+		}
+		for _, p := range s.Ports {
+			c.Ports = append(c.Ports, apiv1.ContainerPort{ContainerPort: int32(p)})
+		}
+		spec.Containers = append(spec.Containers, c)
+	}
+	return nil
+}
+
 func isDockerVolumeMount(subPath string) bool {
 	if strings.HasPrefix(subPath, model.SourceCodeSubPath) {
 		return true
@@ -287,20 +378,37 @@ func TranslateProbes(c *apiv1.Container, p *model.Probes) {
 	}
 }
 
-//TranslateLifecycle translates the lifecycle events attached to a container
+//TranslateLifecycle translates the lifecycle events attached to a container.
+//PreStop hooks of the container are kept so the application can clean up when the development container is stopped
 func TranslateLifecycle(c *apiv1.Container, l *model.Lifecycle) {
 	if l == nil {
 		return
 	}
+	if len(l.PreStop.Values) > 0 {
+		if c.Lifecycle == nil {
+			c.Lifecycle = &apiv1.Lifecycle{}
+		}
+		c.Lifecycle.PreStop = &apiv1.Handler{
+			Exec: &apiv1.ExecAction{Command: l.PreStop.Values},
+		}
+	}
 	if c.Lifecycle == nil {
 		return
 	}
 	if !l.PostStart {
 		c.Lifecycle.PostStart = nil
 	}
-	if !l.PostStart {
-		c.Lifecycle.PostStart = nil
+}
+
+//TranslateTerminationGracePeriod keeps the termination grace period of the pod when a container has a preStop hook, so the hook has time to run
+func TranslateTerminationGracePeriod(spec *apiv1.PodSpec, original *int64) {
+	for _, c := range spec.Containers {
+		if c.Lifecycle != nil && c.Lifecycle.PreStop != nil {
+			spec.TerminationGracePeriodSeconds = original
+			return
+		}
 	}
+	spec.TerminationGracePeriodSeconds = &devTerminationGracePeriodSeconds
 }
 
 //TranslateResources translates the resources attached to a container
@@ -381,6 +489,7 @@ func TranslateVolumeMounts(c *apiv1.Container, rule *model.TranslationRule) {
 				Name:      v.Name,
 				MountPath: v.MountPath,
 				SubPath:   v.SubPath,
+				ReadOnly:  v.ReadOnly,
 			},
 		)
 	}
@@ -533,8 +642,28 @@ func TranslateContainerSecurityContext(c *apiv1.Container, s *model.SecurityCont
 	}
 
 	c.SecurityContext.ReadOnlyRootFilesystem = nil
-	c.SecurityContext.Capabilities.Add = append(c.SecurityContext.Capabilities.Add, s.Capabilities.Add...)
-	c.SecurityContext.Capabilities.Drop = append(c.SecurityContext.Capabilities.Drop, s.Capabilities.Drop...)
+	c.SecurityContext.Capabilities.Add = mergeCapabilities(c.SecurityContext.Capabilities.Add, s.Capabilities.Add)
+	c.SecurityContext.Capabilities.Drop = mergeCapabilities(c.SecurityContext.Capabilities.Drop, s.Capabilities.Drop)
+}
+
+//mergeCapabilities returns the sorted union of two lists of capabilities without duplicates
+func mergeCapabilities(current, added []apiv1.Capability) []apiv1.Capability {
+	if len(current) == 0 && len(added) == 0 {
+		return nil
+	}
+	seen := map[apiv1.Capability]bool{}
+	result := []apiv1.Capability{}
+	for _, c := range append(append([]apiv1.Capability{}, current...), added...) {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
 }
 
 func translateInitResources(c *apiv1.Container, resources model.ResourceRequirements) {
@@ -575,9 +704,50 @@ func TranslateOktetoInitBinContainer(initContainer model.InitContainer, spec *ap
 	spec.InitContainers = append(spec.InitContainers, c)
 }
 
+//TranslateOktetoMountFromImageContainer translates the init container that seeds a volume from the content of another image.
+//It runs before the init volume container so the volume isn't initialized with the content of the dev image
+func TranslateOktetoMountFromImageContainer(spec *apiv1.PodSpec, rule *model.TranslationRule) {
+	if rule.MountFromImage == nil || !rule.PersistentVolume {
+		return
+	}
+
+	mountPath := path.Clean(rule.MountFromImage.Path)
+	var volume *model.VolumeMount
+	for i := range rule.Volumes {
+		if path.Clean(rule.Volumes[i].MountPath) == mountPath && strings.HasPrefix(rule.Volumes[i].SubPath, model.DataSubPath) {
+			volume = &rule.Volumes[i]
+			break
+		}
+	}
+	if volume == nil {
+		return
+	}
+
+	if spec.InitContainers == nil {
+		spec.InitContainers = []apiv1.Container{}
+	}
+
+	c := &apiv1.Container{
+		Name:            OktetoMountFromImageContainerName,
+		Image:           rule.MountFromImage.Image,
+		ImagePullPolicy: apiv1.PullIfNotPresent,
+		Command:         []string{"sh", "-c", fmt.Sprintf("[ \"$(ls -A /init-volume)\" ] || %s", getInitCopyCommand(mountPath, "/init-volume", rule.InitContainer.Exclude))},
+		VolumeMounts: []apiv1.VolumeMount{
+			{
+				Name:      volume.Name,
+				MountPath: "/init-volume",
+				SubPath:   volume.SubPath,
+			},
+		},
+	}
+	translateInitResources(c, rule.InitContainer.Resources)
+	TranslateContainerSecurityContext(c, rule.SecurityContext)
+	spec.InitContainers = append(spec.InitContainers, *c)
+}
+
 //TranslateOktetoInitFromImageContainer translates the init from image container of a pod
 func TranslateOktetoInitFromImageContainer(spec *apiv1.PodSpec, rule *model.TranslationRule) {
-	if !rule.PersistentVolume {
+	if !rule.PersistentVolume || !rule.InitContainer.IsEnabled() {
 		return
 	}
 
@@ -594,7 +764,7 @@ func TranslateOktetoInitFromImageContainer(spec *apiv1.PodSpec, rule *model.Tran
 	command := "echo initializing"
 	iVolume := 1
 	for _, v := range rule.Volumes {
-		if !strings.HasPrefix(v.SubPath, model.SourceCodeSubPath) && !strings.HasPrefix(v.SubPath, model.DataSubPath) {
+		if !isInitVolume(v) {
 			continue
 		}
 		c.VolumeMounts = append(
@@ -606,7 +776,16 @@ func TranslateOktetoInitFromImageContainer(spec *apiv1.PodSpec, rule *model.Tran
 			},
 		)
 		mounPath := path.Join(v.MountPath, ".")
-		command = fmt.Sprintf("%s && ( [ \"$(ls -A /init-volume/%d)\" ] || cp -Rv %s/. /init-volume/%d || true)", command, iVolume, mounPath, iVolume)
+		copyCommand := getInitCopyCommand(mounPath, fmt.Sprintf("/init-volume/%d", iVolume), rule.InitContainer.Exclude)
+		command = fmt.Sprintf("%s && ( [ \"$(ls -A /init-volume/%d)\" ] || %s || true)", command, iVolume, copyCommand)
+		for _, r := range rule.InitContainer.Refresh {
+			r = path.Clean(r)
+			if getInitVolumeMountPath(r, rule.Volumes) != mounPath {
+				continue
+			}
+			dst := path.Join(fmt.Sprintf("/init-volume/%d", iVolume), strings.TrimPrefix(r, mounPath))
+			command = fmt.Sprintf("%s && ( mkdir -p %s && cp -Rv %s/. %s || true)", command, dst, r, dst)
+		}
 		iVolume++
 	}
 
@@ -616,6 +795,45 @@ func TranslateOktetoInitFromImageContainer(spec *apiv1.PodSpec, rule *model.Tran
 	spec.InitContainers = append(spec.InitContainers, *c)
 }
 
+func isInitVolume(v model.VolumeMount) bool {
+	return strings.HasPrefix(v.SubPath, model.SourceCodeSubPath) || strings.HasPrefix(v.SubPath, model.DataSubPath)
+}
+
+// getInitVolumeMountPath returns the mount path of the deepest initialized volume that contains p, so nested volumes get their own content
+func getInitVolumeMountPath(p string, volumes []model.VolumeMount) string {
+	result := ""
+	for _, v := range volumes {
+		if !isInitVolume(v) {
+			continue
+		}
+		mountPath := path.Join(v.MountPath, ".")
+		if p != mountPath && !strings.HasPrefix(p, strings.TrimSuffix(mountPath, "/")+"/") {
+			continue
+		}
+		if len(mountPath) > len(result) {
+			result = mountPath
+		}
+	}
+	return result
+}
+
+// getInitCopyCommand returns the command that copies src into dst, skipping the paths matching the exclude patterns
+func getInitCopyCommand(src, dst string, exclude []string) string {
+	if len(exclude) == 0 {
+		return fmt.Sprintf("cp -Rv %s %s", shell.Quote(src+"/."), shell.Quote(dst))
+	}
+
+	prunes := make([]string, 0, len(exclude))
+	for _, e := range exclude {
+		prunes = append(prunes, fmt.Sprintf("-path %s", shell.Quote("./"+path.Clean(e))))
+	}
+	// the paths are passed in batches to a single shell, which gets the destination as $0. find lists each folder before its content
+	return fmt.Sprintf(
+		"(cd %s && find . \\( %s \\) -prune -o -exec sh -c %s %s {} +)",
+		shell.Quote(src), strings.Join(prunes, " -o "), shell.Quote(initCopyScript), shell.Quote(dst),
+	)
+}
+
 //TranslateOktetoSyncSecret translates the syncthing secret container of a pod
 func TranslateOktetoSyncSecret(spec *apiv1.PodSpec, name string) {
 	if spec.Volumes == nil {
@@ -656,6 +874,46 @@ func TranslateOktetoSyncSecret(spec *apiv1.PodSpec, name string) {
 	spec.Volumes = append(spec.Volumes, v)
 }
 
+//TranslateServiceAccountTokens adds a projected volume for each service account token and mounts it in the folder of the token path
+func TranslateServiceAccountTokens(spec *apiv1.PodSpec, c *apiv1.Container, tokens []model.ServiceAccountToken) {
+	for i, t := range tokens {
+		name := fmt.Sprintf(oktetoServiceAccountTokenVolumeTemplate, i)
+		tokenPath := path.Clean(t.Path)
+		source := &apiv1.ServiceAccountTokenProjection{
+			Audience: t.Audience,
+			Path:     path.Base(tokenPath),
+		}
+		if t.ExpirationSeconds > 0 {
+			expiration := t.ExpirationSeconds
+			source.ExpirationSeconds = &expiration
+		}
+
+		exists := false
+		for j := range spec.Volumes {
+			if spec.Volumes[j].Name == name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			spec.Volumes = append(spec.Volumes, apiv1.Volume{
+				Name: name,
+				VolumeSource: apiv1.VolumeSource{
+					Projected: &apiv1.ProjectedVolumeSource{
+						Sources: []apiv1.VolumeProjection{{ServiceAccountToken: source}},
+					},
+				},
+			})
+		}
+
+		c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+			Name:      name,
+			MountPath: path.Dir(tokenPath),
+			ReadOnly:  true,
+		})
+	}
+}
+
 //TranslateOktetoDevSecret translates the devs secret of a pod
 func TranslateOktetoDevSecret(spec *apiv1.PodSpec, secret string, secrets []model.Secret) {
 	if len(secrets) == 0 {
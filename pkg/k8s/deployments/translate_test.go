@@ -202,7 +202,7 @@ services:
 							Name:            OktetoInitVolumeContainerName,
 							Image:           "web:latest",
 							ImagePullPolicy: apiv1.PullIfNotPresent,
-							Command:         []string{"sh", "-c", "echo initializing && ( [ \"$(ls -A /init-volume/1)\" ] || cp -Rv /go/pkg/. /init-volume/1 || true) && ( [ \"$(ls -A /init-volume/2)\" ] || cp -Rv /root/.cache/go-build/. /init-volume/2 || true) && ( [ \"$(ls -A /init-volume/3)\" ] || cp -Rv /app/. /init-volume/3 || true) && ( [ \"$(ls -A /init-volume/4)\" ] || cp -Rv /path/. /init-volume/4 || true)"},
+							Command:         []string{"sh", "-c", "echo initializing && ( [ \"$(ls -A /init-volume/1)\" ] || cp -Rv '/go/pkg/.' '/init-volume/1' || true) && ( [ \"$(ls -A /init-volume/2)\" ] || cp -Rv '/root/.cache/go-build/.' '/init-volume/2' || true) && ( [ \"$(ls -A /init-volume/3)\" ] || cp -Rv '/app/.' '/init-volume/3' || true) && ( [ \"$(ls -A /init-volume/4)\" ] || cp -Rv '/path/.' '/init-volume/4' || true)"},
 							SecurityContext: &apiv1.SecurityContext{
 								RunAsUser:  &runAsUser,
 								RunAsGroup: &runAsGroup,
@@ -480,6 +480,771 @@ services:
 	}
 }
 
+func Test_translateReadOnlyExternalVolumes(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest
+sync:
+  - .:/app
+externalVolumes:
+  - name: shared
+    subpath: reference
+    mountpath: /data
+    readOnly: true
+  - cache:/cache`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := dev.GevSandbox()
+	rule := dev.ToTranslationRule(dev, false)
+	tr := &model.Translation{
+		Interactive: true,
+		Name:        dev.Name,
+		Version:     model.TranslationVersion,
+		Deployment:  d,
+		Rules:       []*model.TranslationRule{rule},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	mounts := map[string]apiv1.VolumeMount{}
+	for _, vm := range d.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounts[vm.MountPath] = vm
+	}
+
+	if vm, ok := mounts["/data"]; !ok || !vm.ReadOnly {
+		t.Errorf("expected a read-only mount at '/data', got %+v", vm)
+	}
+	if vm, ok := mounts["/cache"]; !ok || vm.ReadOnly {
+		t.Errorf("expected a read-write mount at '/cache', got %+v", vm)
+	}
+	if vm, ok := mounts["/app"]; !ok || vm.ReadOnly {
+		t.Errorf("expected a read-write sync mount at '/app', got %+v", vm)
+	}
+}
+
+func Test_translateMountFromImage(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest
+sync:
+  - .:/app
+volumes:
+  - /app/vendor
+mountFromImage:
+  image: okteto/vendor:1.0
+  path: /app/vendor`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := dev.GevSandbox()
+	rule := dev.ToTranslationRule(dev, false)
+	tr := &model.Translation{
+		Interactive: true,
+		Name:        dev.Name,
+		Version:     model.TranslationVersion,
+		Deployment:  d,
+		Rules:       []*model.TranslationRule{rule},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	initContainers := d.Spec.Template.Spec.InitContainers
+	names := []string{}
+	for _, c := range initContainers {
+		names = append(names, c.Name)
+	}
+	expectedNames := []string{OktetoBinName, OktetoMountFromImageContainerName, OktetoInitVolumeContainerName}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("expected init containers %v, got %v", expectedNames, names)
+	}
+
+	c := initContainers[1]
+	if c.Image != "okteto/vendor:1.0" {
+		t.Errorf("expected image 'okteto/vendor:1.0', got '%s'", c.Image)
+	}
+	expectedCommand := []string{"sh", "-c", `[ "$(ls -A /init-volume)" ] || cp -Rv '/app/vendor/.' '/init-volume'`}
+	if !reflect.DeepEqual(c.Command, expectedCommand) {
+		t.Errorf("expected command %v, got %v", expectedCommand, c.Command)
+	}
+	expectedMounts := []apiv1.VolumeMount{
+		{
+			Name:      dev.GetVolumeName(),
+			MountPath: "/init-volume",
+			SubPath:   path.Join(model.DataSubPath, "app/vendor"),
+		},
+	}
+	if !reflect.DeepEqual(c.VolumeMounts, expectedMounts) {
+		t.Errorf("expected volume mounts %v, got %v", expectedMounts, c.VolumeMounts)
+	}
+}
+
+func Test_translateServiceAccountTokens(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest
+serviceAccountTokens:
+  - audience: vault
+    expirationSeconds: 7200
+    path: /var/run/secrets/vault/token
+  - audience: sts.amazonaws.com
+    path: /var/run/secrets/aws/token`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := dev.GevSandbox()
+	rule := dev.ToTranslationRule(dev, false)
+	tr := &model.Translation{
+		Interactive: true,
+		Name:        dev.Name,
+		Version:     model.TranslationVersion,
+		Deployment:  d,
+		Rules:       []*model.TranslationRule{rule},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	expiration := int64(7200)
+	expectedVolumes := []apiv1.Volume{
+		{
+			Name: "okteto-sa-token-0",
+			VolumeSource: apiv1.VolumeSource{
+				Projected: &apiv1.ProjectedVolumeSource{
+					Sources: []apiv1.VolumeProjection{
+						{
+							ServiceAccountToken: &apiv1.ServiceAccountTokenProjection{
+								Audience:          "vault",
+								ExpirationSeconds: &expiration,
+								Path:              "token",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "okteto-sa-token-1",
+			VolumeSource: apiv1.VolumeSource{
+				Projected: &apiv1.ProjectedVolumeSource{
+					Sources: []apiv1.VolumeProjection{
+						{
+							ServiceAccountToken: &apiv1.ServiceAccountTokenProjection{
+								Audience: "sts.amazonaws.com",
+								Path:     "token",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, expected := range expectedVolumes {
+		found := false
+		for _, v := range d.Spec.Template.Spec.Volumes {
+			if v.Name != expected.Name {
+				continue
+			}
+			found = true
+			if !reflect.DeepEqual(v, expected) {
+				t.Errorf("expected volume %+v, got %+v", expected, v)
+			}
+		}
+		if !found {
+			t.Errorf("volume '%s' not found", expected.Name)
+		}
+	}
+
+	expectedMounts := map[string]string{
+		"okteto-sa-token-0": "/var/run/secrets/vault",
+		"okteto-sa-token-1": "/var/run/secrets/aws",
+	}
+	for _, m := range d.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mountPath, ok := expectedMounts[m.Name]
+		if !ok {
+			continue
+		}
+		if m.MountPath != mountPath || !m.ReadOnly {
+			t.Errorf("expected read-only mount of '%s' in '%s', got %+v", m.Name, mountPath, m)
+		}
+		delete(expectedMounts, m.Name)
+	}
+	if len(expectedMounts) > 0 {
+		t.Errorf("volume mounts not found: %v", expectedMounts)
+	}
+}
+
+func Test_translatePodLabels(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest
+podLabels:
+  role: frontend
+  app: other`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := dev.GevSandbox()
+	rule := dev.ToTranslationRule(dev, false)
+	tr := &model.Translation{
+		Interactive: true,
+		Name:        dev.Name,
+		Version:     model.TranslationVersion,
+		Deployment:  d,
+		PodLabels:   dev.PodLabels,
+		Rules:       []*model.TranslationRule{rule},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	podLabels := d.Spec.Template.Labels
+	if podLabels["role"] != "frontend" {
+		t.Errorf("expected pod label 'role=frontend', got %v", podLabels)
+	}
+	if podLabels["app"] != "web" {
+		t.Errorf("the selector label 'app' was overridden: %v", podLabels)
+	}
+	if podLabels[model.InteractiveDevLabel] != "web" {
+		t.Errorf("expected pod label '%s=web', got %v", model.InteractiveDevLabel, podLabels)
+	}
+
+	dDown, err := TranslateDevModeOff(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := dDown.Spec.Template.Labels["role"]; ok {
+		t.Errorf("pod label 'role' wasn't removed after down: %v", dDown.Spec.Template.Labels)
+	}
+}
+
+func Test_translatePodLabelsLegacyDown(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest
+podLabels:
+  role: frontend
+  app: other
+  tier: web`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := dev.GevSandbox()
+	d.Spec.Template.Labels["role"] = "backend"
+	tr := &model.Translation{
+		Interactive: true,
+		Name:        dev.Name,
+		Version:     model.TranslationVersion,
+		Deployment:  d,
+		PodLabels:   dev.PodLabels,
+		Rules:       []*model.TranslationRule{dev.ToTranslationRule(dev, false)},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// deployments activated by older versions only keep the translation rules
+	delete(d.Annotations, oktetoDeploymentAnnotation)
+	if err := setTranslationAsAnnotation(d.Spec.Template.GetObjectMeta(), tr); err != nil {
+		t.Fatal(err)
+	}
+
+	dDown, err := TranslateDevModeOff(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	podLabels := dDown.Spec.Template.Labels
+	if podLabels["role"] != "backend" {
+		t.Errorf("pod label 'role' wasn't restored after down: %v", podLabels)
+	}
+	if podLabels["app"] != "web" {
+		t.Errorf("the selector label 'app' wasn't kept after down: %v", podLabels)
+	}
+	if _, ok := podLabels["tier"]; ok {
+		t.Errorf("pod label 'tier' wasn't removed after down: %v", podLabels)
+	}
+}
+
+func Test_translatePodAnnotations(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.LoadPodAnnotations([]string{"sidecar.istio.io/inject=false"}); err != nil {
+		t.Fatal(err)
+	}
+	d := dev.GevSandbox()
+	d.Spec.Template.Annotations = map[string]string{"sidecar.istio.io/inject": "true"}
+	rule := dev.ToTranslationRule(dev, false)
+	tr := &model.Translation{
+		Interactive:    true,
+		Name:           dev.Name,
+		Version:        model.TranslationVersion,
+		Deployment:     d,
+		PodAnnotations: dev.PodAnnotations,
+		Rules:          []*model.TranslationRule{rule},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if value := d.Spec.Template.Annotations["sidecar.istio.io/inject"]; value != "false" {
+		t.Errorf("expected pod annotation 'sidecar.istio.io/inject=false', got %v", d.Spec.Template.Annotations)
+	}
+	if _, ok := d.Annotations["sidecar.istio.io/inject"]; ok {
+		t.Errorf("pod annotation set in the deployment: %v", d.Annotations)
+	}
+
+	dDown, err := TranslateDevModeOff(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := dDown.Spec.Template.Annotations["sidecar.istio.io/inject"]; value != "true" {
+		t.Errorf("pod annotation 'sidecar.istio.io/inject' wasn't restored after down: %v", dDown.Spec.Template.Annotations)
+	}
+}
+
+func Test_translatePodAnnotationsLegacyDown(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.LoadPodAnnotations([]string{"sidecar.istio.io/inject=false", "example.com/debug=true"}); err != nil {
+		t.Fatal(err)
+	}
+	d := dev.GevSandbox()
+	d.Spec.Template.Annotations = map[string]string{"sidecar.istio.io/inject": "true"}
+	tr := &model.Translation{
+		Interactive:    true,
+		Name:           dev.Name,
+		Version:        model.TranslationVersion,
+		Deployment:     d,
+		PodAnnotations: dev.PodAnnotations,
+		Rules:          []*model.TranslationRule{dev.ToTranslationRule(dev, false)},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// deployments activated by older versions only keep the translation rules
+	delete(d.Annotations, oktetoDeploymentAnnotation)
+	if err := setTranslationAsAnnotation(d.Spec.Template.GetObjectMeta(), tr); err != nil {
+		t.Fatal(err)
+	}
+
+	dDown, err := TranslateDevModeOff(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := dDown.Spec.Template.Annotations["sidecar.istio.io/inject"]; value != "true" {
+		t.Errorf("pod annotation 'sidecar.istio.io/inject' wasn't restored after down: %v", dDown.Spec.Template.Annotations)
+	}
+	if _, ok := dDown.Spec.Template.Annotations["example.com/debug"]; ok {
+		t.Errorf("pod annotation 'example.com/debug' wasn't removed after down: %v", dDown.Spec.Template.Annotations)
+	}
+}
+
+func Test_translatePullSecret(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: registry.example.com/web:latest
+pullSecret:
+  registry: registry.example.com
+  usernameEnv: REGISTRY_USERNAME
+  passwordEnv: REGISTRY_PASSWORD`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := dev.GevSandbox()
+	d.Spec.Template.Spec.ImagePullSecrets = []apiv1.LocalObjectReference{{Name: "existing"}}
+	rule := dev.ToTranslationRule(dev, false)
+	tr := &model.Translation{
+		Interactive: true,
+		Name:        dev.Name,
+		Version:     model.TranslationVersion,
+		Deployment:  d,
+		PullSecret:  getPullSecretName(dev),
+		Rules:       []*model.TranslationRule{rule},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []apiv1.LocalObjectReference{{Name: "existing"}, {Name: "okteto-pull-web"}}
+	if !reflect.DeepEqual(d.Spec.Template.Spec.ImagePullSecrets, expected) {
+		t.Errorf("expected pull secrets %v, got %v", expected, d.Spec.Template.Spec.ImagePullSecrets)
+	}
+
+	TranslatePullSecret(&d.Spec.Template.Spec, tr.PullSecret)
+	if !reflect.DeepEqual(d.Spec.Template.Spec.ImagePullSecrets, expected) {
+		t.Errorf("pull secret was attached twice: %v", d.Spec.Template.Spec.ImagePullSecrets)
+	}
+
+	removePullSecret(&d.Spec.Template.Spec, tr.PullSecret)
+	expected = []apiv1.LocalObjectReference{{Name: "existing"}}
+	if !reflect.DeepEqual(d.Spec.Template.Spec.ImagePullSecrets, expected) {
+		t.Errorf("expected pull secrets %v after down, got %v", expected, d.Spec.Template.Spec.ImagePullSecrets)
+	}
+}
+
+func Test_translateInitFromImageExclude(t *testing.T) {
+	rule := &model.TranslationRule{
+		Image:            "okteto/golang:1",
+		PersistentVolume: true,
+		InitContainer: model.InitContainer{
+			Exclude: []string{"vendor", "./web/node_modules/"},
+		},
+		Volumes: []model.VolumeMount{
+			{Name: "okteto", MountPath: "/app", SubPath: model.SourceCodeSubPath},
+		},
+	}
+	spec := &apiv1.PodSpec{}
+	TranslateOktetoInitFromImageContainer(spec, rule)
+
+	if len(spec.InitContainers) != 1 {
+		t.Fatalf("expected 1 init container, got %d", len(spec.InitContainers))
+	}
+	expected := []string{"sh", "-c", `echo initializing && ( [ "$(ls -A /init-volume/1)" ] || (cd '/app' && find . \( -path './vendor' -o -path './web/node_modules' \) -prune -o -exec sh -c 'for f; do if [ -d "$f" ] && [ ! -L "$f" ]; then mkdir -p "$0/$f"; else cp -Pp "$f" "$0/$f" && echo "$f"; fi; done' '/init-volume/1' {} +) || true)`}
+	if !reflect.DeepEqual(spec.InitContainers[0].Command, expected) {
+		t.Errorf("wrong init command.\nActual:   %s\nExpected: %s", spec.InitContainers[0].Command, expected)
+	}
+}
+
+func Test_getInitCopyCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		dst      string
+		exclude  []string
+		expected string
+	}{
+		{
+			name:     "no-exclude",
+			src:      "/app",
+			dst:      "/init-volume",
+			expected: `cp -Rv '/app/.' '/init-volume'`,
+		},
+		{
+			name:     "quoted-paths",
+			src:      "/app's dir",
+			dst:      "/init-volume",
+			expected: `cp -Rv '/app'\''s dir/.' '/init-volume'`,
+		},
+		{
+			name:     "quoted-exclude",
+			src:      "/app $(id)",
+			dst:      "/init-volume",
+			exclude:  []string{"node_modules/*"},
+			expected: `(cd '/app $(id)' && find . \( -path './node_modules/*' \) -prune -o -exec sh -c 'for f; do if [ -d "$f" ] && [ ! -L "$f" ]; then mkdir -p "$0/$f"; else cp -Pp "$f" "$0/$f" && echo "$f"; fi; done' '/init-volume' {} +)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getInitCopyCommand(tt.src, tt.dst, tt.exclude); got != tt.expected {
+				t.Errorf("wrong command.\nActual:   %s\nExpected: %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_translateInitFromImageRefresh(t *testing.T) {
+	rule := &model.TranslationRule{
+		Image:            "okteto/node:14",
+		PersistentVolume: true,
+		InitContainer: model.InitContainer{
+			Refresh: []string{"/app/node_modules", "/app/config/"},
+		},
+		Volumes: []model.VolumeMount{
+			{Name: "okteto", MountPath: "/app", SubPath: model.SourceCodeSubPath},
+			{Name: "okteto", MountPath: "/app/node_modules", SubPath: model.DataSubPath},
+		},
+	}
+	spec := &apiv1.PodSpec{}
+	TranslateOktetoInitFromImageContainer(spec, rule)
+
+	if len(spec.InitContainers) != 1 {
+		t.Fatalf("expected 1 init container, got %d", len(spec.InitContainers))
+	}
+	expected := []string{"sh", "-c", `echo initializing && ( [ "$(ls -A /init-volume/1)" ] || cp -Rv '/app/.' '/init-volume/1' || true) && ( mkdir -p /init-volume/1/config && cp -Rv /app/config/. /init-volume/1/config || true) && ( [ "$(ls -A /init-volume/2)" ] || cp -Rv '/app/node_modules/.' '/init-volume/2' || true) && ( mkdir -p /init-volume/2 && cp -Rv /app/node_modules/. /init-volume/2 || true)`}
+	if !reflect.DeepEqual(spec.InitContainers[0].Command, expected) {
+		t.Errorf("wrong init command.\nActual:   %s\nExpected: %s", spec.InitContainers[0].Command, expected)
+	}
+}
+
+func Test_translateSidecars(t *testing.T) {
+	rule := &model.TranslationRule{
+		Container: "dev",
+		Sidecars: []model.Sidecar{
+			{
+				Name:        "db",
+				Image:       "postgres:13",
+				Command:     model.Command{Values: []string{"postgres", "-c", "fsync=off"}},
+				Environment: model.Environment{{Name: "POSTGRES_PASSWORD", Value: "secret"}},
+				Ports:       []int{5432},
+			},
+		},
+	}
+	spec := &apiv1.PodSpec{
+		Containers: []apiv1.Container{
+			{
+				Name:  "dev",
+				Image: "okteto/golang:1",
+				Ports: []apiv1.ContainerPort{{ContainerPort: 8080}},
+			},
+		},
+	}
+	if err := TranslateSidecarContainers(spec, rule); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []apiv1.Container{
+		{
+			Name:  "dev",
+			Image: "okteto/golang:1",
+			Ports: []apiv1.ContainerPort{{ContainerPort: 8080}},
+		},
+		{
+			Name:    "db",
+			Image:   "postgres:13",
+			Command: []string{"postgres", "-c", "fsync=off"},
+			Env:     []apiv1.EnvVar{{Name: "POSTGRES_PASSWORD", Value: "secret"}},
+			Ports:   []apiv1.ContainerPort{{ContainerPort: 5432}},
+		},
+	}
+	if !reflect.DeepEqual(spec.Containers, expected) {
+		t.Errorf("wrong containers.\nActual:   %+v\nExpected: %+v", spec.Containers, expected)
+	}
+
+	rule.Sidecars[0].Ports = []int{8080}
+	spec.Containers = spec.Containers[:1]
+	if err := TranslateSidecarContainers(spec, rule); err == nil {
+		t.Error("expected an error for a port collision with the dev container")
+	}
+
+	rule.Sidecars[0].Name = "dev"
+	rule.Sidecars[0].Ports = nil
+	if err := TranslateSidecarContainers(spec, rule); err == nil {
+		t.Error("expected an error for a name collision with the dev container")
+	}
+}
+
+func Test_translateLifecycle(t *testing.T) {
+	preStop := &apiv1.Handler{Exec: &apiv1.ExecAction{Command: []string{"/app/flush.sh"}}}
+	var gracePeriod int64 = 45
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "n"},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					TerminationGracePeriodSeconds: &gracePeriod,
+					Containers: []apiv1.Container{
+						{
+							Name:  "api",
+							Image: "api:1",
+							Lifecycle: &apiv1.Lifecycle{
+								PostStart: &apiv1.Handler{Exec: &apiv1.ExecAction{Command: []string{"/app/warmup.sh"}}},
+								PreStop:   preStop,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tr := &model.Translation{
+		Interactive: true,
+		Name:        "api",
+		Deployment:  d,
+		Rules: []*model.TranslationRule{
+			{
+				Container: "api",
+				Lifecycle: &model.Lifecycle{},
+			},
+		},
+	}
+	if err := translate(tr, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	c := d.Spec.Template.Spec.Containers[0]
+	if c.Lifecycle.PostStart != nil {
+		t.Error("the postStart hook wasn't removed")
+	}
+	if !reflect.DeepEqual(c.Lifecycle.PreStop, preStop) {
+		t.Errorf("the preStop hook wasn't preserved: %+v", c.Lifecycle.PreStop)
+	}
+	if *d.Spec.Template.Spec.TerminationGracePeriodSeconds != gracePeriod {
+		t.Errorf("expected termination grace period %d, got %d", gracePeriod, *d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	}
+}
+
+func Test_translateLifecyclePreStop(t *testing.T) {
+	c := &apiv1.Container{Name: "api"}
+	TranslateLifecycle(c, &model.Lifecycle{PreStop: model.Command{Values: []string{"sh", "-c", "kill -TERM 1 && sleep 5"}}})
+
+	expected := &apiv1.Lifecycle{
+		PreStop: &apiv1.Handler{Exec: &apiv1.ExecAction{Command: []string{"sh", "-c", "kill -TERM 1 && sleep 5"}}},
+	}
+	if !reflect.DeepEqual(c.Lifecycle, expected) {
+		t.Errorf("wrong lifecycle.\nActual:   %+v\nExpected: %+v", c.Lifecycle, expected)
+	}
+
+	spec := &apiv1.PodSpec{Containers: []apiv1.Container{*c}}
+	TranslateTerminationGracePeriod(spec, nil)
+	if spec.TerminationGracePeriodSeconds != nil {
+		t.Errorf("expected the default termination grace period, got %d", *spec.TerminationGracePeriodSeconds)
+	}
+
+	spec = &apiv1.PodSpec{Containers: []apiv1.Container{{Name: "api"}}}
+	TranslateTerminationGracePeriod(spec, nil)
+	if spec.TerminationGracePeriodSeconds == nil || *spec.TerminationGracePeriodSeconds != devTerminationGracePeriodSeconds {
+		t.Errorf("expected the dev termination grace period, got %v", spec.TerminationGracePeriodSeconds)
+	}
+}
+
+func Test_translateNoInitContainer(t *testing.T) {
+	var tests = []struct {
+		name     string
+		manifest string
+		expected []string
+	}{
+		{
+			name: "default",
+			manifest: `name: web
+namespace: n
+image: web:latest
+sync:
+  - .:/app`,
+			expected: []string{OktetoBinName, OktetoInitVolumeContainerName},
+		},
+		{
+			name: "no-init-container",
+			manifest: `name: web
+namespace: n
+image: web:latest
+initContainer:
+  enabled: false
+sync:
+  - .:/app`,
+			expected: []string{OktetoBinName},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := model.Read([]byte(tt.manifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := dev.GevSandbox()
+			rule := dev.ToTranslationRule(dev, false)
+			tr := &model.Translation{
+				Interactive: true,
+				Name:        dev.Name,
+				Version:     model.TranslationVersion,
+				Deployment:  d,
+				Rules:       []*model.TranslationRule{rule},
+			}
+			if err := translate(tr, nil, false); err != nil {
+				t.Fatal(err)
+			}
+
+			names := []string{}
+			for _, c := range d.Spec.Template.Spec.InitContainers {
+				names = append(names, c.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("expected init containers %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func Test_translateServiceInheritEnv(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+image: web:latest
+environment:
+  key1: value1
+  key2: value2
+services:
+  - name: worker
+    image: worker:latest
+    inheritEnv: true
+    environment:
+      key2: worker2
+      key3: worker3
+  - name: db
+    image: db:latest
+    environment:
+      key3: db3`)
+
+	dev, err := model.Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		service  *model.Dev
+		expected []apiv1.EnvVar
+	}{
+		{
+			name:    "inherit",
+			service: dev.Services[0],
+			expected: []apiv1.EnvVar{
+				{Name: "key1", Value: "value1"},
+				{Name: "key2", Value: "worker2"},
+				{Name: "key3", Value: "worker3"},
+			},
+		},
+		{
+			name:    "no-inherit",
+			service: dev.Services[1],
+			expected: []apiv1.EnvVar{
+				{Name: "key3", Value: "db3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.service.ToTranslationRule(dev, false)
+			c := &apiv1.Container{}
+			TranslateEnvVars(c, rule)
+			if !reflect.DeepEqual(c.Env, tt.expected) {
+				t.Errorf("got %v, expected %v", c.Env, tt.expected)
+			}
+		})
+	}
+
+	if len(dev.Environment) != 2 {
+		t.Errorf("the environment of the main dev was modified: %v", dev.Environment)
+	}
+}
+
 func Test_translateWithoutVolumes(t *testing.T) {
 	manifest := []byte(`name: web
 namespace: n
@@ -725,7 +1490,7 @@ docker:
 							Name:            OktetoInitVolumeContainerName,
 							Image:           "web:latest",
 							ImagePullPolicy: apiv1.PullIfNotPresent,
-							Command:         []string{"sh", "-c", "echo initializing && ( [ \"$(ls -A /init-volume/1)\" ] || cp -Rv /app/. /init-volume/1 || true)"},
+							Command:         []string{"sh", "-c", "echo initializing && ( [ \"$(ls -A /init-volume/1)\" ] || cp -Rv '/app/.' '/init-volume/1' || true)"},
 							SecurityContext: &apiv1.SecurityContext{
 								RunAsUser:    &rootUser,
 								RunAsGroup:   &rootUser,
@@ -1074,6 +1839,25 @@ func Test_translateSecurityContext(t *testing.T) {
 			expectedAdd:  []apiv1.Capability{"SYS_FOO", "SYS_TRACE"},
 			expectedDrop: []apiv1.Capability{"SYS_BAR", "SYS_NICE"},
 		},
+		{
+			name: "dedupe-and-sort",
+			c: &apiv1.Container{
+				SecurityContext: &apiv1.SecurityContext{
+					Capabilities: &apiv1.Capabilities{
+						Add:  []apiv1.Capability{"SYS_TRACE", "NET_ADMIN"},
+						Drop: []apiv1.Capability{"SYS_NICE"},
+					},
+				},
+			},
+			s: &model.SecurityContext{
+				Capabilities: &model.Capabilities{
+					Add:  []apiv1.Capability{"SYS_TRACE", "SYS_PTRACE", "SYS_PTRACE"},
+					Drop: []apiv1.Capability{"SYS_NICE", "KILL"},
+				},
+			},
+			expectedAdd:  []apiv1.Capability{"NET_ADMIN", "SYS_PTRACE", "SYS_TRACE"},
+			expectedDrop: []apiv1.Capability{"KILL", "SYS_NICE"},
+		},
 		{
 			name: "read-only",
 			c: &apiv1.Container{
@@ -1295,7 +2079,7 @@ environment:
 							Name:            OktetoInitVolumeContainerName,
 							Image:           "web:latest",
 							ImagePullPolicy: apiv1.PullIfNotPresent,
-							Command:         []string{"sh", "-c", "echo initializing && ( [ \"$(ls -A /init-volume/1)\" ] || cp -Rv /app/. /init-volume/1 || true)"},
+							Command:         []string{"sh", "-c", "echo initializing && ( [ \"$(ls -A /init-volume/1)\" ] || cp -Rv '/app/.' '/init-volume/1' || true)"},
 							SecurityContext: &apiv1.SecurityContext{
 								RunAsUser:    &rootUser,
 								RunAsGroup:   &rootUser,
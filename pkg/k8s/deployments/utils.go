@@ -15,6 +15,7 @@ package deployments
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/okteto/okteto/pkg/k8s/annotations"
 	"github.com/okteto/okteto/pkg/log"
@@ -36,6 +37,32 @@ func setTranslationAsAnnotation(o metav1.Object, tr *model.Translation) error {
 	return nil
 }
 
+// setOriginalDeploymentAsAnnotation stores the spec of the deployment before the dev mode translation,
+// so that "okteto down" can restore it verbatim instead of reversing the translation
+func setOriginalDeploymentAsAnnotation(d *appsv1.Deployment) error {
+	d.Status = appsv1.DeploymentStatus{}
+	delete(d.Annotations, oktetoDeploymentAnnotation)
+	manifestBytes, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	annotations.Set(d.GetObjectMeta(), oktetoDeploymentAnnotation, string(manifestBytes))
+	return nil
+}
+
+// GetOriginalDeploymentFromAnnotation returns the deployment before the dev mode translation, or nil if it isn't stored in its annotations
+func GetOriginalDeploymentFromAnnotation(d *appsv1.Deployment) (*appsv1.Deployment, error) {
+	manifest := annotations.Get(d.GetObjectMeta(), oktetoDeploymentAnnotation)
+	if manifest == "" {
+		return nil, nil
+	}
+	dOrig := &appsv1.Deployment{}
+	if err := json.Unmarshal([]byte(manifest), dOrig); err != nil {
+		return nil, fmt.Errorf("malformed manifest: %s", err)
+	}
+	return dOrig, nil
+}
+
 func getTranslationFromAnnotation(annotations map[string]string) (model.Translation, error) {
 	tr := model.Translation{}
 	err := json.Unmarshal([]byte(annotations[model.TranslationAnnotation]), &tr)
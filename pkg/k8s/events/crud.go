@@ -16,6 +16,7 @@ package events
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -54,3 +55,20 @@ func GetUnhealthyEventFailure(ctx context.Context, namespace, podName string, c
 	}
 	return ""
 }
+
+// ListRecent returns the last max events of a namespace, sorted by their last timestamp
+func ListRecent(ctx context.Context, namespace string, max int, c kubernetes.Interface) ([]apiv1.Event, error) {
+	events, err := c.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	items := events.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].LastTimestamp.Before(&items[j].LastTimestamp)
+	})
+	if len(items) > max {
+		items = items[len(items)-max:]
+	}
+	return items, nil
+}
@@ -17,18 +17,34 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"strings"
 
 	dockerterm "github.com/moby/term"
+	"github.com/okteto/okteto/pkg/log"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
 	kexec "k8s.io/kubectl/pkg/cmd/exec"
 )
 
+// contextExecutor is a remote executor that closes the exec stream once the context is done
+type contextExecutor struct {
+	ctx context.Context
+}
+
+// contextUpgrader closes the upgraded connections once the context is done
+type contextUpgrader struct {
+	ctx      context.Context
+	upgrader spdy.Upgrader
+}
+
 // Exec executes the command in the development container
 func Exec(ctx context.Context, c *kubernetes.Clientset, config *rest.Config, podNamespace, podName, container string, tty bool, stdin io.Reader, stdout, stderr io.Writer, command []string) error {
 	//dockerterm.StdStreams() configures the terminal on windows
@@ -38,7 +54,7 @@ func Exec(ctx context.Context, c *kubernetes.Clientset, config *rest.Config, pod
 
 	p.Config = config
 	p.Command = command
-	p.Executor = &kexec.DefaultRemoteExecutor{}
+	p.Executor = &contextExecutor{ctx: ctx}
 	p.IOStreams = genericclioptions.IOStreams{In: stdin, Out: stdout, ErrOut: stderr}
 	p.Stdin = true
 	p.TTY = tty
@@ -97,3 +113,47 @@ func Exec(ctx context.Context, c *kubernetes.Clientset, config *rest.Config, pod
 
 	return nil
 }
+
+// Execute implements the RemoteExecutor interface of kubectl
+func (e *contextExecutor) Execute(method string, url *url.URL, config *rest.Config, stdin io.Reader, stdout, stderr io.Writer, tty bool, terminalSizeQueue remotecommand.TerminalSizeQueue) error {
+	wrapper, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return err
+	}
+
+	exec, err := remotecommand.NewSPDYExecutorForTransports(wrapper, &contextUpgrader{ctx: e.ctx, upgrader: upgrader}, method, url)
+	if err != nil {
+		return err
+	}
+
+	return exec.Stream(remotecommand.StreamOptions{
+		Stdin:             stdin,
+		Stdout:            stdout,
+		Stderr:            stderr,
+		Tty:               tty,
+		TerminalSizeQueue: terminalSizeQueue,
+	})
+}
+
+// NewConnection implements the Upgrader interface of the spdy pkg
+func (u *contextUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	go closeOnDone(u.ctx, conn)
+	return conn, nil
+}
+
+func closeOnDone(ctx context.Context, conn httpstream.Connection) {
+	select {
+	case <-ctx.Done():
+		if err := conn.Close(); err != nil {
+			log.Infof("failed to close the exec stream: %s", err)
+			return
+		}
+		log.Infof("exec stream closed: %s", ctx.Err())
+	case <-conn.CloseChan():
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+type fakeConnection struct {
+	httpstream.Connection
+	once   sync.Once
+	closed chan bool
+}
+
+func (c *fakeConnection) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *fakeConnection) CloseChan() <-chan bool {
+	return c.closed
+}
+
+type fakeUpgrader struct {
+	conn *fakeConnection
+}
+
+func (u *fakeUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	return u.conn, nil
+}
+
+func Test_contextUpgraderClosesStreamOnTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	conn := &fakeConnection{closed: make(chan bool)}
+	u := &contextUpgrader{ctx: ctx, upgrader: &fakeUpgrader{conn: conn}}
+	c, err := u.NewConnection(&http.Response{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fake exec never returns until its stream is closed
+	done := make(chan struct{})
+	go func() {
+		<-c.CloseChan()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the exec stream wasn't closed after the timeout")
+	}
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("expected the context deadline to be exceeded, got %v", ctx.Err())
+	}
+}
+
+func Test_contextUpgraderKeepsStreamOpen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn := &fakeConnection{closed: make(chan bool)}
+	u := &contextUpgrader{ctx: ctx, upgrader: &fakeUpgrader{conn: conn}}
+	if _, err := u.NewConnection(&http.Response{}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-conn.closed:
+		t.Fatal("the exec stream was closed before the context was done")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
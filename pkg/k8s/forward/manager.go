@@ -40,8 +40,8 @@ type PortForwardManager struct {
 	stopped        bool
 	iface          string
 	ports          map[int]model.Forward
-	services       map[string]struct{}
-	activeDev      *active
+	services       map[serviceForward]struct{}
+	activeDev      []*active
 	activeServices map[string]*active
 	ctx            context.Context
 	restConfig     *rest.Config
@@ -49,6 +49,12 @@ type PortForwardManager struct {
 	namespace      string
 }
 
+// serviceForward identifies the port forwards to a service that listen on the same local address
+type serviceForward struct {
+	name    string
+	address string
+}
+
 type active struct {
 	readyChan chan struct{}
 	stopChan  chan struct{}
@@ -84,7 +90,7 @@ func NewPortForwardManager(ctx context.Context, iface string, restConfig *rest.C
 		ctx:        ctx,
 		iface:      iface,
 		ports:      make(map[int]model.Forward),
-		services:   make(map[string]struct{}),
+		services:   make(map[serviceForward]struct{}),
 		restConfig: restConfig,
 		client:     c,
 		namespace:  namespace,
@@ -97,7 +103,7 @@ func (p *PortForwardManager) Add(f model.Forward) error {
 		return fmt.Errorf("port %d is listed multiple times, please check your configuration", f.Local)
 	}
 
-	if !model.IsPortAvailable(p.iface, f.Local) {
+	if !model.IsPortAvailable(f.GetLocalAddress(p.iface), f.Local) {
 		if f.Local <= 1024 {
 			os := runtime.GOOS
 			switch os {
@@ -112,7 +118,7 @@ func (p *PortForwardManager) Add(f model.Forward) error {
 
 	p.ports[f.Local] = f
 	if f.Service {
-		p.services[f.ServiceName] = struct{}{}
+		p.services[serviceForward{name: f.ServiceName, address: f.GetLocalAddress(p.iface)}] = struct{}{}
 	}
 
 	return nil
@@ -123,33 +129,41 @@ func (p *PortForwardManager) AddReverse(_ model.Reverse) error {
 	return fmt.Errorf("not implemented")
 }
 
-// Start starts all the port forwarders to the development container
+// Start starts all the port forwarders to the development container. If one of them fails, the ones already started are stopped
 func (p *PortForwardManager) Start(devPod, namespace string) error {
 	p.stopped = false
-	a, devPF, err := p.buildForwarderToDevPod(namespace, devPod)
-	if err != nil {
-		return fmt.Errorf("failed to k8s forward to development container: %w", err)
-	}
-
-	p.activeDev = a
-	go func() {
-		err := devPF.ForwardPorts()
+	p.activeDev = []*active{}
+	for address, ports := range getDevPorts(p.ports, p.iface) {
+		a, devPF, err := p.buildForwarder(namespace, devPod, address, ports)
 		if err != nil {
-			log.Infof("k8s forwarding to dev pod finished with errors: %s", err)
-			p.activeDev.closeReady()
-			p.activeDev.err = err
+			p.Stop()
+			return fmt.Errorf("failed to k8s forward to development container: %w", err)
 		}
-	}()
+
+		p.activeDev = append(p.activeDev, a)
+		go func() {
+			err := devPF.ForwardPorts()
+			if err != nil {
+				log.Infof("k8s forwarding to dev pod finished with errors: %s", err)
+				a.closeReady()
+				a.err = err
+			}
+		}()
+	}
 
 	p.activeServices = map[string]*active{}
 	for svc := range p.services {
 		go p.forwardService(p.ctx, namespace, svc)
 	}
 
-	<-p.activeDev.readyChan
-
-	if err := p.activeDev.error(); err != nil {
-		return err
+	for _, a := range p.activeDev {
+		if a.readyChan != nil {
+			<-a.readyChan
+		}
+		if err := a.error(); err != nil {
+			p.Stop()
+			return err
+		}
 	}
 
 	log.Infof("all k8s port-forwards are connected")
@@ -159,7 +173,9 @@ func (p *PortForwardManager) Start(devPod, namespace string) error {
 // Stop stops all the port forwarders
 func (p *PortForwardManager) Stop() {
 	p.stopped = true
-	p.activeDev.stop()
+	for _, a := range p.activeDev {
+		a.stop()
+	}
 
 	for _, a := range p.activeServices {
 		a.stop()
@@ -179,18 +195,20 @@ func (fm *PortForwardManager) TransformLabelsToServiceName(f model.Forward) (mod
 	return f, nil
 }
 
-func (p *PortForwardManager) buildForwarderToDevPod(namespace, pod string) (*active, *portforward.PortForwarder, error) {
-	ports := []string{}
-	for _, f := range p.ports {
+// getDevPorts returns the ports forwarded to the development container, grouped by the local address they listen on
+func getDevPorts(forwards map[int]model.Forward, iface string) map[string][]string {
+	result := map[string][]string{}
+	for _, f := range forwards {
 		if !f.Service {
-			ports = append(ports, fmt.Sprintf("%d:%d", f.Local, f.Remote))
+			address := f.GetLocalAddress(iface)
+			result[address] = append(result[address], fmt.Sprintf("%d:%d", f.Local, f.Remote))
 		}
 	}
 
-	return p.buildForwarder(namespace, pod, ports)
+	return result
 }
 
-func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []string) (*active, *portforward.PortForwarder, error) {
+func (p *PortForwardManager) buildForwarder(namespace, pod, address string, ports []string) (*active, *portforward.PortForwarder, error) {
 	dialer, err := p.buildDialer(namespace, pod)
 	if err != nil {
 		return nil, nil, err
@@ -204,7 +222,7 @@ func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []strin
 
 	pf, err := portforward.NewOnAddresses(
 		dialer,
-		[]string{p.iface},
+		[]string{address},
 		ports,
 		a.stopChan,
 		a.readyChan,
@@ -218,8 +236,8 @@ func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []strin
 	return a, pf, nil
 }
 
-func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service string) (*active, *portforward.PortForwarder, error) {
-	svc, err := services.Get(ctx, service, namespace, p.client)
+func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace string, service serviceForward) (*active, *portforward.PortForwarder, error) {
+	svc, err := services.Get(ctx, service.name, namespace, p.client)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -233,14 +251,14 @@ func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namesp
 		return nil, nil, fmt.Errorf("failed to get pod mapped to service/%s: %w", svc.GetName(), err)
 	}
 
-	ports := getServicePorts(svc.GetName(), p.ports)
-	return p.buildForwarder(pod.GetNamespace(), pod.GetName(), ports)
+	ports := getServicePorts(service, p.ports, p.iface)
+	return p.buildForwarder(pod.GetNamespace(), pod.GetName(), service.address, ports)
 }
 
-func getServicePorts(service string, forwards map[int]model.Forward) []string {
+func getServicePorts(service serviceForward, forwards map[int]model.Forward, iface string) []string {
 	ports := []string{}
 	for _, f := range forwards {
-		if f.Service && f.ServiceName == service {
+		if f.Service && f.ServiceName == service.name && f.GetLocalAddress(iface) == service.address {
 			remote := f.Remote
 			ports = append(ports, fmt.Sprintf("%d:%d", f.Local, remote))
 		}
@@ -268,7 +286,7 @@ func (p *PortForwardManager) buildDialer(namespace, pod string) (httpstream.Dial
 	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url), nil
 }
 
-func (p *PortForwardManager) forwardService(ctx context.Context, namespace, service string) {
+func (p *PortForwardManager) forwardService(ctx context.Context, namespace string, service serviceForward) {
 	t := time.NewTicker(3 * time.Second)
 
 	for {
@@ -276,19 +294,19 @@ func (p *PortForwardManager) forwardService(ctx context.Context, namespace, serv
 			return
 		}
 
-		log.Infof("k8s forwarding ports for service/%s", service)
+		log.Infof("k8s forwarding ports for service/%s on %s", service.name, service.address)
 		a, pf, err := p.buildForwarderToService(ctx, namespace, service)
 		if err != nil {
-			log.Infof("failed to k8s forward ports to service/%s: %s", service, err)
+			log.Infof("failed to k8s forward ports to service/%s: %s", service.name, err)
 			<-t.C
 			continue
 		}
 
 		if err := pf.ForwardPorts(); err != nil {
-			log.Infof("k8s forwarding to service/%s finished with errors: %s", service, err)
+			log.Infof("k8s forwarding to service/%s finished with errors: %s", service.name, err)
 			a.stop()
 		} else {
-			log.Infof("k8s forwarding to service/%s finished", service)
+			log.Infof("k8s forwarding to service/%s finished", service.name)
 		}
 
 		<-t.C
@@ -45,16 +45,18 @@ func TestAdd(t *testing.T) {
 		t.Fatalf("expected 3 ports but got %d", len(pf.ports))
 	}
 
-	if _, ok := pf.services["svc"]; !ok {
+	if _, ok := pf.services[serviceForward{name: "svc", address: model.Localhost}]; !ok {
 		t.Errorf("service/svc wasn't added to list: %+v", pf.services)
 	}
 }
 
 func TestStop(t *testing.T) {
 	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
-	pf.activeDev = &active{
-		readyChan: make(chan struct{}, 1),
-		stopChan:  make(chan struct{}, 1),
+	pf.activeDev = []*active{
+		{
+			readyChan: make(chan struct{}, 1),
+			stopChan:  make(chan struct{}, 1),
+		},
 	}
 
 	pf.activeServices = map[string]*active{
@@ -171,11 +173,19 @@ func Test_getServicePorts(t *testing.T) {
 			},
 			expected: []string{"8080:8090", "8089:80890"},
 		},
+		{
+			name: "services-with-address",
+			forwards: map[int]model.Forward{
+				8080: {Local: 8080, Remote: 8090, ServiceName: "svc", Service: true},
+				8089: {Local: 8089, Remote: 8090, ServiceName: "svc", Service: true, Address: "0.0.0.0"},
+			},
+			expected: []string{"8080:8090"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ports := getServicePorts("svc", tt.forwards)
+			ports := getServicePorts(serviceForward{name: "svc", address: model.Localhost}, tt.forwards, model.Localhost)
 			sort.Strings(ports)
 			if !reflect.DeepEqual(ports, tt.expected) {
 				t.Errorf("Expected: %+v, Got: %+v", tt.expected, ports)
@@ -183,3 +193,25 @@ func Test_getServicePorts(t *testing.T) {
 		})
 	}
 }
+
+func Test_getDevPorts(t *testing.T) {
+	forwards := map[int]model.Forward{
+		8080: {Local: 8080, Remote: 8080},
+		9090: {Local: 9090, Remote: 9091, Address: "0.0.0.0"},
+		9229: {Local: 9229, Remote: 9229},
+		5432: {Local: 5432, Remote: 5432, ServiceName: "db", Service: true, Address: "0.0.0.0"},
+	}
+
+	ports := getDevPorts(forwards, model.Localhost)
+	for _, p := range ports {
+		sort.Strings(p)
+	}
+
+	expected := map[string][]string{
+		model.Localhost: {"8080:8080", "9229:9229"},
+		"0.0.0.0":       {"9090:9091"},
+	}
+	if !reflect.DeepEqual(ports, expected) {
+		t.Errorf("Expected: %+v, Got: %+v", expected, ports)
+	}
+}
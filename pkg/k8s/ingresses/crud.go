@@ -36,8 +36,8 @@ type Ingress struct {
 	V1Beta1 *networkingv1beta1.Ingress
 }
 
-func GetClient(ctx context.Context, c *kubernetes.Clientset) (*Client, error) {
-	rList, err := c.ServerResourcesForGroupVersion("networking.k8s.io/v1")
+func GetClient(ctx context.Context, c kubernetes.Interface) (*Client, error) {
+	rList, err := c.Discovery().ServerResourcesForGroupVersion("networking.k8s.io/v1")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,118 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limitranges
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// List returns the limit ranges of a namespace
+func List(ctx context.Context, namespace string, c kubernetes.Interface) ([]apiv1.LimitRange, error) {
+	lrs, err := c.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return lrs.Items, nil
+}
+
+// GetContainerDefaults returns the default requests and limits that the limit ranges set to containers without resources
+func GetContainerDefaults(lrs []apiv1.LimitRange) model.ResourceRequirements {
+	result := model.ResourceRequirements{
+		Requests: model.ResourceList{},
+		Limits:   model.ResourceList{},
+	}
+	for _, lr := range lrs {
+		for _, item := range lr.Spec.Limits {
+			if item.Type != apiv1.LimitTypeContainer {
+				continue
+			}
+			for name, q := range item.DefaultRequest {
+				result.Requests[name] = q
+			}
+			for name, q := range item.Default {
+				result.Limits[name] = q
+			}
+		}
+	}
+	return result
+}
+
+// ValidateResources checks that the requests and limits of a container are within the min and max of the limit ranges
+func ValidateResources(lrs []apiv1.LimitRange, r model.ResourceRequirements) error {
+	for _, lr := range lrs {
+		for _, item := range lr.Spec.Limits {
+			if item.Type != apiv1.LimitTypeContainer {
+				continue
+			}
+			if err := validateResourceList("request", r.Requests, item, lr.Name); err != nil {
+				return err
+			}
+			if err := validateResourceList("limit", r.Limits, item, lr.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateResourceList(kind string, values model.ResourceList, item apiv1.LimitRangeItem, lrName string) error {
+	for _, name := range sortedNames(values) {
+		q := values[name]
+		if min, ok := item.Min[name]; ok && q.Cmp(min) < 0 {
+			return fmt.Errorf("%s %s '%s' is lower than the minimum '%s' of the LimitRange '%s'", name, kind, q.String(), min.String(), lrName)
+		}
+		if max, ok := item.Max[name]; ok && q.Cmp(max) > 0 {
+			return fmt.Errorf("%s %s '%s' is greater than the maximum '%s' of the LimitRange '%s'", name, kind, q.String(), max.String(), lrName)
+		}
+	}
+	return nil
+}
+
+// FormatResources returns a readable representation of resources, like "requests: cpu=100m, memory=128Mi; limits: cpu=1"
+func FormatResources(r model.ResourceRequirements) string {
+	result := []string{}
+	if len(r.Requests) > 0 {
+		result = append(result, fmt.Sprintf("requests: %s", formatResourceList(r.Requests)))
+	}
+	if len(r.Limits) > 0 {
+		result = append(result, fmt.Sprintf("limits: %s", formatResourceList(r.Limits)))
+	}
+	return strings.Join(result, "; ")
+}
+
+func formatResourceList(values model.ResourceList) string {
+	result := []string{}
+	for _, name := range sortedNames(values) {
+		q := values[name]
+		result = append(result, fmt.Sprintf("%s=%s", name, q.String()))
+	}
+	return strings.Join(result, ", ")
+}
+
+func sortedNames(values model.ResourceList) []apiv1.ResourceName {
+	names := []apiv1.ResourceName{}
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
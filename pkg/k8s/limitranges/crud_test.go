@@ -0,0 +1,114 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limitranges
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func getLimitRange() *apiv1.LimitRange {
+	return &apiv1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "test"},
+		Spec: apiv1.LimitRangeSpec{
+			Limits: []apiv1.LimitRangeItem{
+				{
+					Type: apiv1.LimitTypePod,
+					Max:  apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("8")},
+				},
+				{
+					Type:           apiv1.LimitTypeContainer,
+					Min:            apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("64Mi")},
+					Max:            apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("2"), apiv1.ResourceMemory: resource.MustParse("4Gi")},
+					Default:        apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("1"), apiv1.ResourceMemory: resource.MustParse("1Gi")},
+					DefaultRequest: apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("100m")},
+				},
+			},
+		},
+	}
+}
+
+func TestGetContainerDefaults(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(getLimitRange())
+
+	lrs, err := List(ctx, "test", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := GetContainerDefaults(lrs)
+	expected := "requests: cpu=100m; limits: cpu=1, memory=1Gi"
+	if result := FormatResources(defaults); result != expected {
+		t.Errorf("expected '%s', got '%s'", expected, result)
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	lrs := []apiv1.LimitRange{*getLimitRange()}
+
+	var tests = []struct {
+		name      string
+		resources model.ResourceRequirements
+		expectErr bool
+	}{
+		{
+			name: "within-limits",
+			resources: model.ResourceRequirements{
+				Requests: model.ResourceList{apiv1.ResourceMemory: resource.MustParse("128Mi")},
+				Limits:   model.ResourceList{apiv1.ResourceCPU: resource.MustParse("2")},
+			},
+			expectErr: false,
+		},
+		{
+			name: "limit-greater-than-max",
+			resources: model.ResourceRequirements{
+				Limits: model.ResourceList{apiv1.ResourceCPU: resource.MustParse("4")},
+			},
+			expectErr: true,
+		},
+		{
+			name: "request-lower-than-min",
+			resources: model.ResourceRequirements{
+				Requests: model.ResourceList{apiv1.ResourceMemory: resource.MustParse("32Mi")},
+			},
+			expectErr: true,
+		},
+		{
+			name: "resource-without-constraints",
+			resources: model.ResourceRequirements{
+				Limits: model.ResourceList{model.ResourceNVIDIAGPU: resource.MustParse("1")},
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResources(lrs, tt.resources)
+			if tt.expectErr && err == nil {
+				t.Error("didn't get the expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("got an unexpected error: %s", err)
+			}
+		})
+	}
+}
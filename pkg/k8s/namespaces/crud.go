@@ -15,11 +15,15 @@ package namespaces
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/okteto/okteto/pkg/errors"
 	k8Client "github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"k8s.io/client-go/kubernetes"
 )
@@ -44,7 +48,7 @@ func IsOktetoAllowed(ns *apiv1.Namespace) bool {
 }
 
 // Get returns the namespace object of ns
-func Get(ctx context.Context, ns string, c *kubernetes.Clientset) (*apiv1.Namespace, error) {
+func Get(ctx context.Context, ns string, c kubernetes.Interface) (*apiv1.Namespace, error) {
 	n, err := c.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
@@ -53,6 +57,41 @@ func Get(ctx context.Context, ns string, c *kubernetes.Clientset) (*apiv1.Namesp
 	return n, nil
 }
 
+// WaitUntilReady waits for a namespace to exist and allow okteto operations.
+// Namespaces created by okteto might not be fully provisioned right after their creation
+func WaitUntilReady(ctx context.Context, ns string, c kubernetes.Interface, interval, timeout time.Duration) (*apiv1.Namespace, error) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+
+	for {
+		n, err := Get(ctx, ns, c)
+		switch {
+		case err == nil:
+			if IsOktetoAllowed(n) {
+				return n, nil
+			}
+			log.Infof("namespace '%s' doesn't allow okteto operations yet", ns)
+		case errors.IsNotFound(err), errors.IsForbidden(err), errors.IsTransient(err):
+			log.Infof("namespace '%s' is not ready yet: %s", ns, err)
+		default:
+			return nil, err
+		}
+
+		select {
+		case <-t.C:
+		case <-to.C:
+			if err != nil {
+				return nil, fmt.Errorf("namespace '%s' wasn't ready after %s: %w", ns, timeout, err)
+			}
+			return n, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func IsOktetoNamespaceFromName(ctx context.Context, namespace string) bool {
 	c, _, err := k8Client.GetLocal()
 	if err != nil {
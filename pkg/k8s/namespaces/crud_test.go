@@ -0,0 +1,115 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestWaitUntilReady(t *testing.T) {
+	notFound := k8sErrors.NewNotFound(apiv1.Resource("namespaces"), "ns")
+	notAllowed := &apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: map[string]string{OktetoNotAllowedLabel: "true"}},
+	}
+
+	var tests = []struct {
+		name          string
+		responses     []interface{}
+		expectErr     bool
+		expectAllowed bool
+	}{
+		{
+			name:          "ready",
+			responses:     []interface{}{},
+			expectAllowed: true,
+		},
+		{
+			name:          "not-found-then-ready",
+			responses:     []interface{}{notFound, notFound},
+			expectAllowed: true,
+		},
+		{
+			name:          "not-allowed-then-ready",
+			responses:     []interface{}{notAllowed, notAllowed},
+			expectAllowed: true,
+		},
+		{
+			name:      "never-found",
+			responses: repeat(notFound, 1000),
+			expectErr: true,
+		},
+		{
+			name:      "unexpected-error",
+			responses: []interface{}{fmt.Errorf("the server has asked for the client to provide credentials")},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}})
+			calls := 0
+			c.PrependReactor("get", "namespaces", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls > len(tt.responses) {
+					return false, nil, nil
+				}
+				switch r := tt.responses[calls-1].(type) {
+				case error:
+					return true, nil, r
+				case *apiv1.Namespace:
+					return true, r, nil
+				}
+				return false, nil, nil
+			})
+
+			n, err := WaitUntilReady(context.Background(), "ns", c, 5*time.Millisecond, 50*time.Millisecond)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if IsOktetoAllowed(n) != tt.expectAllowed {
+				t.Errorf("expected allowed %t, got %t", tt.expectAllowed, IsOktetoAllowed(n))
+			}
+
+			if calls != len(tt.responses)+1 {
+				t.Errorf("expected %d polls, got %d", len(tt.responses)+1, calls)
+			}
+		})
+	}
+}
+
+func repeat(r interface{}, n int) []interface{} {
+	result := make([]interface{}, n)
+	for i := range result {
+		result[i] = r
+	}
+	return result
+}
@@ -40,6 +40,7 @@ import (
 const (
 	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
 	maxRetriesPodRunning         = 300 //1min pod is created
+	oomKilledReason              = "OOMKilled"
 )
 
 var (
@@ -426,6 +427,146 @@ func waitUntilRunning(ctx context.Context, namespace, selector string, c *kubern
 	return fmt.Errorf("Pod(s) %s didn't restart after 60 seconds", strings.Join(pods, ","))
 }
 
+// GetImageDigest returns the repository digest of the image running in a container of a pod, as reported by its container statuses
+func GetImageDigest(p *apiv1.Pod, container string) (string, error) {
+	if container == "" {
+		return "", fmt.Errorf("the container name is required to get the image digest of pod '%s'", p.Name)
+	}
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name != container {
+			continue
+		}
+		return parseImageDigest(cs.ImageID)
+	}
+	return "", fmt.Errorf("container '%s' not found in pod '%s'", container, p.Name)
+}
+
+// parseImageDigest returns the digest of an image ID in the 'repository@sha256:<digest>' form.
+// Image IDs like 'docker://sha256:<id>' are local image config IDs, not repository digests, and are rejected
+func parseImageDigest(imageID string) (string, error) {
+	i := strings.LastIndex(imageID, "@")
+	if i < 0 || !strings.HasPrefix(imageID[i+1:], "sha256:") {
+		return "", fmt.Errorf("image ID '%s' doesn't contain a repository digest", imageID)
+	}
+	return imageID[i+1:], nil
+}
+
+// CheckImagePullErrors returns a user error if a container of the pod can't start because its image can't be pulled
+func CheckImagePullErrors(p *apiv1.Pod) error {
+	statuses := []apiv1.ContainerStatus{}
+	statuses = append(statuses, p.Status.InitContainerStatuses...)
+	statuses = append(statuses, p.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.State.Waiting == nil {
+			continue
+		}
+		switch cs.State.Waiting.Reason {
+		case "ErrImageNeverPull":
+			return errors.UserError{
+				E:    fmt.Errorf("Image '%s' is not present on the node and 'imagePullPolicy' is set to 'Never'", cs.Image),
+				Hint: "Build or load the image in your cluster nodes, or set 'imagePullPolicy' to 'IfNotPresent' in your okteto manifest",
+			}
+		case "ImagePullBackOff":
+			return errors.UserError{
+				E:    fmt.Errorf("Image '%s' can't be pulled: %s", cs.Image, cs.State.Waiting.Message),
+				Hint: "Check that the image exists and that your cluster has access to its registry, or build it with 'okteto build'",
+			}
+		}
+	}
+	return nil
+}
+
+// CheckMissingStartScript returns a user error if the development container can't start because the okteto start script doesn't exist
+func CheckMissingStartScript(p *apiv1.Pod, container string) error {
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name != container {
+			continue
+		}
+
+		messages := []string{}
+		if cs.State.Waiting != nil {
+			messages = append(messages, cs.State.Waiting.Message)
+		}
+		if cs.State.Terminated != nil {
+			messages = append(messages, cs.State.Terminated.Message)
+		}
+		if cs.LastTerminationState.Terminated != nil {
+			messages = append(messages, cs.LastTerminationState.Terminated.Message)
+		}
+
+		for _, m := range messages {
+			if err := CheckMissingStartScriptMessage(m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CheckMissingStartScriptMessage returns a user error if a container status or event message reports that the okteto start script doesn't exist
+func CheckMissingStartScriptMessage(message string) error {
+	if !strings.Contains(message, model.OktetoStartScript) {
+		return nil
+	}
+	if !strings.Contains(message, "no such file or directory") && !strings.Contains(message, "not found") {
+		return nil
+	}
+
+	return errors.UserError{
+		E: fmt.Errorf("Your development container can't start: '%s' doesn't exist", model.OktetoStartScript),
+		Hint: fmt.Sprintf(`okteto copies its binaries to '/var/okteto/bin' with the init container of your development container.
+    If you set 'initContainer.image' in your okteto manifest, use an image based on '%s' or remove the field.
+    Check also that none of your volumes is mounted on '/var/okteto/bin'`, model.OktetoBinImageTag),
+	}
+}
+
+// GetRestartCount returns the number of restarts of a container of a pod
+func GetRestartCount(p *apiv1.Pod, container string) int32 {
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name == container {
+			return cs.RestartCount
+		}
+	}
+	return 0
+}
+
+// CheckOOMKilled returns an error if the container has been OOMKilled after having been restarted 'restarts' times
+func CheckOOMKilled(p *apiv1.Pod, container string, restarts int32) error {
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name != container {
+			continue
+		}
+
+		terminated := cs.State.Terminated
+		if (terminated == nil || terminated.Reason != oomKilledReason) && cs.RestartCount > restarts {
+			terminated = cs.LastTerminationState.Terminated
+		}
+		if terminated == nil || terminated.Reason != oomKilledReason {
+			return nil
+		}
+
+		hint := "Increase the memory limit of your development container in the 'resources' field of your okteto manifest and run 'okteto up' again"
+		for _, c := range p.Spec.Containers {
+			if c.Name != container {
+				continue
+			}
+			if limit, ok := c.Resources.Limits[apiv1.ResourceMemory]; ok {
+				hint = fmt.Sprintf("The current memory limit is %s. %s", limit.String(), hint)
+			}
+		}
+		return errors.UserError{
+			E:    fmt.Errorf("Your development container ran out of memory and was killed (reason: %s, exit code: %d)", terminated.Reason, terminated.ExitCode),
+			Hint: hint,
+		}
+	}
+	return nil
+}
+
+// IsReady returns true if the pod is running, ready and not being deleted
+func IsReady(p *apiv1.Pod) bool {
+	return isRunning(p)
+}
+
 func isRunning(p *apiv1.Pod) bool {
 	if p.Status.Phase != apiv1.PodRunning {
 		return false
@@ -15,9 +15,12 @@ package pods
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/errors"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -179,3 +182,328 @@ func Test_parseUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetImageDigest(t *testing.T) {
+	var tests = []struct {
+		name      string
+		container string
+		statuses  []apiv1.ContainerStatus
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:      "docker-pullable",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "sidecar", ImageID: "docker-pullable://okteto/sidecar@sha256:111"},
+				{Name: "dev", ImageID: "docker-pullable://okteto/golang@sha256:222"},
+			},
+			expected: "sha256:222",
+		},
+		{
+			name:      "containerd",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "dev", ImageID: "docker.io/okteto/golang@sha256:333"},
+			},
+			expected: "sha256:333",
+		},
+		{
+			name:      "local-image-config-id",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "dev", ImageID: "docker://sha256:444"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "no-container-name",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "dev", ImageID: "docker-pullable://okteto/golang@sha256:222"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "not-started",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "dev", ImageID: ""},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "container-not-found",
+			container: "dev",
+			statuses: []apiv1.ContainerStatus{
+				{Name: "other", ImageID: "docker-pullable://okteto/golang@sha256:222"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+				Status:     apiv1.PodStatus{ContainerStatuses: tt.statuses},
+			}
+			digest, err := GetImageDigest(pod, tt.container)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got digest '%s'", digest)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if digest != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, digest)
+			}
+		})
+	}
+}
+
+func TestCheckImagePullErrors(t *testing.T) {
+	var tests = []struct {
+		name      string
+		status    apiv1.PodStatus
+		expectErr bool
+	}{
+		{
+			name: "never-pull",
+			status: apiv1.PodStatus{
+				ContainerStatuses: []apiv1.ContainerStatus{
+					{
+						Name:  "dev",
+						Image: "okteto/golang:1",
+						State: apiv1.ContainerState{
+							Waiting: &apiv1.ContainerStateWaiting{Reason: "ErrImageNeverPull"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "init-container-backoff",
+			status: apiv1.PodStatus{
+				InitContainerStatuses: []apiv1.ContainerStatus{
+					{
+						Name:  "okteto-bin",
+						Image: "okteto/bin:1",
+						State: apiv1.ContainerState{
+							Waiting: &apiv1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "creating",
+			status: apiv1.PodStatus{
+				ContainerStatuses: []apiv1.ContainerStatus{
+					{
+						Name:  "dev",
+						Image: "okteto/golang:1",
+						State: apiv1.ContainerState{
+							Waiting: &apiv1.ContainerStateWaiting{Reason: "ContainerCreating"},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckImagePullErrors(&apiv1.Pod{Status: tt.status})
+			if !tt.expectErr {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if _, ok := err.(errors.UserError); !ok {
+				t.Errorf("expected a user error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckMissingStartScript(t *testing.T) {
+	startError := `failed to create containerd task: OCI runtime create failed: container_linux.go:367: starting container process caused: exec: "/var/okteto/bin/start.sh": stat /var/okteto/bin/start.sh: no such file or directory: unknown`
+	var tests = []struct {
+		name      string
+		status    apiv1.ContainerStatus
+		expectErr bool
+	}{
+		{
+			name: "start-error",
+			status: apiv1.ContainerStatus{
+				Name: "dev",
+				State: apiv1.ContainerState{
+					Terminated: &apiv1.ContainerStateTerminated{Reason: "StartError", ExitCode: 128, Message: startError},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "crash-loop",
+			status: apiv1.ContainerStatus{
+				Name: "dev",
+				State: apiv1.ContainerState{
+					Waiting: &apiv1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 10s restarting failed container"},
+				},
+				LastTerminationState: apiv1.ContainerState{
+					Terminated: &apiv1.ContainerStateTerminated{Reason: "ContainerCannotRun", ExitCode: 127, Message: startError},
+				},
+				RestartCount: 2,
+			},
+			expectErr: true,
+		},
+		{
+			name: "other-container",
+			status: apiv1.ContainerStatus{
+				Name: "sidecar",
+				State: apiv1.ContainerState{
+					Terminated: &apiv1.ContainerStateTerminated{Reason: "StartError", ExitCode: 128, Message: startError},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "other-error",
+			status: apiv1.ContainerStatus{
+				Name: "dev",
+				State: apiv1.ContainerState{
+					Terminated: &apiv1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, Message: "/var/okteto/bin/start.sh: syncthing exited"},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "running",
+			status: apiv1.ContainerStatus{
+				Name:  "dev",
+				State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &apiv1.Pod{Status: apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{tt.status}}}
+			err := CheckMissingStartScript(pod, "dev")
+			if !tt.expectErr {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if _, ok := err.(errors.UserError); !ok {
+				t.Errorf("expected a user error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckOOMKilled(t *testing.T) {
+	oomKilled := &apiv1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}
+	var tests = []struct {
+		name      string
+		status    apiv1.ContainerStatus
+		restarts  int32
+		expectErr bool
+	}{
+		{
+			name: "restarted-after-oomkilled",
+			status: apiv1.ContainerStatus{
+				Name:                 "dev",
+				RestartCount:         1,
+				State:                apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+				LastTerminationState: apiv1.ContainerState{Terminated: oomKilled},
+			},
+			restarts:  0,
+			expectErr: true,
+		},
+		{
+			name: "oomkilled",
+			status: apiv1.ContainerStatus{
+				Name:  "dev",
+				State: apiv1.ContainerState{Terminated: oomKilled},
+			},
+			restarts:  0,
+			expectErr: true,
+		},
+		{
+			name: "oomkilled-before-up",
+			status: apiv1.ContainerStatus{
+				Name:                 "dev",
+				RestartCount:         1,
+				State:                apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+				LastTerminationState: apiv1.ContainerState{Terminated: oomKilled},
+			},
+			restarts:  1,
+			expectErr: false,
+		},
+		{
+			name: "restarted-after-error",
+			status: apiv1.ContainerStatus{
+				Name:                 "dev",
+				RestartCount:         1,
+				State:                apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+				LastTerminationState: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			},
+			restarts:  0,
+			expectErr: false,
+		},
+		{
+			name: "other-container",
+			status: apiv1.ContainerStatus{
+				Name:  "sidecar",
+				State: apiv1.ContainerState{Terminated: oomKilled},
+			},
+			restarts:  0,
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &apiv1.Pod{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{
+						{
+							Name: "dev",
+							Resources: apiv1.ResourceRequirements{
+								Limits: apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("1Gi")},
+							},
+						},
+					},
+				},
+				Status: apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{tt.status}},
+			}
+			err := CheckOOMKilled(pod, "dev", tt.restarts)
+			if !tt.expectErr {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			uErr, ok := err.(errors.UserError)
+			if !ok {
+				t.Fatalf("expected a user error, got %v", err)
+			}
+			if !strings.Contains(uErr.E.Error(), "OOMKilled") {
+				t.Errorf("expected the termination reason in the error, got '%s'", uErr.E.Error())
+			}
+			if !strings.Contains(uErr.Hint, "1Gi") {
+				t.Errorf("expected the memory limit in the hint, got '%s'", uErr.Hint)
+			}
+		})
+	}
+}
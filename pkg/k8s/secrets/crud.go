@@ -15,6 +15,8 @@ package secrets
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"strings"
@@ -32,7 +34,7 @@ const (
 )
 
 // Get returns the value of a secret
-func Get(ctx context.Context, name, namespace string, c *kubernetes.Clientset) (*v1.Secret, error) {
+func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*v1.Secret, error) {
 	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return secret, fmt.Errorf("Error getting kubernetes secret: %s", err)
@@ -40,8 +42,8 @@ func Get(ctx context.Context, name, namespace string, c *kubernetes.Clientset) (
 	return secret, nil
 }
 
-// Create creates the syncthing config secret
-func Create(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset, s *syncthing.Syncthing) error {
+// Create creates the syncthing config secret, or updates it if it already exists
+func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *syncthing.Syncthing) error {
 	secretName := GetSecretName(dev)
 
 	sct, err := Get(ctx, secretName, dev.Namespace, c)
@@ -55,7 +57,8 @@ func Create(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset, s *syn
 	}
 	data := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: secretName,
+			Name:      secretName,
+			Namespace: dev.Namespace,
 			Labels: map[string]string{
 				model.DevLabel: "true",
 			},
@@ -77,20 +80,22 @@ func Create(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset, s *syn
 		data.Data[s.GetKeyName()] = content
 	}
 
-	if sct.Name == "" {
+	if sct == nil || sct.Name == "" {
 		_, err := c.CoreV1().Secrets(dev.Namespace).Create(ctx, data, metav1.CreateOptions{})
-		if err != nil {
+		if err == nil {
+			log.Infof("created okteto secret '%s'", secretName)
+			return nil
+		}
+		if !strings.Contains(err.Error(), "already exists") {
 			return fmt.Errorf("error creating kubernetes sync secret: %s", err)
 		}
+		log.Infof("okteto secret '%s' already exists, updating it", secretName)
+	}
 
-		log.Infof("created okteto secret '%s'", secretName)
-	} else {
-		_, err := c.CoreV1().Secrets(dev.Namespace).Update(ctx, data, metav1.UpdateOptions{})
-		if err != nil {
-			return fmt.Errorf("error updating kubernetes okteto secret: %s", err)
-		}
-		log.Infof("updated okteto secret '%s'", secretName)
+	if _, err := c.CoreV1().Secrets(dev.Namespace).Update(ctx, data, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating kubernetes okteto secret: %s", err)
 	}
+	log.Infof("updated okteto secret '%s'", secretName)
 	return nil
 }
 
@@ -107,6 +112,79 @@ func Destroy(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error
 	return nil
 }
 
+// CreatePullSecret creates the docker registry secret declared by the 'pullSecret' field of the okteto manifest, or updates it if it already exists
+func CreatePullSecret(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	if dev.PullSecret == nil {
+		return nil
+	}
+
+	username, password, err := dev.PullSecret.GetCredentials()
+	if err != nil {
+		return err
+	}
+
+	config, err := getDockerConfigJSON(dev.PullSecret.Registry, username, password)
+	if err != nil {
+		return fmt.Errorf("error generating the pull secret: %s", err)
+	}
+
+	secretName := dev.GetPullSecretName()
+	data := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: dev.Namespace,
+			Labels: map[string]string{
+				model.DevLabel: "true",
+			},
+		},
+		Type: v1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			v1.DockerConfigJsonKey: config,
+		},
+	}
+
+	_, err = c.CoreV1().Secrets(dev.Namespace).Create(ctx, data, metav1.CreateOptions{})
+	if err == nil {
+		log.Infof("created pull secret '%s'", secretName)
+		return nil
+	}
+	if !strings.Contains(err.Error(), "already exists") {
+		return fmt.Errorf("error creating kubernetes pull secret: %s", err)
+	}
+
+	if _, err := c.CoreV1().Secrets(dev.Namespace).Update(ctx, data, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating kubernetes pull secret: %s", err)
+	}
+	log.Infof("updated pull secret '%s'", secretName)
+	return nil
+}
+
+// DestroyPullSecret deletes the docker registry secret of the development container
+func DestroyPullSecret(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	err := c.CoreV1().Secrets(dev.Namespace).Delete(ctx, dev.GetPullSecretName(), metav1.DeleteOptions{})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil
+		}
+		return fmt.Errorf("error deleting kubernetes pull secret: %s", err)
+	}
+	return nil
+}
+
+func getDockerConfigJSON(registry, username, password string) ([]byte, error) {
+	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password)))
+	config := map[string]interface{}{
+		"auths": map[string]interface{}{
+			registry: map[string]string{
+				"username": username,
+				"password": password,
+				"auth":     auth,
+			},
+		},
+	}
+	return json.Marshal(config)
+}
+
 // GetSecretName returns the okteto secret name for a given development container
 func GetSecretName(dev *model.Dev) string {
 	return fmt.Sprintf(oktetoSecretTemplate, dev.Name)
@@ -0,0 +1,218 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateUpdatesExistingSecret(t *testing.T) {
+	ctx := context.Background()
+	dev := &model.Dev{Name: "dev", Namespace: "test"}
+	stale := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetSecretName(dev),
+			Namespace: dev.Namespace,
+		},
+		Data: map[string][]byte{
+			"config.xml": []byte("<password>stale-hash</password>"),
+		},
+	}
+	clientset := fake.NewSimpleClientset(stale)
+
+	sy := &syncthing.Syncthing{
+		APIKey:          "apikey",
+		GUIPasswordHash: "current-hash",
+		RescanInterval:  "300",
+		Compression:     "true",
+	}
+	if err := Create(ctx, dev, clientset, sy); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := Get(ctx, GetSecretName(dev), dev.Namespace, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := string(updated.Data["config.xml"])
+	if strings.Contains(config, "stale-hash") {
+		t.Errorf("secret still contains the stale password hash")
+	}
+	if !strings.Contains(config, "current-hash") {
+		t.Errorf("secret doesn't contain the current password hash")
+	}
+	if updated.Labels[model.DevLabel] != "true" {
+		t.Errorf("secret is missing the '%s' label", model.DevLabel)
+	}
+}
+
+func TestCreateNewSecret(t *testing.T) {
+	ctx := context.Background()
+	dev := &model.Dev{Name: "dev", Namespace: "test"}
+	clientset := fake.NewSimpleClientset()
+
+	sy := &syncthing.Syncthing{
+		APIKey:          "apikey",
+		GUIPasswordHash: "current-hash",
+	}
+	if err := Create(ctx, dev, clientset, sy); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := Get(ctx, GetSecretName(dev), dev.Namespace, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Namespace != dev.Namespace {
+		t.Errorf("wrong namespace. Got %s, expected %s", created.Namespace, dev.Namespace)
+	}
+}
+
+func TestCreatePullSecret(t *testing.T) {
+	ctx := context.Background()
+	os.Setenv("TEST_REGISTRY_USERNAME", "user")
+	defer os.Unsetenv("TEST_REGISTRY_USERNAME")
+	os.Setenv("TEST_REGISTRY_PASSWORD", "pass")
+	defer os.Unsetenv("TEST_REGISTRY_PASSWORD")
+
+	dev := &model.Dev{
+		Name:      "dev",
+		Namespace: "test",
+		PullSecret: &model.PullSecret{
+			Registry:    "registry.example.com",
+			UsernameEnv: "TEST_REGISTRY_USERNAME",
+			PasswordEnv: "TEST_REGISTRY_PASSWORD",
+		},
+	}
+	clientset := fake.NewSimpleClientset()
+	if err := CreatePullSecret(ctx, dev, clientset); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := Get(ctx, "okteto-pull-dev", dev.Namespace, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Type != v1.SecretTypeDockerConfigJson {
+		t.Errorf("wrong secret type. Got %s, expected %s", created.Type, v1.SecretTypeDockerConfigJson)
+	}
+
+	config := struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(created.Data[v1.DockerConfigJsonKey], &config); err != nil {
+		t.Fatal(err)
+	}
+	auth, ok := config.Auths["registry.example.com"]
+	if !ok {
+		t.Fatalf("registry missing in the dockerconfigjson: %s", string(created.Data[v1.DockerConfigJsonKey]))
+	}
+	if auth.Username != "user" || auth.Password != "pass" || auth.Auth != base64.StdEncoding.EncodeToString([]byte("user:pass")) {
+		t.Errorf("wrong credentials in the dockerconfigjson: %+v", auth)
+	}
+
+	os.Setenv("TEST_REGISTRY_PASSWORD", "new-pass")
+	if err := CreatePullSecret(ctx, dev, clientset); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := Get(ctx, "okteto-pull-dev", dev.Namespace, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(updated.Data[v1.DockerConfigJsonKey]), "new-pass") {
+		t.Errorf("pull secret wasn't updated")
+	}
+
+	if err := DestroyPullSecret(ctx, dev, clientset); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Get(ctx, "okteto-pull-dev", dev.Namespace, clientset); err == nil {
+		t.Errorf("pull secret wasn't deleted")
+	}
+}
+
+func TestCreatePullSecretWithoutCredentials(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "dev",
+		Namespace: "test",
+		PullSecret: &model.PullSecret{
+			Registry:    "registry.example.com",
+			UsernameEnv: "TEST_UNDEFINED_USERNAME",
+			PasswordEnv: "TEST_UNDEFINED_PASSWORD",
+		},
+	}
+	if err := CreatePullSecret(context.Background(), dev, fake.NewSimpleClientset()); err == nil {
+		t.Errorf("expected error when the credentials are not defined")
+	}
+}
+
+func TestCreateUpdatesGUIPasswordHash(t *testing.T) {
+	ctx := context.Background()
+	dev := &model.Dev{Name: "dev", Namespace: "test"}
+	clientset := fake.NewSimpleClientset()
+
+	sy := &syncthing.Syncthing{
+		APIKey:          "apikey",
+		RescanInterval:  "300",
+		Compression:     "true",
+		GUIPassword:     "previous",
+		GUIPasswordHash: "previous-hash",
+	}
+	if err := Create(ctx, dev, clientset, sy); err != nil {
+		t.Fatal(err)
+	}
+
+	previous, err := Get(ctx, GetSecretName(dev), dev.Namespace, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previousHash := sy.GUIPasswordHash
+
+	sy.GUIPassword, sy.GUIPasswordHash = "new", "new-hash"
+	if err := Create(ctx, dev, clientset, sy); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := Get(ctx, GetSecretName(dev), dev.Namespace, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(previous.Data["config.xml"]) == string(updated.Data["config.xml"]) {
+		t.Errorf("secret wasn't updated after changing the password")
+	}
+	config := string(updated.Data["config.xml"])
+	if strings.Contains(config, previousHash) {
+		t.Errorf("secret still contains the previous password hash")
+	}
+	if !strings.Contains(config, sy.GUIPasswordHash) {
+		t.Errorf("secret doesn't contain the new password hash")
+	}
+}
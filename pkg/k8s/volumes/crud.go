@@ -16,7 +16,7 @@ package volumes
 import (
 	"context"
 	"fmt"
-	"strings"
+	"reflect"
 	"time"
 
 	"github.com/google/uuid"
@@ -45,34 +45,114 @@ func List(ctx context.Context, namespace, labels string, c kubernetes.Interface)
 	return vList.Items, nil
 }
 
+// terminatingPollInterval is how often a terminating volume claim is checked until it's deleted
+var terminatingPollInterval = 1 * time.Second
+
 // CreateForDev deploys the volume claim for a given development container
-func CreateForDev(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset) error {
+func CreateForDev(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
 	vClient := c.CoreV1().PersistentVolumeClaims(dev.Namespace)
 	pvc := translate(dev)
-	k8Volume, err := vClient.Get(ctx, pvc.Name, metav1.GetOptions{})
-	if err != nil && !strings.Contains(err.Error(), "not found") {
-		return fmt.Errorf("error getting kubernetes volume claim: %s", err)
+	k8Volume, err := getActive(ctx, pvc.Name, dev, c)
+	if err != nil {
+		return err
 	}
-	if k8Volume.Name == "" {
+	if k8Volume == nil {
 		log.Infof("creating volume claim '%s'", pvc.Name)
 		_, err = vClient.Create(ctx, pvc, metav1.CreateOptions{})
-		if err != nil {
+		if err == nil {
+			return nil
+		}
+		if !errors.IsAlreadyExists(err) {
 			return fmt.Errorf("error creating kubernetes volume claim: %s", err)
 		}
-	} else {
-		if err := checkPVCValues(pvc, dev); err != nil {
+
+		log.Infof("volume claim '%s' already exists", pvc.Name)
+		k8Volume, err = getActive(ctx, pvc.Name, dev, c)
+		if err != nil {
 			return err
 		}
-		log.Infof("updating volume claim '%s'", pvc.Name)
-		if pvc.Spec.StorageClassName == nil {
-			pvc.Spec.StorageClassName = k8Volume.Spec.StorageClassName
+		if k8Volume == nil {
+			_, err = vClient.Create(ctx, pvc, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("error creating kubernetes volume claim: %s", err)
+			}
+			return nil
 		}
-		pvc.Spec.VolumeName = k8Volume.Spec.VolumeName
-		_, err = vClient.Update(ctx, pvc, metav1.UpdateOptions{})
+		return checkExistingPVC(k8Volume, pvc)
+	}
+
+	if err := checkPVCValues(pvc, dev); err != nil {
+		return err
+	}
+	log.Infof("updating volume claim '%s'", pvc.Name)
+	if pvc.Spec.StorageClassName == nil {
+		pvc.Spec.StorageClassName = k8Volume.Spec.StorageClassName
+	}
+	pvc.Spec.VolumeName = k8Volume.Spec.VolumeName
+	_, err = vClient.Update(ctx, pvc, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("error updating kubernetes volume claim: %s", err)
+	}
+	return nil
+}
+
+// getActive returns the volume claim, or nil if it doesn't exist.
+// It waits for the volume claim of a previous session to be deleted when it's terminating
+func getActive(ctx context.Context, name string, dev *model.Dev, c kubernetes.Interface) (*apiv1.PersistentVolumeClaim, error) {
+	vClient := c.CoreV1().PersistentVolumeClaims(dev.Namespace)
+	ticker := time.NewTicker(terminatingPollInterval)
+	defer ticker.Stop()
+	to := time.Now().Add(dev.Timeout.Default)
+
+	for {
+		k8Volume, err := vClient.Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
-			return fmt.Errorf("error updating kubernetes volume claim: %s", err)
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("error getting kubernetes volume claim: %s", err)
+		}
+		if k8Volume.DeletionTimestamp == nil {
+			return k8Volume, nil
+		}
+
+		if time.Now().After(to) {
+			return nil, fmt.Errorf("volume claim '%s' is still being deleted after %s, please try again", name, dev.Timeout.Default.String())
+		}
+		log.Infof("waiting for volume claim '%s' to be deleted", name)
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			log.Info("call to volumes.getActive canceled")
+			return nil, ctx.Err()
 		}
 	}
+}
+
+// checkExistingPVC checks that a volume claim created by a previous session has the size and access modes of the desired one
+func checkExistingPVC(existing, desired *apiv1.PersistentVolumeClaim) error {
+	existingSize := existing.Spec.Resources.Requests["storage"]
+	desiredSize := desired.Spec.Resources.Requests["storage"]
+	if existingSize.Cmp(desiredSize) != 0 {
+		return fmt.Errorf(
+			"okteto volume '%s' already exists with size '%s' instead of '%s'. Run 'okteto down -v' and try again",
+			existing.Name,
+			existingSize.String(),
+			desiredSize.String(),
+		)
+	}
+
+	if !reflect.DeepEqual(existing.Spec.AccessModes, desired.Spec.AccessModes) {
+		return fmt.Errorf(
+			"okteto volume '%s' already exists with access modes %v instead of %v. Run 'okteto down -v' and try again",
+			existing.Name,
+			existing.Spec.AccessModes,
+			desired.Spec.AccessModes,
+		)
+	}
+
 	return nil
 }
 
@@ -144,7 +224,7 @@ func DestroyDev(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset) er
 }
 
 // Destroy destroys a persistent volume claim
-func Destroy(ctx context.Context, name, namespace string, c *kubernetes.Clientset, timeout time.Duration) error {
+func Destroy(ctx context.Context, name, namespace string, c kubernetes.Interface, timeout time.Duration) error {
 	vClient := c.CoreV1().PersistentVolumeClaims(namespace)
 	log.Infof("destroying volume '%s'", name)
 
@@ -185,7 +265,7 @@ func Destroy(ctx context.Context, name, namespace string, c *kubernetes.Clientse
 
 }
 
-func checkIfAttached(ctx context.Context, name, namespace string, c *kubernetes.Clientset) error {
+func checkIfAttached(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
 	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		log.Infof("failed to get available pods: %s", err)
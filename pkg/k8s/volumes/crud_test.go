@@ -14,11 +14,19 @@
 package volumes
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/okteto/okteto/pkg/model"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
 )
 
 func Test_checkPVCValues(t *testing.T) {
@@ -160,3 +168,109 @@ func Test_checkPVCValues(t *testing.T) {
 		})
 	}
 }
+
+func newTestVolumeDev(size string) *model.Dev {
+	return &model.Dev{
+		Name:                 "dev",
+		Namespace:            "test",
+		PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: true, Size: size},
+		Timeout:              model.Timeout{Default: 5 * time.Second},
+	}
+}
+
+func newTestPVC(dev *model.Dev, size string, accessMode apiv1.PersistentVolumeAccessMode) *apiv1.PersistentVolumeClaim {
+	return &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: dev.GetVolumeName(), Namespace: dev.Namespace},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			AccessModes: []apiv1.PersistentVolumeAccessMode{accessMode},
+			Resources: apiv1.ResourceRequirements{
+				Requests: apiv1.ResourceList{
+					"storage": resource.MustParse(size),
+				},
+			},
+		},
+	}
+}
+
+// hideOnFirstGet simulates a volume claim created by a previous session after 'okteto up' checked it
+func hideOnFirstGet(c *fake.Clientset) {
+	hidden := false
+	c.Fake.PrependReactor("get", "persistentvolumeclaims", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if hidden {
+			return false, nil, nil
+		}
+		hidden = true
+		return true, nil, fmt.Errorf("persistentvolumeclaims \"%s\" not found", action.(k8sTesting.GetAction).GetName())
+	})
+}
+
+func TestCreateForDevAlreadyExistsCompatible(t *testing.T) {
+	dev := newTestVolumeDev("10Gi")
+	c := fake.NewSimpleClientset(newTestPVC(dev, "10Gi", apiv1.ReadWriteOnce))
+	hideOnFirstGet(c)
+
+	if err := CreateForDev(context.Background(), dev, c); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestCreateForDevAlreadyExistsIncompatible(t *testing.T) {
+	var tests = []struct {
+		name       string
+		size       string
+		accessMode apiv1.PersistentVolumeAccessMode
+		expected   string
+	}{
+		{name: "size", size: "5Gi", accessMode: apiv1.ReadWriteOnce, expected: "size '5Gi' instead of '10Gi'"},
+		{name: "access-mode", size: "10Gi", accessMode: apiv1.ReadWriteMany, expected: "access modes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := newTestVolumeDev("10Gi")
+			c := fake.NewSimpleClientset(newTestPVC(dev, tt.size, tt.accessMode))
+			hideOnFirstGet(c)
+
+			err := CreateForDev(context.Background(), dev, c)
+			if err == nil {
+				t.Fatal("didn't get an error for an incompatible volume claim")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("got error '%s', expected it to contain '%s'", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateForDevWaitsForTerminating(t *testing.T) {
+	terminatingPollInterval = 10 * time.Millisecond
+	defer func() { terminatingPollInterval = 1 * time.Second }()
+
+	dev := newTestVolumeDev("10Gi")
+	terminating := newTestPVC(dev, "5Gi", apiv1.ReadWriteOnce)
+	now := metav1.Now()
+	terminating.DeletionTimestamp = &now
+	c := fake.NewSimpleClientset(terminating)
+
+	ctx := context.Background()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		if err := c.CoreV1().PersistentVolumeClaims(dev.Namespace).Delete(ctx, terminating.Name, metav1.DeleteOptions{}); err != nil {
+			t.Errorf("failed to delete the volume claim: %s", err)
+		}
+	}()
+
+	if err := CreateForDev(ctx, dev, c); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	pvc, err := c.CoreV1().PersistentVolumeClaims(dev.Namespace).Get(ctx, dev.GetVolumeName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pvc.DeletionTimestamp != nil {
+		t.Errorf("the volume claim wasn't recreated")
+	}
+	if size := pvc.Spec.Resources.Requests["storage"]; size.String() != "10Gi" {
+		t.Errorf("got size '%s', expected '10Gi'", size.String())
+	}
+}
@@ -297,6 +297,14 @@ func GetSupportedLanguages() []string {
 	return l
 }
 
+// IsSupportedLanguage returns true if the language has default values
+func IsSupportedLanguage(language string) bool {
+	if strings.ToLower(language) == Unrecognized {
+		return true
+	}
+	return NormalizeLanguage(language) != Unrecognized
+}
+
 // GetDevDefaults gets default values for the specified language
 func GetDevDefaults(language, workdir string) (*model.Dev, error) {
 	language = NormalizeLanguage(language)
@@ -92,6 +92,21 @@ const (
 	Localhost                   = "localhost"
 	oktetoSSHServerPortVariable = "OKTETO_REMOTE_PORT"
 	oktetoDefaultSSHServerPort  = 2222
+	// SyncthingClusterPort is the port used by syncthing in the development container
+	SyncthingClusterPort = 22000
+	// SyncthingGUIPort is the port used by syncthing in the development container for the http endpoint
+	SyncthingGUIPort = 8384
+	// DinDContainerName is the name of the docker container
+	DinDContainerName = "dind"
+	// dindPort is the port of the docker container
+	dindPort = 2376
+	// minServiceAccountTokenExpiration and maxServiceAccountTokenExpiration are the bounds of the expiration of projected service account tokens
+	minServiceAccountTokenExpiration int64 = 600
+	maxServiceAccountTokenExpiration int64 = 1 << 32
+	// oktetoFolder is the folder of the development container where okteto mounts its binaries, secrets and remote files
+	oktetoFolder = "/var/okteto"
+	// reservedStartFlags are the flags of the start script set by okteto
+	reservedStartFlags = "revsd"
 	//OktetoDefaultPVSize default volume size
 	OktetoDefaultPVSize = "2Gi"
 	//OktetoUpCmd up command
@@ -109,6 +124,8 @@ const (
 	DeprecatedOktetoVolumeName = "okteto"
 	//OktetoVolumeNameTemplate name template of the development container persistent volume
 	OktetoVolumeNameTemplate = "okteto-%s"
+	//OktetoPullSecretTemplate name template of the development container pull secret
+	OktetoPullSecretTemplate = "okteto-pull-%s"
 	//DataSubPath subpath in the development container persistent volume for the data volumes
 	DataSubPath = "data"
 	//SourceCodeSubPath subpath in the development container persistent volume for the source code
@@ -117,16 +134,27 @@ const (
 	OktetoSyncthingMountPath = "/var/syncthing"
 	//RemoteMountPath remote volume mount path
 	RemoteMountPath = "/var/okteto/remote"
+	//OktetoStartScript is the command of the development container, copied by the okteto-bin init container
+	OktetoStartScript = "/var/okteto/bin/start.sh"
 	//SyncthingSubPath subpath in the development container persistent volume for the syncthing data
 	SyncthingSubPath = "syncthing"
 	//DefaultSyncthingRescanInterval default syncthing re-scan interval
 	DefaultSyncthingRescanInterval = 300
+
+	//DefaultSyncthingReconnectionInterval default syncthing device reconnection interval
+	DefaultSyncthingReconnectionInterval = 1
+	//SyncModeSendReceive syncs changes in both directions once the initial sync is completed
+	SyncModeSendReceive = "sendreceive"
+	//SyncModeSendOnly only sends local changes to the development container
+	SyncModeSendOnly = "sendonly"
 	//RemoteSubPath subpath in the development container persistent volume for the remote data
 	RemoteSubPath = "okteto-remote"
 	//OktetoURLAnnotation indicates the okteto cluster public url
 	OktetoURLAnnotation = "dev.okteto.com/url"
 	//OktetoAutoCreateAnnotation indicates if the deployment was auto generatted by okteto up
 	OktetoAutoCreateAnnotation = "dev.okteto.com/auto-create"
+	//OktetoDevHashAnnotation stores the hash of the okteto manifest that activated the development container
+	OktetoDevHashAnnotation = "dev.okteto.com/manifest-hash"
 	//OktetoRestartAnnotation indicates the dev pod must be recreated to pull the latest version of its image
 	OktetoRestartAnnotation = "dev.okteto.com/restart"
 	//OktetoStignoreAnnotation indicates the hash of the stignore files to force redeployment
@@ -14,13 +14,17 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
@@ -35,6 +39,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 var (
@@ -46,6 +51,12 @@ var (
 	// ValidKubeNameRegex is the regex to validate a kubernetes resource name
 	ValidKubeNameRegex = regexp.MustCompile(`[^a-z0-9\-]+`)
 
+	// validImageReferenceRegex is the regex to validate an image reference: [registry[:port]/]repository[:tag][@digest]
+	validImageReferenceRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*(:[0-9]+)?/)?[a-z0-9]+([._-]+[a-z0-9]+)*(/[a-z0-9]+([._-]+[a-z0-9]+)*)*(:[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+
+	// envVarRegex matches the references to environment variables: $VAR, ${VAR} or ${VAR<modifier>}
+	envVarRegex = regexp.MustCompile(`\$(?:\{([a-zA-Z_][a-zA-Z0-9_]*)([^}]*)\}|([a-zA-Z_][a-zA-Z0-9_]*))`)
+
 	rootUser int64
 
 	// DevReplicas is the number of dev replicas
@@ -64,40 +75,55 @@ type Dev struct {
 	Autocreate           bool                  `json:"autocreate,omitempty" yaml:"autocreate,omitempty"`
 	Labels               Labels                `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Annotations          Annotations           `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	PodLabels            Labels                `json:"podLabels,omitempty" yaml:"podLabels,omitempty"`
+	PodAnnotations       Annotations           `json:"-" yaml:"-"`
 	Tolerations          []apiv1.Toleration    `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
 	Context              string                `json:"context,omitempty" yaml:"context,omitempty"`
 	Namespace            string                `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 	Container            string                `json:"container,omitempty" yaml:"container,omitempty"`
+	ManifestContainer    string                `json:"-" yaml:"-"`
 	EmptyImage           bool                  `json:"-" yaml:"-"`
 	Image                *BuildInfo            `json:"image,omitempty" yaml:"image,omitempty"`
 	Push                 *BuildInfo            `json:"-" yaml:"push,omitempty"`
 	ImagePullPolicy      apiv1.PullPolicy      `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	PullSecret           *PullSecret           `json:"pullSecret,omitempty" yaml:"pullSecret,omitempty"`
 	Environment          Environment           `json:"environment,omitempty" yaml:"environment,omitempty"`
+	InheritEnv           bool                  `json:"inheritEnv,omitempty" yaml:"inheritEnv,omitempty"`
 	Secrets              []Secret              `json:"secrets,omitempty" yaml:"secrets,omitempty"`
 	Command              Command               `json:"command,omitempty" yaml:"command,omitempty"`
+	CommandWrapper       []string              `json:"commandWrapper,omitempty" yaml:"commandWrapper,omitempty"`
 	Healthchecks         bool                  `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"`
 	Probes               *Probes               `json:"probes,omitempty" yaml:"probes,omitempty"`
 	Lifecycle            *Lifecycle            `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
 	Workdir              string                `json:"workdir,omitempty" yaml:"workdir,omitempty"`
 	SecurityContext      *SecurityContext      `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
 	ServiceAccount       string                `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+	ServiceAccountTokens []ServiceAccountToken `json:"serviceAccountTokens,omitempty" yaml:"serviceAccountTokens,omitempty"`
 	RemotePort           int                   `json:"remote,omitempty" yaml:"remote,omitempty"`
 	SSHServerPort        int                   `json:"sshServerPort,omitempty" yaml:"sshServerPort,omitempty"`
+	StartArgs            []string              `json:"startArgs,omitempty" yaml:"startArgs,omitempty"`
 	Volumes              []Volume              `json:"volumes,omitempty" yaml:"volumes,omitempty"`
 	ExternalVolumes      []ExternalVolume      `json:"externalVolumes,omitempty" yaml:"externalVolumes,omitempty"`
 	Sync                 Sync                  `json:"sync,omitempty" yaml:"sync,omitempty"`
 	parentSyncFolder     string                `json:"-" yaml:"-"`
+	manifestHash         string                `json:"-" yaml:"-"`
+	manifestKey          string                `json:"-" yaml:"-"`
+	localEnv             map[string]*string    `json:"-" yaml:"-"`
 	Forward              []Forward             `json:"forward,omitempty" yaml:"forward,omitempty"`
 	Reverse              []Reverse             `json:"reverse,omitempty" yaml:"reverse,omitempty"`
 	Interface            string                `json:"interface,omitempty" yaml:"interface,omitempty"`
 	Resources            ResourceRequirements  `json:"resources,omitempty" yaml:"resources,omitempty"`
 	Services             []*Dev                `json:"services,omitempty" yaml:"services,omitempty"`
+	Replicas             *int32                `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	PersistentVolumeInfo *PersistentVolumeInfo `json:"persistentVolume,omitempty" yaml:"persistentVolume,omitempty"`
 	InitContainer        InitContainer         `json:"initContainer,omitempty" yaml:"initContainer,omitempty"`
 	InitFromImage        bool                  `json:"initFromImage,omitempty" yaml:"initFromImage,omitempty"`
+	MountFromImage       *MountFromImage       `json:"mountFromImage,omitempty" yaml:"mountFromImage,omitempty"`
 	Timeout              Timeout               `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	Docker               DinDContainer         `json:"docker,omitempty" yaml:"docker,omitempty"`
+	Sidecars             []Sidecar             `json:"sidecars,omitempty" yaml:"sidecars,omitempty"`
 	Divert               *Divert               `json:"divert,omitempty" yaml:"divert,omitempty"`
+	Warnings             []string              `json:"-" yaml:"-"`
 }
 
 // Entrypoint represents the start command of a development container
@@ -133,12 +159,15 @@ type Volume struct {
 
 // Sync represents a sync info in the development container
 type Sync struct {
-	Compression    bool         `json:"compression" yaml:"compression"`
-	Verbose        bool         `json:"verbose" yaml:"verbose"`
-	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
-	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
-	LocalPath      string
-	RemotePath     string
+	Compression       bool         `json:"compression" yaml:"compression"`
+	Verbose           bool         `json:"verbose" yaml:"verbose"`
+	RescanInterval    int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	ReconnectInterval int          `json:"reconnectIntervalS,omitempty" yaml:"reconnectIntervalS,omitempty"`
+	UseGitignore      bool         `json:"useGitignore,omitempty" yaml:"useGitignore,omitempty"`
+	Mode              string       `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Folders           []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	LocalPath         string
+	RemotePath        string
 }
 
 // SyncFolder represents a sync folder in the development container
@@ -152,6 +181,7 @@ type ExternalVolume struct {
 	Name      string
 	SubPath   string
 	MountPath string
+	ReadOnly  bool
 }
 
 // PersistentVolumeInfo info about the persistent volume
@@ -163,8 +193,22 @@ type PersistentVolumeInfo struct {
 
 // InitContainer represents the initial container
 type InitContainer struct {
+	Enabled   *bool                `json:"enabled,omitempty" yaml:"enabled,omitempty"`
 	Image     string               `json:"image,omitempty" yaml:"image,omitempty"`
 	Resources ResourceRequirements `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Exclude   []string             `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+	Refresh   []string             `json:"refresh,omitempty" yaml:"refresh,omitempty"`
+}
+
+// IsEnabled returns true unless the init container is disabled with 'initContainer.enabled: false'
+func (i *InitContainer) IsEnabled() bool {
+	return i.Enabled == nil || *i.Enabled
+}
+
+// MountFromImage represents a path of an image used to initialize a volume
+type MountFromImage struct {
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+	Path  string `json:"path,omitempty" yaml:"path,omitempty"`
 }
 
 // DinDContainer represents the DinD container
@@ -174,6 +218,22 @@ type DinDContainer struct {
 	Resources ResourceRequirements `json:"resources,omitempty" yaml:"resources,omitempty"`
 }
 
+// Sidecar represents an extra container added to the development pod
+type Sidecar struct {
+	Name        string      `json:"name" yaml:"name"`
+	Image       string      `json:"image" yaml:"image"`
+	Command     Command     `json:"command,omitempty" yaml:"command,omitempty"`
+	Environment Environment `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Ports       []int       `json:"ports,omitempty" yaml:"ports,omitempty"`
+}
+
+// ServiceAccountToken represents a projected service account token mounted in the development container
+type ServiceAccountToken struct {
+	Audience          string `json:"audience" yaml:"audience"`
+	ExpirationSeconds int64  `json:"expirationSeconds,omitempty" yaml:"expirationSeconds,omitempty"`
+	Path              string `json:"path" yaml:"path"`
+}
+
 // Timeout represents the timeout for the command
 type Timeout struct {
 	Default   time.Duration `json:"default,omitempty" yaml:"default,omitempty"`
@@ -229,10 +289,12 @@ type Probes struct {
 	Startup   bool `json:"startup,omitempty" yaml:"startup,omitempty"`
 }
 
-// Lifecycle defines the lifecycle for containers
+// Lifecycle defines the lifecycle for containers.
+// PreStop is a command run by a preStop hook before the development container is stopped
 type Lifecycle struct {
-	PostStart bool `json:"postStart,omitempty" yaml:"postStart,omitempty"`
-	PostStop  bool `json:"postStop,omitempty" yaml:"postStop,omitempty"`
+	PostStart bool    `json:"postStart,omitempty" yaml:"postStart,omitempty"`
+	PostStop  bool    `json:"postStop,omitempty" yaml:"postStop,omitempty"`
+	PreStop   Command `json:"preStop,omitempty" yaml:"preStop,omitempty"`
 }
 
 // Divert defines how to divert a given service
@@ -242,6 +304,14 @@ type Divert struct {
 	Port    int    `yaml:"port,omitempty"`
 }
 
+// PullSecret represents the credentials of a private registry.
+// The credentials are read from environment variables so they are never stored in the okteto manifest
+type PullSecret struct {
+	Registry    string `json:"registry,omitempty" yaml:"registry,omitempty"`
+	UsernameEnv string `json:"usernameEnv,omitempty" yaml:"usernameEnv,omitempty"`
+	PasswordEnv string `json:"passwordEnv,omitempty" yaml:"passwordEnv,omitempty"`
+}
+
 // ResourceList is a set of (resource name, quantity) pairs.
 type ResourceList map[apiv1.ResourceName]resource.Quantity
 
@@ -257,36 +327,65 @@ type Environment []EnvVar
 // EnvFiles is a list of environment files
 type EnvFiles []string
 
-// Get returns a Dev object from a given file
+// Get returns a Dev object from a given file.
+// It fails if the file defines several development environments
 func Get(devPath string) (*Dev, error) {
-	b, err := ioutil.ReadFile(devPath)
+	devs, err := GetDevs(devPath)
 	if err != nil {
 		return nil, err
 	}
 
-	dev, err := Read(b)
-	if err != nil {
-		return nil, err
+	if len(devs) > 1 {
+		return nil, fmt.Errorf("'%s' defines several development environments: %s", devPath, strings.Join(GetDevNames(devs), ", "))
 	}
 
-	if err := dev.translateDeprecatedVolumeFields(); err != nil {
+	for _, dev := range devs {
+		return dev, nil
+	}
+	return nil, fmt.Errorf("'%s' doesn't define any development environment", devPath)
+}
+
+// GetDevs returns the development environments defined in a given file, indexed by name
+func GetDevs(devPath string) (map[string]*Dev, error) {
+	b, err := ioutil.ReadFile(devPath)
+	if err != nil {
 		return nil, err
 	}
 
-	if err := dev.loadAbsPaths(devPath); err != nil {
+	b, err = resolveExtends(devPath, b)
+	if err != nil {
 		return nil, err
 	}
 
-	if err := dev.validate(); err != nil {
+	devs, err := ReadDevs(b)
+	if err != nil {
 		return nil, err
 	}
 
-	dev.computeParentSyncFolder()
+	for _, dev := range devs {
+		if err := dev.translateDeprecatedVolumeFields(); err != nil {
+			return nil, err
+		}
 
-	return dev, nil
+		if err := dev.loadAbsPaths(devPath); err != nil {
+			return nil, err
+		}
+
+		if err := dev.Validate(); err != nil {
+			return nil, err
+		}
+
+		dev.computeParentSyncFolder()
+
+		if err := dev.computeManifestHash(); err != nil {
+			return nil, err
+		}
+	}
+
+	return devs, nil
 }
 
-// Read reads an okteto manifests
+// Read reads an okteto manifests. Manifests extended with the 'extends' field are relative to the current folder
 func Read(bytes []byte) (*Dev, error) {
 	dev := &Dev{
 		Image:       &BuildInfo{},
@@ -306,7 +405,14 @@ func Read(bytes []byte) (*Dev, error) {
 	}
 
 	if bytes != nil {
+		bytes, err := resolveExtends("", bytes)
+		if err != nil {
+			return nil, err
+		}
 		if err := yaml.UnmarshalStrict(bytes, dev); err != nil {
+			if IsLegacyManifest(bytes) {
+				return nil, errors.New("your okteto manifest uses the legacy 'swap' and 'mount' fields, run 'okteto migrate' to convert it")
+			}
 			if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
 				var sb strings.Builder
 				_, _ = sb.WriteString("Invalid manifest:\n")
@@ -330,13 +436,14 @@ func Read(bytes []byte) (*Dev, error) {
 	if err := dev.expandEnvVars(); err != nil {
 		return nil, err
 	}
+	dev.ManifestContainer = dev.Container
 	for _, s := range dev.Services {
 		if err := s.expandEnvVars(); err != nil {
 			return nil, err
 		}
 	}
 
-	if err := dev.setDefaults(); err != nil {
+	if err := dev.Complete(); err != nil {
 		return nil, err
 	}
 
@@ -405,6 +512,12 @@ func (dev *Dev) expandEnvVars() error {
 	if err := dev.loadLabels(); err != nil {
 		return err
 	}
+	if err := dev.loadCommand(); err != nil {
+		return err
+	}
+	if err := dev.loadWorkdir(); err != nil {
+		return err
+	}
 
 	return dev.loadImage()
 }
@@ -453,6 +566,31 @@ func (dev *Dev) loadLabels() error {
 	return nil
 }
 
+func (dev *Dev) loadCommand() error {
+	for i := range dev.Command.Values {
+		value, undefined, err := expandEnv(dev.Command.Values[i])
+		if err != nil {
+			return err
+		}
+		if len(undefined) > 0 {
+			log.Warning("undefined environment variables expanded to an empty string in the command '%s': %s. Use '$$' to escape a literal '$'", dev.Command.Values[i], strings.Join(undefined, ", "))
+		}
+		dev.Command.Values[i] = value
+	}
+	return nil
+}
+
+func (dev *Dev) loadWorkdir() error {
+	var err error
+	if len(dev.Workdir) > 0 {
+		dev.Workdir, err = ExpandEnv(dev.Workdir)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (dev *Dev) loadImage() error {
 	var err error
 	if dev.Image == nil {
@@ -470,7 +608,17 @@ func (dev *Dev) loadImage() error {
 	return nil
 }
 
-func (dev *Dev) setDefaults() error {
+// Complete sets the default values of a development container
+func (dev *Dev) Complete() error {
+	if dev.Image == nil {
+		dev.Image = &BuildInfo{}
+	}
+	if dev.Push == nil {
+		dev.Push = &BuildInfo{}
+	}
+	if dev.InitContainer.Image == "" {
+		dev.InitContainer.Image = OktetoBinImageTag
+	}
 	if dev.Command.Values == nil {
 		dev.Command.Values = []string{"sh"}
 	}
@@ -491,7 +639,7 @@ func (dev *Dev) setDefaults() error {
 		dev.Annotations = Annotations{}
 	}
 	if dev.Healthchecks {
-		log.Yellow("The use of 'healthchecks' field is deprecated and will be removed in a future release. Please use the field 'probes' instead.")
+		dev.Warnings = append(dev.Warnings, "The use of 'healthchecks' field is deprecated and will be removed in a future release. Please use the field 'probes' instead.")
 		if dev.Probes == nil {
 			dev.Probes = &Probes{Liveness: true, Readiness: true, Startup: true}
 		}
@@ -519,12 +667,22 @@ func (dev *Dev) setDefaults() error {
 	} else if dev.Sync.RescanInterval == 0 {
 		dev.Sync.RescanInterval = DefaultSyncthingRescanInterval
 	}
+	if dev.Sync.ReconnectInterval == 0 {
+		dev.Sync.ReconnectInterval = DefaultSyncthingReconnectionInterval
+	}
+	if dev.Sync.Mode == "" {
+		dev.Sync.Mode = SyncModeSendReceive
+	}
 
 	if dev.Docker.Enabled && dev.Docker.Image == "" {
 		dev.Docker.Image = DefaultDinDImage
 	}
 
+	dev.Warnings = append(dev.Warnings, dev.getDeprecatedVolumeWarnings()...)
+	dev.Warnings = append(dev.Warnings, getPrivilegedPortWarnings(dev.Forward, runtime.GOOS)...)
+
 	for _, s := range dev.Services {
+		dev.Warnings = append(dev.Warnings, s.getDeprecatedVolumeWarnings()...)
 		if s.ImagePullPolicy == "" {
 			s.ImagePullPolicy = apiv1.PullAlways
 		}
@@ -546,6 +704,8 @@ func (dev *Dev) setDefaults() error {
 		s.Services = make([]*Dev, 0)
 		s.Sync.Compression = false
 		s.Sync.RescanInterval = DefaultSyncthingRescanInterval
+		s.Sync.ReconnectInterval = DefaultSyncthingReconnectionInterval
+		s.Sync.Mode = SyncModeSendReceive
 		if s.Probes == nil {
 			s.Probes = &Probes{}
 		}
@@ -601,7 +761,8 @@ func (dev *Dev) setTimeout() error {
 	return nil
 }
 
-func (dev *Dev) validate() error {
+// Validate checks that the values of a development container are valid
+func (dev *Dev) Validate() error {
 	if dev.Name == "" {
 		return fmt.Errorf("Name cannot be empty")
 	}
@@ -634,6 +795,30 @@ func (dev *Dev) validate() error {
 		return err
 	}
 
+	if err := dev.validateMountFromImage(); err != nil {
+		return err
+	}
+
+	if err := validateInitContainerExclude(dev.InitContainer.Exclude); err != nil {
+		return err
+	}
+
+	if err := dev.validateInitContainerRefresh(); err != nil {
+		return err
+	}
+
+	if err := validateSecurityContext(dev.SecurityContext); err != nil {
+		return err
+	}
+
+	if err := ValidateSyncMode(dev.Sync.Mode); err != nil {
+		return err
+	}
+
+	if dev.Sync.ReconnectInterval < 0 {
+		return fmt.Errorf("'sync.reconnectIntervalS' must be a non-negative integer")
+	}
+
 	if _, err := resource.ParseQuantity(dev.PersistentVolumeSize()); err != nil {
 		return fmt.Errorf("'persistentVolume.size' is not valid. A sample value would be '10Gi'")
 	}
@@ -642,6 +827,48 @@ func (dev *Dev) validate() error {
 		return fmt.Errorf("'sshServerPort' must be > 0")
 	}
 
+	if err := validateStartArgs(dev.StartArgs); err != nil {
+		return err
+	}
+
+	if err := validatePodLabels(dev.PodLabels); err != nil {
+		return err
+	}
+
+	if err := validatePullSecret(dev.PullSecret); err != nil {
+		return err
+	}
+
+	for i := range dev.Forward {
+		if err := dev.Forward[i].validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateSidecars(dev); err != nil {
+		return err
+	}
+
+	if err := validateServiceAccountTokens(dev); err != nil {
+		return err
+	}
+
+	if err := validateInitContainerDisabled(dev); err != nil {
+		return err
+	}
+
+	if dev.Replicas != nil {
+		return fmt.Errorf("'replicas' is only supported in services")
+	}
+
+	if dev.InheritEnv {
+		return fmt.Errorf("'inheritEnv' is only supported in services")
+	}
+
+	if err := validateCommandWrapper(dev.CommandWrapper); err != nil {
+		return err
+	}
+
 	for _, s := range dev.Services {
 		if err := validatePullPolicy(s.ImagePullPolicy); err != nil {
 			return err
@@ -649,6 +876,39 @@ func (dev *Dev) validate() error {
 		if err := s.validateVolumes(dev); err != nil {
 			return err
 		}
+		if err := validateSecurityContext(s.SecurityContext); err != nil {
+			return err
+		}
+		if s.Replicas != nil && *s.Replicas < 1 {
+			return fmt.Errorf("'replicas' must be > 0 for service '%s'", s.Name)
+		}
+		if len(s.StartArgs) > 0 {
+			return fmt.Errorf("'startArgs' is not supported in services")
+		}
+		if len(s.PodLabels) > 0 {
+			return fmt.Errorf("'podLabels' is not supported in services")
+		}
+		if s.PullSecret != nil {
+			return fmt.Errorf("'pullSecret' is not supported in services")
+		}
+		if len(s.Sidecars) > 0 {
+			return fmt.Errorf("'sidecars' is not supported in services")
+		}
+		if len(s.ServiceAccountTokens) > 0 {
+			return fmt.Errorf("'serviceAccountTokens' is not supported in services")
+		}
+		if err := validateInitContainerExclude(s.InitContainer.Exclude); err != nil {
+			return err
+		}
+		if err := validateInitContainerRefreshPaths(s.InitContainer.Refresh); err != nil {
+			return err
+		}
+		if !s.InitContainer.IsEnabled() {
+			return fmt.Errorf("'initContainer.enabled' is not supported in services")
+		}
+		if err := validateCommandWrapper(s.CommandWrapper); err != nil {
+			return err
+		}
 	}
 
 	if dev.Docker.Enabled && !dev.PersistentVolumeEnabled() {
@@ -659,6 +919,188 @@ func (dev *Dev) validate() error {
 	return nil
 }
 
+// validateCommandWrapper checks that the command wrapper isn't empty when it's set
+func validateCommandWrapper(wrapper []string) error {
+	if wrapper == nil {
+		return nil
+	}
+	if len(wrapper) == 0 {
+		return fmt.Errorf("'commandWrapper' cannot be empty")
+	}
+	for _, w := range wrapper {
+		if strings.TrimSpace(w) == "" {
+			return fmt.Errorf("'commandWrapper' cannot contain empty values")
+		}
+	}
+	return nil
+}
+
+// validateInitContainerDisabled checks that the options of the init container aren't set when it's disabled
+func validateInitContainerDisabled(dev *Dev) error {
+	if dev.InitContainer.IsEnabled() {
+		return nil
+	}
+	if len(dev.InitContainer.Exclude) > 0 {
+		return fmt.Errorf("'initContainer.exclude' is not supported when 'initContainer.enabled' is false")
+	}
+	if len(dev.InitContainer.Refresh) > 0 {
+		return fmt.Errorf("'initContainer.refresh' is not supported when 'initContainer.enabled' is false")
+	}
+	return nil
+}
+
+// validateServiceAccountTokens checks the audience, expiration and path of the projected service account tokens.
+// Each token is mounted in its own folder, so the folders of the paths can't overlap with each other,
+// with the root folder, with the okteto folders or with the synchronized folders and volumes of the development container
+func validateServiceAccountTokens(dev *Dev) error {
+	reserved := map[string]string{
+		oktetoFolder:             "the okteto folder",
+		OktetoSyncthingMountPath: "the syncthing folder",
+	}
+	for _, f := range dev.Sync.Folders {
+		reserved[path.Clean(f.RemotePath)] = "a synchronized folder"
+	}
+	for _, v := range dev.Volumes {
+		reserved[path.Clean(v.RemotePath)] = "a volume"
+	}
+	for _, v := range dev.ExternalVolumes {
+		reserved[path.Clean(v.MountPath)] = "an external volume"
+	}
+
+	folders := map[string]bool{}
+	for _, t := range dev.ServiceAccountTokens {
+		if strings.TrimSpace(t.Audience) == "" {
+			return fmt.Errorf("'serviceAccountTokens.audience' is required")
+		}
+		if strings.ContainsAny(t.Audience, " \t\n") {
+			return fmt.Errorf("'serviceAccountTokens.audience' cannot contain spaces: '%s'", t.Audience)
+		}
+		if t.ExpirationSeconds != 0 && (t.ExpirationSeconds < minServiceAccountTokenExpiration || t.ExpirationSeconds >= maxServiceAccountTokenExpiration) {
+			return fmt.Errorf("'serviceAccountTokens.expirationSeconds' of audience '%s' must be at least %d and less than %d", t.Audience, minServiceAccountTokenExpiration, maxServiceAccountTokenExpiration)
+		}
+		if !path.IsAbs(t.Path) || path.Clean(t.Path) == "/" {
+			return fmt.Errorf("'serviceAccountTokens.path' of audience '%s' must be an absolute file path", t.Audience)
+		}
+		folder := path.Dir(path.Clean(t.Path))
+		if folder == "/" {
+			return fmt.Errorf("'serviceAccountTokens.path' of audience '%s' can't be in the root folder, as its folder is replaced by the token volume", t.Audience)
+		}
+		for other := range folders {
+			if pathsOverlap(folder, other) {
+				return fmt.Errorf("'serviceAccountTokens.path' must be in different folders: '%s' overlaps with '%s'", folder, other)
+			}
+		}
+		for p, owner := range reserved {
+			if pathsOverlap(folder, p) {
+				return fmt.Errorf("the folder '%s' of 'serviceAccountTokens.path' overlaps with %s: '%s'", folder, owner, p)
+			}
+		}
+		folders[folder] = true
+	}
+	return nil
+}
+
+// pathsOverlap returns true if a and b are the same folder or one of them contains the other
+func pathsOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, strings.TrimSuffix(b, "/")+"/") || strings.HasPrefix(b, strings.TrimSuffix(a, "/")+"/")
+}
+
+// validateSidecars checks that the sidecars don't collide with the names and ports used by the development container
+func validateSidecars(dev *Dev) error {
+	names := map[string]string{DinDContainerName: "the docker container"}
+	if dev.Container != "" {
+		names[dev.Container] = "the development container"
+	}
+	ports := map[int]string{
+		dev.SSHServerPort:    "the development container",
+		SyncthingClusterPort: "the development container",
+		SyncthingGUIPort:     "the development container",
+	}
+	if dev.Docker.Enabled {
+		ports[dindPort] = "the docker container"
+	}
+	return ValidateSidecars(dev.Sidecars, names, ports)
+}
+
+// ValidateSidecars checks that the sidecars are valid and don't collide with each other nor with the given container names and ports, mapped to their owner
+func ValidateSidecars(sidecars []Sidecar, names map[string]string, ports map[int]string) error {
+	usedNames := map[string]string{}
+	for k, v := range names {
+		usedNames[k] = v
+	}
+	usedPorts := map[int]string{}
+	for k, v := range ports {
+		usedPorts[k] = v
+	}
+
+	for _, s := range sidecars {
+		if s.Name == "" {
+			return fmt.Errorf("'sidecars.name' is required")
+		}
+		if s.Image == "" {
+			return fmt.Errorf("'image' is required for sidecar '%s'", s.Name)
+		}
+		if owner, ok := usedNames[s.Name]; ok {
+			return fmt.Errorf("sidecar name '%s' is already used by %s", s.Name, owner)
+		}
+		usedNames[s.Name] = fmt.Sprintf("sidecar '%s'", s.Name)
+
+		for _, p := range s.Ports {
+			if p <= 0 || p > 65535 {
+				return fmt.Errorf("port '%d' of sidecar '%s' is not valid: it must be in the range [1, 65535]", p, s.Name)
+			}
+			if owner, ok := usedPorts[p]; ok {
+				return fmt.Errorf("port '%d' of sidecar '%s' is already used by %s", p, s.Name, owner)
+			}
+			usedPorts[p] = fmt.Sprintf("sidecar '%s'", s.Name)
+		}
+	}
+	return nil
+}
+
+// validatePodLabels checks that the pod labels don't override the labels used by okteto
+func validatePodLabels(podLabels Labels) error {
+	for key := range podLabels {
+		prefix := strings.SplitN(key, "/", 2)[0]
+		if prefix == "okteto.com" || strings.HasSuffix(prefix, ".okteto.com") {
+			return fmt.Errorf("'podLabels' cannot contain the label '%s': it is reserved by okteto", key)
+		}
+	}
+	return nil
+}
+
+// validatePullSecret checks that the pull secret defines the registry and the environment variables of its credentials
+func validatePullSecret(p *PullSecret) error {
+	if p == nil {
+		return nil
+	}
+	if p.Registry == "" {
+		return fmt.Errorf("'pullSecret.registry' cannot be empty")
+	}
+	if p.UsernameEnv == "" {
+		return fmt.Errorf("'pullSecret.usernameEnv' cannot be empty")
+	}
+	if p.PasswordEnv == "" {
+		return fmt.Errorf("'pullSecret.passwordEnv' cannot be empty")
+	}
+	return nil
+}
+
+// validateStartArgs checks that the start script arguments don't override the flags set by okteto
+func validateStartArgs(args []string) error {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") {
+			continue
+		}
+		for _, flag := range arg[1:] {
+			if strings.ContainsRune(reservedStartFlags, flag) {
+				return fmt.Errorf("'startArgs' cannot contain the flag '-%c': it is reserved by okteto", flag)
+			}
+		}
+	}
+	return nil
+}
+
 func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	switch pullPolicy {
 	case apiv1.PullAlways:
@@ -681,6 +1123,33 @@ func validateSecrets(secrets []Secret) error {
 	return nil
 }
 
+//ValidateSyncMode checks that the sync mode is supported
+func ValidateSyncMode(mode string) error {
+	switch mode {
+	case SyncModeSendReceive:
+	case SyncModeSendOnly:
+	default:
+		return fmt.Errorf("supported values for 'sync.mode' are: '%s' or '%s'", SyncModeSendReceive, SyncModeSendOnly)
+	}
+	return nil
+}
+
+func validateSecurityContext(s *SecurityContext) error {
+	if s == nil || s.Capabilities == nil {
+		return nil
+	}
+	added := map[apiv1.Capability]bool{}
+	for _, c := range s.Capabilities.Add {
+		added[c] = true
+	}
+	for _, c := range s.Capabilities.Drop {
+		if added[c] {
+			return fmt.Errorf("capability '%s' cannot be added and dropped at the same time", c)
+		}
+	}
+	return nil
+}
+
 // LoadRemote configures remote execution
 func (dev *Dev) LoadRemote(pubKeyPath string) {
 	if dev.RemotePort == 0 {
@@ -723,14 +1192,122 @@ func (dev *Dev) LoadForcePull() {
 	log.Infof("enabled force pull")
 }
 
+//LoadPodAnnotations sets the annotations of the pod of the development container from values of the form 'key=value'
+func (dev *Dev) LoadPodAnnotations(values []string) error {
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid pod annotation '%s': must be of the form 'key=value'", v)
+		}
+		if errs := validation.IsQualifiedName(parts[0]); len(errs) > 0 {
+			return fmt.Errorf("invalid pod annotation '%s': %s", v, strings.Join(errs, ", "))
+		}
+		prefix := strings.SplitN(parts[0], "/", 2)[0]
+		if prefix == "okteto.com" || strings.HasSuffix(prefix, ".okteto.com") {
+			return fmt.Errorf("invalid pod annotation '%s': the annotation '%s' is reserved by okteto", v, parts[0])
+		}
+		if dev.PodAnnotations == nil {
+			dev.PodAnnotations = Annotations{}
+		}
+		dev.PodAnnotations[parts[0]] = parts[1]
+	}
+	return nil
+}
+
+//LoadServicePullPolicies overrides the image pull policy of the services with values of the form 'service=policy'.
+//Services with the 'Always' policy are recreated to pull the latest version of their image
+func (dev *Dev) LoadServicePullPolicies(values []string) error {
+	restartUUID := uuid.New().String()
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("invalid pull policy '%s': must be of the form 'service=policy'", v)
+		}
+
+		policy := apiv1.PullPolicy(parts[1])
+		if err := validatePullPolicy(policy); err != nil {
+			return fmt.Errorf("invalid pull policy '%s': %s", v, err)
+		}
+
+		s := dev.getServiceByName(parts[0])
+		if s == nil {
+			return fmt.Errorf("invalid pull policy '%s': service '%s' is not defined in your okteto manifest", v, parts[0])
+		}
+
+		s.ImagePullPolicy = policy
+		if policy == apiv1.PullAlways {
+			s.Annotations[OktetoRestartAnnotation] = restartUUID
+		}
+		log.Infof("image pull policy of service '%s' set to '%s'", s.Name, policy)
+	}
+	return nil
+}
+
+func (dev *Dev) getServiceByName(name string) *Dev {
+	for _, s := range dev.Services {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+//GetCommand returns the command of the development container prepended with its command wrapper
+func (dev *Dev) GetCommand() []string {
+	if len(dev.CommandWrapper) == 0 {
+		return dev.Command.Values
+	}
+	command := append([]string{}, dev.CommandWrapper...)
+	return append(command, dev.Command.Values...)
+}
+
+//OverrideForwards replaces the forwards of dev using the same local port and appends the rest
+func (dev *Dev) OverrideForwards(forwards []Forward) error {
+	for i := range forwards {
+		if err := forwards[i].validate(); err != nil {
+			return err
+		}
+
+		found := false
+		for j := range dev.Forward {
+			if dev.Forward[j].Local == forwards[i].Local {
+				dev.Forward[j] = forwards[i]
+				found = true
+				break
+			}
+		}
+		if !found {
+			dev.Forward = append(dev.Forward, forwards[i])
+		}
+	}
+
+	sort.SliceStable(dev.Forward, func(i, j int) bool {
+		return dev.Forward[i].less(&dev.Forward[j])
+	})
+	return nil
+}
+
 //Save saves the okteto manifest in a given path
 func (dev *Dev) Save(path string) error {
+	return dev.save(path, false)
+}
+
+//SaveGenerated saves the okteto manifest in a given path, marked as generated by okteto
+func (dev *Dev) SaveGenerated(path string) error {
+	return dev.save(path, true)
+}
+
+func (dev *Dev) save(path string, generated bool) error {
 	marshalled, err := yaml.Marshal(dev)
 	if err != nil {
 		log.Infof("failed to marshall development container: %s", err)
 		return fmt.Errorf("Failed to generate your manifest")
 	}
 
+	if generated {
+		marshalled = MarkAsGenerated(marshalled, "#")
+	}
+
 	if err := ioutil.WriteFile(path, marshalled, 0600); err != nil {
 		log.Infof("failed to write okteto manifest at %s: %s", path, err)
 		return fmt.Errorf("Failed to write your manifest")
@@ -764,6 +1341,24 @@ func (dev *Dev) GetVolumeName() string {
 	return fmt.Sprintf(OktetoVolumeNameTemplate, dev.Name)
 }
 
+//GetPullSecretName returns the name of the pull secret for a given development container
+func (dev *Dev) GetPullSecretName() string {
+	return fmt.Sprintf(OktetoPullSecretTemplate, dev.Name)
+}
+
+// GetCredentials returns the username and password of the registry from the environment
+func (p *PullSecret) GetCredentials() (string, string, error) {
+	username := os.Getenv(p.UsernameEnv)
+	if username == "" {
+		return "", "", fmt.Errorf("the environment variable '%s' of 'pullSecret.usernameEnv' is not defined", p.UsernameEnv)
+	}
+	password := os.Getenv(p.PasswordEnv)
+	if password == "" {
+		return "", "", fmt.Errorf("the environment variable '%s' of 'pullSecret.passwordEnv' is not defined", p.PasswordEnv)
+	}
+	return username, password, nil
+}
+
 // LabelsSelector returns the labels of a Deployment as a k8s selector
 func (dev *Dev) LabelsSelector() string {
 	labels := ""
@@ -777,12 +1372,64 @@ func (dev *Dev) LabelsSelector() string {
 	return labels
 }
 
+// getEnvironment returns the environment of dev, merged with the environment of main when 'inheritEnv' is set.
+// The variables of dev override the variables of main with the same name
+func (dev *Dev) getEnvironment(main *Dev) Environment {
+	if !dev.InheritEnv || main == dev {
+		return dev.Environment
+	}
+
+	overrides := map[string]bool{}
+	for _, e := range dev.Environment {
+		overrides[e.Name] = true
+	}
+
+	result := Environment{}
+	for _, e := range main.Environment {
+		if overrides[e.Name] {
+			continue
+		}
+		if previous, ok := main.localEnv[e.Name]; ok {
+			// variables of the local machine are only set in the main development container
+			if previous == nil {
+				continue
+			}
+			e.Value = *previous
+		}
+		result = append(result, e)
+	}
+	return append(result, dev.Environment...)
+}
+
+// SetLocalEnvVar sets a variable of the local machine in the environment of the main development container.
+// Services with 'inheritEnv' keep the value of the okteto manifest
+func (dev *Dev) SetLocalEnvVar(name, value string) {
+	if dev.localEnv == nil {
+		dev.localEnv = map[string]*string{}
+	}
+
+	for i := range dev.Environment {
+		if dev.Environment[i].Name != name {
+			continue
+		}
+		if _, ok := dev.localEnv[name]; !ok {
+			previous := dev.Environment[i].Value
+			dev.localEnv[name] = &previous
+		}
+		dev.Environment[i].Value = value
+		return
+	}
+
+	dev.localEnv[name] = nil
+	dev.Environment = append(dev.Environment, EnvVar{Name: name, Value: value})
+}
+
 // ToTranslationRule translates a dev struct into a translation rule
 func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 	rule := &TranslationRule{
 		Container:        dev.Container,
 		ImagePullPolicy:  dev.ImagePullPolicy,
-		Environment:      dev.Environment,
+		Environment:      dev.getEnvironment(main),
 		Secrets:          dev.Secrets,
 		WorkDir:          dev.Workdir,
 		PersistentVolume: main.PersistentVolumeEnabled(),
@@ -811,6 +1458,9 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 	if main == dev {
 		rule.Marker = OktetoBinImageTag //for backward compatibility
 		rule.OktetoBinImageTag = dev.InitContainer.Image
+		rule.MountFromImage = dev.MountFromImage
+		rule.Sidecars = dev.Sidecars
+		rule.ServiceAccountTokens = dev.ServiceAccountTokens
 		rule.Environment = append(
 			rule.Environment,
 			EnvVar{
@@ -895,7 +1545,7 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 				},
 			)
 		}
-		rule.Command = []string{"/var/okteto/bin/start.sh"}
+		rule.Command = []string{OktetoStartScript}
 		if main.RemoteModeEnabled() {
 			rule.Args = []string{"-r"}
 		} else {
@@ -913,8 +1563,9 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 		if dev.Docker.Enabled {
 			rule.Args = append(rule.Args, "-d")
 		}
+		rule.Args = append(rule.Args, dev.StartArgs...)
 	} else if len(dev.Command.Values) > 0 {
-		rule.Command = dev.Command.Values
+		rule.Command = dev.GetCommand()
 		rule.Args = []string{}
 	}
 
@@ -948,6 +1599,7 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 				Name:      v.Name,
 				MountPath: v.MountPath,
 				SubPath:   v.SubPath,
+				ReadOnly:  v.ReadOnly,
 			},
 		)
 	}
@@ -1015,6 +1667,32 @@ func (dev *Dev) GevSandbox() *appsv1.Deployment {
 	}
 }
 
+// Hash returns a hash of the okteto manifest of the development container.
+// It is computed when the manifest is loaded, before any command line override is applied
+func (dev *Dev) Hash() (string, error) {
+	if dev.manifestHash != "" {
+		return dev.manifestHash, nil
+	}
+	return dev.hash()
+}
+
+func (dev *Dev) computeManifestHash() error {
+	hash, err := dev.hash()
+	if err != nil {
+		return err
+	}
+	dev.manifestHash = hash
+	return nil
+}
+
+func (dev *Dev) hash() (string, error) {
+	b, err := json.Marshal(dev)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the hash of the okteto manifest: %s", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
 // RemoteModeEnabled returns true if remote is enabled
 func (dev *Dev) RemoteModeEnabled() bool {
 	if dev == nil {
@@ -1045,15 +1723,52 @@ func (s *Secret) GetFileName() string {
 	return filepath.Base(s.RemotePath)
 }
 
-//ExpandEnv expands the environments supporting the notation "${var:-$DEFAULT}"
+//ExpandEnv expands the environments supporting the notation "${var:-$DEFAULT}".
+//Undefined variables are expanded to an empty string. Use "$$" to escape a literal "$": "$$HOME" is expanded to "$HOME"
 func ExpandEnv(value string) (string, error) {
-	result, err := envsubst.String(value)
+	result, undefined, err := expandEnv(value)
 	if err != nil {
-		return "", fmt.Errorf("error expanding environment on '%s': %s", value, err.Error())
+		return "", err
+	}
+	if len(undefined) > 0 {
+		log.Debugf("undefined environment variables expanded to an empty string in '%s': %s", value, strings.Join(undefined, ", "))
 	}
 	return result, nil
 }
 
+// expandEnv expands the environment of value and returns the undefined variables it references.
+// The "$$" escapes split value so they are never seen by envsubst
+func expandEnv(value string) (string, []string, error) {
+	parts := strings.Split(value, "$$")
+	undefined := []string{}
+	for i := range parts {
+		undefined = append(undefined, getUndefinedEnvVars(parts[i])...)
+		result, err := envsubst.String(parts[i])
+		if err != nil {
+			return "", nil, fmt.Errorf("error expanding environment on '%s': %s", value, err.Error())
+		}
+		parts[i] = result
+	}
+	return strings.Join(parts, "$"), undefined, nil
+}
+
+// getUndefinedEnvVars returns the variables referenced in value that aren't defined and don't have a default value
+func getUndefinedEnvVars(value string) []string {
+	undefined := []string{}
+	for _, match := range envVarRegex.FindAllStringSubmatch(value, -1) {
+		name := match[1]
+		if name == "" {
+			name = match[3]
+		} else if match[2] != "" {
+			continue
+		}
+		if _, ok := os.LookupEnv(name); !ok {
+			undefined = append(undefined, name)
+		}
+	}
+	return undefined
+}
+
 // GetTimeout returns the timeout override
 func GetTimeout() (time.Duration, error) {
 	defaultTimeout := (60 * time.Second)
@@ -504,11 +504,11 @@ func TestDev_validateName(t *testing.T) {
 			}
 			// Since dev isn't being unmarshalled through Read, apply defaults
 			// before validating.
-			if err := dev.setDefaults(); err != nil {
+			if err := dev.Complete(); err != nil {
 				t.Fatalf("error applying defaults: %v", err)
 			}
-			if err := dev.validate(); (err != nil) != tt.wantErr {
-				t.Errorf("Dev.validate() error = %v, wantErr %v", err, tt.wantErr)
+			if err := dev.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Dev.Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -550,7 +550,7 @@ image:
 			}
 			// Since dev isn't being unmarshalled through Read, apply defaults
 			// before validating.
-			if err := dev.setDefaults(); err != nil {
+			if err := dev.Complete(); err != nil {
 				t.Fatalf("error applying defaults: %v", err)
 			}
 			if !reflect.DeepEqual(dev.Image, tt.expected) {
@@ -685,6 +685,77 @@ func Test_LoadForcePull(t *testing.T) {
 	}
 }
 
+func Test_LoadServicePullPolicies(t *testing.T) {
+	manifest := []byte(`
+  name: a
+  imagePullPolicy: IfNotPresent
+  services:
+    - name: b
+      imagePullPolicy: IfNotPresent
+    - name: c
+      imagePullPolicy: IfNotPresent`)
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.LoadServicePullPolicies([]string{"b=Always"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.ImagePullPolicy != apiv1.PullIfNotPresent {
+		t.Errorf("wrong image pull policy for main container: %s", dev.ImagePullPolicy)
+	}
+	if dev.Annotations[OktetoRestartAnnotation] != "" {
+		t.Errorf("restart annotation set for main container")
+	}
+
+	if dev.Services[0].ImagePullPolicy != apiv1.PullAlways {
+		t.Errorf("wrong image pull policy for service b: %s", dev.Services[0].ImagePullPolicy)
+	}
+	if dev.Services[0].Annotations[OktetoRestartAnnotation] == "" {
+		t.Errorf("restart annotation not set for service b")
+	}
+
+	if dev.Services[1].ImagePullPolicy != apiv1.PullIfNotPresent {
+		t.Errorf("wrong image pull policy for service c: %s", dev.Services[1].ImagePullPolicy)
+	}
+	if dev.Services[1].Annotations[OktetoRestartAnnotation] != "" {
+		t.Errorf("restart annotation set for service c")
+	}
+
+	for _, value := range []string{"b", "=Always", "b=always", "b=Sometimes", "d=Always", "a=Always"} {
+		if err := dev.LoadServicePullPolicies([]string{value}); err == nil {
+			t.Errorf("expected an error for '%s'", value)
+		}
+	}
+}
+
+func Test_LoadPodAnnotations(t *testing.T) {
+	dev := &Dev{Name: "a"}
+	if err := dev.LoadPodAnnotations([]string{"sidecar.istio.io/inject=false", "linkerd.io/inject=disabled", "empty="}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Annotations{
+		"sidecar.istio.io/inject": "false",
+		"linkerd.io/inject":       "disabled",
+		"empty":                   "",
+	}
+	if !reflect.DeepEqual(dev.PodAnnotations, expected) {
+		t.Errorf("expected pod annotations %v, got %v", expected, dev.PodAnnotations)
+	}
+	if len(dev.Annotations) > 0 {
+		t.Errorf("the annotations of the deployment were modified: %v", dev.Annotations)
+	}
+
+	for _, value := range []string{"inject", "=false", "-inject=false", "a/b/c=false", "dev.okteto.com/name=a"} {
+		if err := dev.LoadPodAnnotations([]string{value}); err == nil {
+			t.Errorf("expected an error for '%s'", value)
+		}
+	}
+}
+
 func Test_validate(t *testing.T) {
 	file, err := ioutil.TempFile("/tmp", "okteto-secret-test")
 	if err != nil {
@@ -760,144 +831,774 @@ func Test_validate(t *testing.T) {
       name: deployment
       sync:
         - .:/app
-      externalVolumes:
-        - name:/`),
+      externalVolumes:
+        - name:/`),
+			expectErr: true,
+		},
+		{
+			name: "external-volumes-relative-mount-path",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      externalVolumes:
+        - name:path`),
+			expectErr: true,
+		},
+		{
+			name: "external-volumes-read-only",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      externalVolumes:
+        - name: shared
+          mountpath: /data
+          readOnly: true`),
+			expectErr: false,
+		},
+		{
+			name: "external-volumes-read-only-on-sync-folder",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      externalVolumes:
+        - name: shared
+          mountpath: /app/data
+          readOnly: true`),
+			expectErr: true,
+		},
+		{
+			name: "wrong-pvc-size",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      persistentVolume:
+        enabled: true
+        size: wrong`),
+			expectErr: true,
+		},
+		{
+			name: "services-with-mountpath-pullpolicy",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          sync:
+            - .:/app
+          imagePullPolicy: Always`),
+			expectErr: false,
+		},
+		{
+			name: "services-with-bad-pullpolicy",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          sync:
+            - .:/app
+          imagePullPolicy: Sometimes`),
+			expectErr: true,
+		},
+		{
+			name: "volumes",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+        - docs:/docs`),
+			expectErr: false,
+		},
+		{
+			name: "external-volumes",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      externalVolumes:
+        - pvc1:path:/path
+        - pvc2:/path`),
+			expectErr: false,
+		},
+		{
+			name: "secrets",
+			manifest: []byte(fmt.Sprintf(`
+      name: deployment
+      sync:
+        - .:/app
+      secrets:
+        - %s:/remote
+        - %s:/remote`, file.Name(), file.Name())),
+			expectErr: true,
+		},
+		{
+			name: "bad-pull-policy",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      imagePullPolicy: what`),
+			expectErr: true,
+		},
+		{
+			name: "good-pull-policy",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      imagePullPolicy: IfNotPresent`),
+			expectErr: false,
+		},
+		{
+			name: "valid-ssh-server-port",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      sshServerPort: 2222`),
+			expectErr: false,
+		},
+		{
+			name: "invalid-ssh-server-port",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      sshServerPort: -1`),
+			expectErr: true,
+		},
+		{
+			name: "docker-with-persistent-volume",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      docker:
+        enabled: true`),
+			expectErr: false,
+		},
+		{
+			name: "capabilities-add-drop",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      securityContext:
+        capabilities:
+          add:
+            - SYS_PTRACE
+          drop:
+            - SYS_NICE`),
+			expectErr: false,
+		},
+		{
+			name: "capabilities-add-drop-conflict",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      securityContext:
+        capabilities:
+          add:
+            - SYS_PTRACE
+          drop:
+            - SYS_PTRACE`),
+			expectErr: true,
+		},
+		{
+			name: "services-capabilities-add-drop-conflict",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          sync:
+            - .:/app
+          securityContext:
+            capabilities:
+              add:
+                - NET_ADMIN
+              drop:
+                - NET_ADMIN`),
+			expectErr: true,
+		},
+		{
+			name: "services-with-replicas",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          replicas: 2`),
+			expectErr: false,
+		},
+		{
+			name: "services-with-zero-replicas",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          replicas: 0`),
+			expectErr: true,
+		},
+		{
+			name: "main-dev-with-replicas",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      replicas: 2`),
+			expectErr: true,
+		},
+		{
+			name: "sync-mode-sendonly",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        mode: sendonly
+        folders:
+          - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "sync-reconnect-interval",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        reconnectIntervalS: 10
+        folders:
+          - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "sync-reconnect-interval-negative",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        reconnectIntervalS: -1
+        folders:
+          - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "sync-mode-invalid",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        mode: receiveonly
+        folders:
+          - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "docker-without-persistent-volume",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      persistentVolume:
+        enabled: false
+      docker:
+        enabled: true`),
+			expectErr: true,
+		},
+		{
+			name: "mount-from-image",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      volumes:
+        - /app/vendor/
+      mountFromImage:
+        image: okteto/vendor:1.0
+        path: /app/vendor`),
+			expectErr: false,
+		},
+		{
+			name: "mount-from-image-with-registry-and-digest",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      volumes:
+        - /app/vendor
+      mountFromImage:
+        image: registry.example.com:5000/team/vendor@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+        path: /app/vendor`),
+			expectErr: false,
+		},
+		{
+			name: "mount-from-image-invalid-image",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      volumes:
+        - /app/vendor
+      mountFromImage:
+        image: Okteto/Vendor:1.0
+        path: /app/vendor`),
+			expectErr: true,
+		},
+		{
+			name: "mount-from-image-relative-path",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      volumes:
+        - /app/vendor
+      mountFromImage:
+        image: okteto/vendor:1.0
+        path: vendor`),
+			expectErr: true,
+		},
+		{
+			name: "mount-from-image-path-not-in-volumes",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      mountFromImage:
+        image: okteto/vendor:1.0
+        path: /app/vendor`),
+			expectErr: true,
+		},
+		{
+			name: "pod-labels",
+			manifest: []byte(`
+      name: deployment
+      podLabels:
+        role: frontend
+        example.com/policy: allow-syncthing`),
+			expectErr: false,
+		},
+		{
+			name: "pod-labels-reserved",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      podLabels:
+        interactive.dev.okteto.com: deployment`),
+			expectErr: true,
+		},
+		{
+			name: "pod-labels-in-services",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: worker
+          sync:
+            - .:/app
+          podLabels:
+            role: worker`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - audience: vault
+          expirationSeconds: 3600
+          path: /var/run/secrets/vault/token
+        - audience: sts.amazonaws.com
+          path: /var/run/secrets/eks.amazonaws.com/serviceaccount/token`),
+			expectErr: false,
+		},
+		{
+			name: "service-account-tokens-without-audience",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - path: /var/run/secrets/vault/token`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens-short-expiration",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - audience: vault
+          expirationSeconds: 60
+          path: /var/run/secrets/vault/token`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens-relative-path",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - audience: vault
+          path: vault/token`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens-same-folder",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - audience: vault
+          path: /var/run/secrets/tokens/vault
+        - audience: sts.amazonaws.com
+          path: /var/run/secrets/tokens/aws`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens-root-folder",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - audience: vault
+          path: /token`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens-sync-folder",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - audience: vault
+          path: /app/secrets/token`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens-okteto-folder",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - audience: vault
+          path: /var/okteto/vault/token`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens-nested-folders",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      serviceAccountTokens:
+        - audience: vault
+          path: /var/run/secrets/vault/token
+        - audience: sts.amazonaws.com
+          path: /var/run/secrets/vault/aws/token`),
+			expectErr: true,
+		},
+		{
+			name: "service-account-tokens-in-services",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: worker
+          sync:
+            - .:/app
+          serviceAccountTokens:
+            - audience: vault
+              path: /var/run/secrets/vault/token`),
+			expectErr: true,
+		},
+		{
+			name: "init-container-exclude",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        exclude:
+          - vendor
+          - web/node_modules
+          - "*.log"`),
+			expectErr: false,
+		},
+		{
+			name: "init-container-exclude-absolute",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        exclude:
+          - /app/vendor`),
+			expectErr: true,
+		},
+		{
+			name: "init-container-exclude-parent",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        exclude:
+          - ../vendor`),
+			expectErr: true,
+		},
+		{
+			name: "init-container-exclude-shell",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        exclude:
+          - "vendor'; rm -rf /"`),
+			expectErr: true,
+		},
+		{
+			name: "init-container-refresh",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      volumes:
+        - /root/.cache
+      initContainer:
+        refresh:
+          - /app/node_modules
+          - /root/.cache`),
+			expectErr: false,
+		},
+		{
+			name: "init-container-refresh-outside-volumes",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        refresh:
+          - /usr/lib`),
+			expectErr: true,
+		},
+		{
+			name: "init-container-refresh-relative",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      initContainer:
+        refresh:
+          - node_modules`),
 			expectErr: true,
 		},
 		{
-			name: "external-volumes-relative-mount-path",
+			name: "init-container-refresh-shell",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      externalVolumes:
-        - name:path`),
+      initContainer:
+        refresh:
+          - "/app; rm -rf /"`),
 			expectErr: true,
 		},
 		{
-			name: "wrong-pvc-size",
+			name: "init-container-refresh-without-persistent-volume",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
       persistentVolume:
-        enabled: true
-        size: wrong`),
+        enabled: false
+      initContainer:
+        refresh:
+          - /app/node_modules`),
 			expectErr: true,
 		},
 		{
-			name: "services-with-mountpath-pullpolicy",
+			name: "pull-secret",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      services:
-        - name: foo
-          sync:
-            - .:/app
-          imagePullPolicy: Always`),
+      pullSecret:
+        registry: registry.example.com
+        usernameEnv: REGISTRY_USERNAME
+        passwordEnv: REGISTRY_PASSWORD`),
 			expectErr: false,
 		},
 		{
-			name: "services-with-bad-pullpolicy",
+			name: "pull-secret-without-password",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      services:
-        - name: foo
-          sync:
-            - .:/app
-          imagePullPolicy: Sometimes`),
+      pullSecret:
+        registry: registry.example.com
+        usernameEnv: REGISTRY_USERNAME`),
 			expectErr: true,
 		},
 		{
-			name: "volumes",
+			name: "no-init-container",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-        - docs:/docs`),
+      initContainer:
+        enabled: false`),
 			expectErr: false,
 		},
 		{
-			name: "external-volumes",
+			name: "no-init-container-with-exclude",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      externalVolumes:
-        - pvc1:path:/path
-        - pvc2:/path`),
-			expectErr: false,
+      initContainer:
+        enabled: false
+        exclude:
+          - node_modules`),
+			expectErr: true,
 		},
 		{
-			name: "secrets",
-			manifest: []byte(fmt.Sprintf(`
+			name: "no-init-container-in-services",
+			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      secrets:
-        - %s:/remote
-        - %s:/remote`, file.Name(), file.Name())),
+      services:
+        - name: foo
+          sync:
+            - .:/app
+          initContainer:
+            enabled: false`),
 			expectErr: true,
 		},
 		{
-			name: "bad-pull-policy",
+			name: "sidecar",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      imagePullPolicy: what`),
+      sidecars:
+        - name: db
+          sync:
+            - .:/app
+          image: postgres:13
+          ports:
+            - 5432`),
+			expectErr: false,
+		},
+		{
+			name: "sidecar-without-image",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      sidecars:
+        - name: db`),
 			expectErr: true,
 		},
 		{
-			name: "good-pull-policy",
+			name: "sidecar-name-collision",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      imagePullPolicy: IfNotPresent`),
-			expectErr: false,
+      container: api
+      sidecars:
+        - name: api
+          sync:
+            - .:/app
+          image: postgres:13`),
+			expectErr: true,
 		},
 		{
-			name: "valid-ssh-server-port",
+			name: "sidecar-duplicated-name",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      sshServerPort: 2222`),
-			expectErr: false,
+      sidecars:
+        - name: db
+          sync:
+            - .:/app
+          image: postgres:13
+        - name: db
+          sync:
+            - .:/app
+          image: redis`),
+			expectErr: true,
 		},
 		{
-			name: "invalid-ssh-server-port",
+			name: "sidecar-ssh-port-collision",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      sshServerPort: -1`),
+      sidecars:
+        - name: proxy
+          sync:
+            - .:/app
+          image: envoyproxy/envoy
+          ports:
+            - 2222`),
 			expectErr: true,
 		},
 		{
-			name: "docker-with-persistent-volume",
+			name: "sidecar-port-collision",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      docker:
-        enabled: true`),
-			expectErr: false,
+      sidecars:
+        - name: db
+          sync:
+            - .:/app
+          image: postgres:13
+          ports:
+            - 8080
+        - name: proxy
+          sync:
+            - .:/app
+          image: envoyproxy/envoy
+          ports:
+            - 8080`),
+			expectErr: true,
 		},
 		{
-			name: "docker-without-persistent-volume",
+			name: "sidecar-in-services",
 			manifest: []byte(`
       name: deployment
       sync:
         - .:/app
-      persistentVolume:
-        enabled: false
-      docker:
-        enabled: true`),
+      services:
+        - name: foo
+          sync:
+            - .:/app
+          sidecars:
+            - name: db
+              image: postgres:13`),
 			expectErr: true,
 		},
 	}
@@ -909,7 +1610,7 @@ func Test_validate(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			err = dev.validate()
+			err = dev.Validate()
 			if tt.expectErr && err == nil {
 				t.Error("didn't got the expected error")
 			}
@@ -993,6 +1694,26 @@ func Test_ExpandEnv(t *testing.T) {
 			value:  "value-${FOO:-foo}-value",
 			result: "value-foo-value",
 		},
+		{
+			name:   "escaped",
+			value:  "value-$${BAR}-$$BAR-value",
+			result: "value-${BAR}-$BAR-value",
+		},
+		{
+			name:   "escaped-before-var",
+			value:  "value-$$$BAR-value",
+			result: "value-$bar-value",
+		},
+		{
+			name:   "null-character",
+			value:  "value-\x00-$$BAR",
+			result: "value-\x00-$BAR",
+		},
+		{
+			name:   "undefined",
+			value:  "value-${OKTETO_UNDEFINED_VAR}-value",
+			result: "value--value",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1008,6 +1729,56 @@ func Test_ExpandEnv(t *testing.T) {
 	}
 }
 
+func Test_getUndefinedEnvVars(t *testing.T) {
+	os.Setenv("BAR", "bar")
+	os.Unsetenv("FOO")
+	os.Unsetenv("BAZ")
+	undefined := getUndefinedEnvVars("$BAR ${FOO} $BAZ ${FOO:-foo} ${BAR}")
+	expected := []string{"FOO", "BAZ"}
+	if !reflect.DeepEqual(undefined, expected) {
+		t.Errorf("expected undefined variables %v, got %v", expected, undefined)
+	}
+}
+
+func Test_ReadExpandsEnvVars(t *testing.T) {
+	os.Setenv("OKTETO_TEST_SHELL", "bash")
+	os.Setenv("OKTETO_TEST_WORKDIR", "/app")
+	os.Setenv("OKTETO_TEST_PORT", "8080")
+	os.Setenv("OKTETO_TEST_DEBUG", "true")
+	defer os.Unsetenv("OKTETO_TEST_SHELL")
+	defer os.Unsetenv("OKTETO_TEST_WORKDIR")
+	defer os.Unsetenv("OKTETO_TEST_PORT")
+	defer os.Unsetenv("OKTETO_TEST_DEBUG")
+
+	manifest := []byte(`name: web
+image: okteto/web
+command: ["${OKTETO_TEST_SHELL}", "-c", "echo $$HOME"]
+workdir: ${OKTETO_TEST_WORKDIR}/src
+environment:
+  - DEBUG=${OKTETO_TEST_DEBUG}
+forward:
+  - ${OKTETO_TEST_PORT}:80`)
+
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCommand := []string{"bash", "-c", "echo $HOME"}
+	if !reflect.DeepEqual(dev.Command.Values, expectedCommand) {
+		t.Errorf("expected command %v, got %v", expectedCommand, dev.Command.Values)
+	}
+	if dev.Workdir != "/app/src" {
+		t.Errorf("expected workdir '/app/src', got '%s'", dev.Workdir)
+	}
+	if len(dev.Environment) != 1 || dev.Environment[0].Value != "true" {
+		t.Errorf("expected environment 'DEBUG=true', got %v", dev.Environment)
+	}
+	if len(dev.Forward) != 1 || dev.Forward[0].Local != 8080 || dev.Forward[0].Remote != 80 {
+		t.Errorf("expected forward '8080:80', got %v", dev.Forward)
+	}
+}
+
 func TestGetTimeout(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1189,3 +1960,285 @@ func createEnvFile(content map[string]string) (string, error) {
 	file.Sync()
 	return file.Name(), nil
 }
+
+func TestReadDeprecatedWarnings(t *testing.T) {
+	manifest := []byte(`name: deployment
+image: okteto/golang:1
+healthchecks: true
+sync:
+  - .:/app
+volumes:
+  - /go/pkg
+  - data:/data
+services:
+  - name: worker
+    volumes:
+      - worker:/src`)
+
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatalf("deprecated fields must not fail the load: %s", err)
+	}
+
+	expected := []string{
+		"The use of 'healthchecks' field is deprecated and will be removed in a future release. Please use the field 'probes' instead.",
+		fmt.Sprintf("The syntax 'data:/data' is deprecated in the 'volumes' field. Use the field 'sync' instead (%s)", syncFieldDocsURL),
+		fmt.Sprintf("The syntax 'worker:/src' is deprecated in the 'volumes' field. Use the field 'sync' instead (%s)", syncFieldDocsURL),
+	}
+	if !reflect.DeepEqual(dev.Warnings, expected) {
+		t.Errorf("expected warnings %v, got %v", expected, dev.Warnings)
+	}
+
+	dev, err = Read([]byte(`name: deployment
+sync:
+  - .:/app`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dev.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", dev.Warnings)
+	}
+}
+
+func TestDevCompleteAndValidate(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dev     *Dev
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			dev:  &Dev{Name: "deployment", Image: &BuildInfo{Name: "okteto/golang:1"}, Sync: Sync{Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/app"}}}},
+		},
+		{
+			name: "no-build-info",
+			dev:  &Dev{Name: "deployment", Sync: Sync{Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/app"}}}},
+		},
+		{
+			name:    "empty-name",
+			dev:     &Dev{},
+			wantErr: true,
+		},
+		{
+			name:    "bad-pull-policy",
+			dev:     &Dev{Name: "deployment", ImagePullPolicy: "Sometimes"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.dev.Complete(); err != nil {
+				t.Fatalf("error applying defaults: %s", err)
+			}
+
+			if tt.dev.ImagePullPolicy == "" {
+				t.Errorf("default 'imagePullPolicy' wasn't applied")
+			}
+			if tt.dev.Image == nil || tt.dev.Image.Context != "." {
+				t.Errorf("default 'image.context' wasn't applied: %+v", tt.dev.Image)
+			}
+			if tt.dev.InitContainer.Image != OktetoBinImageTag {
+				t.Errorf("expected init container image '%s', got '%s'", OktetoBinImageTag, tt.dev.InitContainer.Image)
+			}
+			if tt.dev.SSHServerPort != oktetoDefaultSSHServerPort {
+				t.Errorf("expected sshServerPort %d, got %d", oktetoDefaultSSHServerPort, tt.dev.SSHServerPort)
+			}
+
+			if err := tt.dev.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Dev.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDevHash(t *testing.T) {
+	dev := &Dev{Name: "web", Image: &BuildInfo{Name: "okteto/golang:1"}}
+	h1, err := dev.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2, err := dev.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 != h2 {
+		t.Errorf("hash is not stable: %s != %s", h1, h2)
+	}
+
+	dev.Image.Name = "okteto/golang:2"
+	h3, err := dev.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 == h3 {
+		t.Errorf("hash didn't change after modifying the manifest")
+	}
+}
+
+func TestDevHashIgnoresOverrides(t *testing.T) {
+	file, err := ioutil.TempFile("", "okteto.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	if err := ioutil.WriteFile(file.Name(), []byte("name: web\nimage: okteto/golang:1\nsync:\n  - .:/app\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := Get(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h1, err := dev.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev.Forward = append(dev.Forward, Forward{Local: 8080, Remote: 8080})
+	dev.Environment = append(dev.Environment, EnvVar{Name: "FOO", Value: "bar"})
+	h2, err := dev.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 != h2 {
+		t.Errorf("hash changed after applying overrides: %s != %s", h1, h2)
+	}
+}
+
+func TestCommandWrapper(t *testing.T) {
+	manifest := []byte(`name: web
+image: web:latest
+command: ["yarn", "start"]
+commandWrapper: ["time", "--verbose"]
+services:
+  - name: worker
+    image: worker:latest
+    command: ["python", "worker.py"]
+    commandWrapper: ["tini", "--"]`)
+
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"time", "--verbose", "yarn", "start"}
+	if got := dev.GetCommand(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got command %v, expected %v", got, expected)
+	}
+	if !reflect.DeepEqual(dev.Command.Values, []string{"yarn", "start"}) {
+		t.Errorf("the command was modified: %v", dev.Command.Values)
+	}
+
+	rule := dev.Services[0].ToTranslationRule(dev, false)
+	expected = []string{"tini", "--", "python", "worker.py"}
+	if !reflect.DeepEqual(rule.Command, expected) {
+		t.Errorf("got service command %v, expected %v", rule.Command, expected)
+	}
+}
+
+func Test_validateCommandWrapper(t *testing.T) {
+	var tests = []struct {
+		name    string
+		wrapper []string
+		wantErr bool
+	}{
+		{name: "unset", wrapper: nil, wantErr: false},
+		{name: "ok", wrapper: []string{"time"}, wantErr: false},
+		{name: "empty", wrapper: []string{}, wantErr: true},
+		{name: "empty-value", wrapper: []string{"tini", " "}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateCommandWrapper(tt.wrapper); (err != nil) != tt.wantErr {
+				t.Errorf("validateCommandWrapper() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetLocalEnvVar(t *testing.T) {
+	dev, err := Read([]byte(`name: web
+image: web:latest
+sync:
+  - .:/app
+environment:
+  key1: value1
+  key2: value2
+services:
+  - name: worker
+    image: worker:latest
+    inheritEnv: true`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev.SetLocalEnvVar("key2", "local2")
+	dev.SetLocalEnvVar("key3", "local3")
+
+	expectedMain := Environment{
+		{Name: "key1", Value: "value1"},
+		{Name: "key2", Value: "local2"},
+		{Name: "key3", Value: "local3"},
+	}
+	if env := dev.ToTranslationRule(dev, false).Environment; !reflect.DeepEqual(env, expectedMain) {
+		t.Errorf("expected main environment %v, got %v", expectedMain, env)
+	}
+
+	expectedService := Environment{
+		{Name: "key1", Value: "value1"},
+		{Name: "key2", Value: "value2"},
+	}
+	if env := dev.Services[0].ToTranslationRule(dev, false).Environment; !reflect.DeepEqual(env, expectedService) {
+		t.Errorf("expected service environment %v, got %v", expectedService, env)
+	}
+}
+
+func Test_ValidateSidecars(t *testing.T) {
+	names := map[string]string{"api": "the container 'api'"}
+	ports := map[int]string{8080: "the container 'api'"}
+	var tests = []struct {
+		name     string
+		sidecars []Sidecar
+		expected string
+	}{
+		{
+			name:     "ok",
+			sidecars: []Sidecar{{Name: "redis", Image: "redis", Ports: []int{6379}}},
+		},
+		{
+			name:     "name-collision",
+			sidecars: []Sidecar{{Name: "api", Image: "redis"}},
+			expected: "sidecar name 'api' is already used by the container 'api'",
+		},
+		{
+			name:     "port-collision",
+			sidecars: []Sidecar{{Name: "redis", Image: "redis", Ports: []int{8080}}},
+			expected: "port '8080' of sidecar 'redis' is already used by the container 'api'",
+		},
+		{
+			name: "sidecars-collision",
+			sidecars: []Sidecar{
+				{Name: "redis", Image: "redis", Ports: []int{6379}},
+				{Name: "cache", Image: "redis", Ports: []int{6379}},
+			},
+			expected: "port '6379' of sidecar 'cache' is already used by sidecar 'redis'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSidecars(tt.sidecars, names, ports)
+			if tt.expected == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.expected != "" && (err == nil || err.Error() != tt.expected) {
+				t.Fatalf("expected error '%s', got %v", tt.expected, err)
+			}
+			if len(names) != 1 || len(ports) != 1 {
+				t.Errorf("the given names and ports were modified: %v %v", names, ports)
+			}
+		})
+	}
+}
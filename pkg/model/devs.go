@@ -0,0 +1,106 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const devsField = "dev"
+
+// devsManifest is an okteto manifest that defines several development environments under the 'dev' field, indexed by name
+type devsManifest struct {
+	Dev map[string]map[interface{}]interface{} `yaml:"dev"`
+}
+
+// IsDevsManifest returns if an okteto manifest defines several development environments under the 'dev' field
+func IsDevsManifest(bytes []byte) bool {
+	m := map[string]interface{}{}
+	if err := yaml.Unmarshal(bytes, &m); err != nil {
+		return false
+	}
+	_, ok := m[devsField].(map[interface{}]interface{})
+	return ok
+}
+
+// ReadDevs reads the development environments of an okteto manifest, indexed by name.
+// Manifests with a single development environment return a map with one element
+func ReadDevs(bytes []byte) (map[string]*Dev, error) {
+	bytes, err := resolveExtends("", bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsDevsManifest(bytes) {
+		dev, err := Read(bytes)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]*Dev{dev.Name: dev}, nil
+	}
+
+	m := devsManifest{}
+	if err := yaml.UnmarshalStrict(bytes, &m); err != nil {
+		msg := strings.Replace(err.Error(), "yaml: unmarshal errors:", "invalid manifest:", 1)
+		return nil, fmt.Errorf("%s", strings.TrimSuffix(msg, "in type model.devsManifest"))
+	}
+
+	if len(m.Dev) == 0 {
+		return nil, fmt.Errorf("invalid manifest: '%s' must define at least one development environment", devsField)
+	}
+
+	devs := map[string]*Dev{}
+	for name, value := range m.Dev {
+		if value == nil {
+			value = map[interface{}]interface{}{}
+		}
+		if _, ok := value["name"]; !ok {
+			value["name"] = name
+		}
+
+		b, err := yaml.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid development environment '%s': %s", name, err)
+		}
+
+		dev, err := Read(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid development environment '%s': %s", name, err)
+		}
+		dev.manifestKey = name
+		devs[name] = dev
+	}
+
+	return devs, nil
+}
+
+// GetDevNames returns the sorted names of a set of development environments
+func GetDevNames(devs map[string]*Dev) []string {
+	names := []string{}
+	for name := range devs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ManifestKey returns the key of the development environment under the 'dev' field of the okteto manifest.
+// It is empty for okteto manifests with a single development environment
+func (dev *Dev) ManifestKey() string {
+	return dev.manifestKey
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ReadDevs(t *testing.T) {
+	var tests = []struct {
+		name      string
+		manifest  []byte
+		expected  map[string]string
+		expectErr bool
+	}{
+		{
+			name:     "single-dev",
+			manifest: []byte("name: api\nimage: okteto/api"),
+			expected: map[string]string{"api": "okteto/api"},
+		},
+		{
+			name:     "several-devs",
+			manifest: []byte("dev:\n  api:\n    image: okteto/api\n  frontend:\n    image: okteto/frontend"),
+			expected: map[string]string{"api": "okteto/api", "frontend": "okteto/frontend"},
+		},
+		{
+			name:     "empty-dev",
+			manifest: []byte("dev:\n  api:\n"),
+			expected: map[string]string{"api": ""},
+		},
+		{
+			name:      "no-devs",
+			manifest:  []byte("dev: {}"),
+			expectErr: true,
+		},
+		{
+			name:      "unknown-top-level-field",
+			manifest:  []byte("dev:\n  api:\n    image: okteto/api\nimage: okteto/api"),
+			expectErr: true,
+		},
+		{
+			name:      "invalid-dev",
+			manifest:  []byte("dev:\n  api:\n    unknown: value"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devs, err := ReadDevs(tt.manifest)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := map[string]string{}
+			for name, dev := range devs {
+				if dev.Name != name {
+					t.Errorf("expected dev name '%s', got '%s'", name, dev.Name)
+				}
+				key := ""
+				if IsDevsManifest(tt.manifest) {
+					key = name
+				}
+				if dev.ManifestKey() != key {
+					t.Errorf("expected manifest key '%s', got '%s'", key, dev.ManifestKey())
+				}
+				result[name] = dev.Image.Name
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_IsDevsManifest(t *testing.T) {
+	if IsDevsManifest([]byte("name: api\nimage: okteto/api")) {
+		t.Error("flat manifest detected as a manifest with several development environments")
+	}
+	if !IsDevsManifest([]byte("dev:\n  api:\n    image: okteto/api")) {
+		t.Error("manifest with several development environments not detected")
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const extendsField = "extends"
+
+type manifestExtends struct {
+	Extends string `yaml:"extends"`
+}
+
+// resolveExtends returns the manifest merged with the manifests it extends, or the manifest itself if it doesn't extend any.
+// If devPath is empty, the extended manifests are relative to the current folder
+func resolveExtends(devPath string, b []byte) ([]byte, error) {
+	e := manifestExtends{}
+	if err := yaml.Unmarshal(b, &e); err != nil || e.Extends == "" {
+		// invalid manifests are reported by Read
+		return b, nil
+	}
+
+	m := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return b, nil
+	}
+
+	source := "okteto manifest"
+	visited := []string{}
+	folder, err := filepath.Abs(".")
+	if err != nil {
+		return nil, err
+	}
+	if devPath != "" {
+		abs, err := filepath.Abs(devPath)
+		if err != nil {
+			return nil, err
+		}
+		source = abs
+		visited = append(visited, abs)
+		folder = filepath.Dir(abs)
+	}
+
+	m, err = extendManifest(m, source, folder, visited)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(m)
+}
+
+// extendManifest recursively merges the manifest m, located in folder, on top of the manifest it extends
+func extendManifest(m map[interface{}]interface{}, source, folder string, visited []string) (map[interface{}]interface{}, error) {
+	value, ok := m[extendsField]
+	if !ok {
+		return m, nil
+	}
+	delete(m, extendsField)
+
+	parent, ok := value.(string)
+	if !ok || parent == "" {
+		return nil, fmt.Errorf("'%s' must be the path of an okteto manifest in '%s'", extendsField, source)
+	}
+	parent = loadAbsPath(folder, parent)
+
+	for i := range visited {
+		if visited[i] == parent {
+			return nil, fmt.Errorf("'extends' cycle detected: %s", strings.Join(append(visited[i:], parent), " -> "))
+		}
+	}
+	visited = append(visited, parent)
+
+	b, err := ioutil.ReadFile(parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest extended by '%s': %s", source, err)
+	}
+
+	base := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &base); err != nil {
+		return nil, fmt.Errorf("invalid manifest '%s': %s", parent, err)
+	}
+
+	base, err = extendManifest(base, parent, filepath.Dir(parent), visited)
+	if err != nil {
+		return nil, err
+	}
+	rebaseManifestPaths(base, filepath.Dir(parent))
+
+	return mergeManifests(base, m), nil
+}
+
+// rebaseManifestPaths makes the relative local paths of a manifest relative to folder, the folder of the manifest that declares them
+func rebaseManifestPaths(m map[interface{}]interface{}, folder string) {
+	for _, field := range []string{"image", "push"} {
+		buildInfo, ok := m[field].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"context", "dockerfile"} {
+			if path, ok := buildInfo[key].(string); ok && !strings.Contains(path, "://") {
+				buildInfo[key] = rebasePath(folder, path)
+			}
+		}
+	}
+
+	switch sync := m["sync"].(type) {
+	case []interface{}:
+		rebaseLocalPaths(sync, folder)
+	case map[interface{}]interface{}:
+		if folders, ok := sync["folders"].([]interface{}); ok {
+			rebaseLocalPaths(folders, folder)
+		}
+	}
+
+	for _, field := range []string{"volumes", "secrets"} {
+		if values, ok := m[field].([]interface{}); ok {
+			rebaseLocalPaths(values, folder)
+		}
+	}
+
+	if services, ok := m["services"].([]interface{}); ok {
+		for i := range services {
+			if s, ok := services[i].(map[interface{}]interface{}); ok {
+				rebaseManifestPaths(s, folder)
+			}
+		}
+	}
+
+	if devs, ok := m[devsField].(map[interface{}]interface{}); ok {
+		for _, value := range devs {
+			if dev, ok := value.(map[interface{}]interface{}); ok {
+				rebaseManifestPaths(dev, folder)
+			}
+		}
+	}
+}
+
+// rebaseLocalPaths rebases the local path of a list of 'LOCAL_PATH:REMOTE_PATH' values
+func rebaseLocalPaths(values []interface{}, folder string) {
+	for i := range values {
+		value, ok := values[i].(string)
+		if !ok {
+			continue
+		}
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		values[i] = rebasePath(folder, parts[0]) + ":" + parts[1]
+	}
+}
+
+// rebasePath returns path relative to folder. Paths starting with environment variables or '~' are kept
+func rebasePath(folder, path string) string {
+	if path == "" || strings.HasPrefix(path, "$") || strings.HasPrefix(path, "~") {
+		return path
+	}
+	return loadAbsPath(folder, path)
+}
+
+// mergeManifests deep merges override on top of base. Values of override win and lists are replaced
+func mergeManifests(base, override map[interface{}]interface{}) map[interface{}]interface{} {
+	result := map[interface{}]interface{}{}
+	for k, v := range base {
+		result[k] = v
+	}
+
+	for k, v := range override {
+		baseMap, okBase := result[k].(map[interface{}]interface{})
+		overrideMap, okOverride := v.(map[interface{}]interface{})
+		if okBase && okOverride {
+			result[k] = mergeManifests(baseMap, overrideMap)
+			continue
+		}
+		result[k] = v
+	}
+
+	return result
+}
@@ -0,0 +1,205 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetExtends(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeManifest(t, filepath.Join(dir, "base.yml"), `
+name: base
+image: okteto/golang:1
+command: ["bash"]
+environment:
+  - BASE=true
+  - LEVEL=base
+resources:
+  limits:
+    memory: 1Gi
+    cpu: 500m
+`)
+	writeManifest(t, filepath.Join(dir, "api", "okteto.yml"), `
+extends: ../base.yml
+name: api
+environment:
+  - LEVEL=api
+resources:
+  limits:
+    cpu: "1"
+`)
+
+	dev, err := Get(filepath.Join(dir, "api", "okteto.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Name != "api" {
+		t.Errorf("expected name 'api', got '%s'", dev.Name)
+	}
+	if dev.Image.Name != "okteto/golang:1" {
+		t.Errorf("expected image inherited from the base manifest, got '%s'", dev.Image.Name)
+	}
+	if len(dev.Command.Values) != 1 || dev.Command.Values[0] != "bash" {
+		t.Errorf("expected command inherited from the base manifest, got %v", dev.Command.Values)
+	}
+	if len(dev.Environment) != 1 || dev.Environment[0].Name != "LEVEL" || dev.Environment[0].Value != "api" {
+		t.Errorf("expected the environment list to be replaced, got %+v", dev.Environment)
+	}
+
+	memory := dev.Resources.Limits["memory"]
+	if memory.String() != "1Gi" {
+		t.Errorf("expected memory limit inherited from the base manifest, got '%s'", memory.String())
+	}
+	cpu := dev.Resources.Limits["cpu"]
+	if cpu.String() != "1" {
+		t.Errorf("expected cpu limit overridden by the manifest, got '%s'", cpu.String())
+	}
+}
+
+func TestGetExtendsCycle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeManifest(t, filepath.Join(dir, "a.yml"), `
+extends: b.yml
+name: a
+`)
+	writeManifest(t, filepath.Join(dir, "b.yml"), `
+extends: a.yml
+name: b
+`)
+
+	_, err = Get(filepath.Join(dir, "a.yml"))
+	if err == nil {
+		t.Fatal("expected an error for mutual extends")
+	}
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected a cycle error, got '%s'", err)
+	}
+}
+
+func TestGetExtendsMissingParent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeManifest(t, filepath.Join(dir, "okteto.yml"), `
+extends: missing.yml
+name: api
+`)
+
+	if _, err := Get(filepath.Join(dir, "okteto.yml")); err == nil {
+		t.Fatal("expected an error for a missing parent manifest")
+	}
+}
+
+func TestGetExtendsRelativePaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeManifest(t, filepath.Join(dir, "base", "okteto.yml"), `
+name: base
+image:
+  name: okteto/golang:1
+  context: .
+  dockerfile: Dockerfile
+sync:
+  - .:/app
+`)
+	writeManifest(t, filepath.Join(dir, "api", "okteto.yml"), `
+extends: ../base/okteto.yml
+name: api
+`)
+
+	dev, err := Get(filepath.Join(dir, "api", "okteto.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := filepath.Join(dir, "base")
+	if len(dev.Sync.Folders) != 1 || dev.Sync.Folders[0].LocalPath != base {
+		t.Errorf("expected sync folder relative to the base manifest, got %+v", dev.Sync.Folders)
+	}
+	if dev.Image.Context != base {
+		t.Errorf("expected build context relative to the base manifest, got '%s'", dev.Image.Context)
+	}
+	if dev.Image.Dockerfile != filepath.Join(base, "Dockerfile") {
+		t.Errorf("expected dockerfile relative to the base manifest, got '%s'", dev.Image.Dockerfile)
+	}
+}
+
+func TestReadExtends(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	writeManifest(t, filepath.Join(dir, "base.yml"), `
+name: base
+image: okteto/golang:1
+`)
+
+	dev, err := Read([]byte(`
+extends: base.yml
+name: api
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Name != "api" {
+		t.Errorf("expected name 'api', got '%s'", dev.Name)
+	}
+	if dev.Image.Name != "okteto/golang:1" {
+		t.Errorf("expected image inherited from the base manifest, got '%s'", dev.Image.Name)
+	}
+}
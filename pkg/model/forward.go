@@ -15,11 +15,24 @@ package model
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 )
 
-const malformedPortForward = "Wrong port-forward syntax '%s', must be of the form 'localPort:remotePort' or 'localPort:serviceName:remotePort'"
+const (
+	malformedPortForward = "Wrong port-forward syntax '%s', must be of the form '[address:]localPort:remotePort' or '[address:]localPort:serviceName:remotePort'"
+
+	// forwardProtocolTCP is the only protocol supported by kubernetes port-forward
+	forwardProtocolTCP = "tcp"
+	forwardProtocolUDP = "udp"
+
+	minPort = 1
+	maxPort = 65535
+
+	// maxPrivilegedPort is the highest port that requires elevated privileges to listen on
+	maxPrivilegedPort = 1023
+)
 
 // Forward represents a port forwarding definition
 type Forward struct {
@@ -28,6 +41,8 @@ type Forward struct {
 	Service     bool              `json:"-" yaml:"-"`
 	ServiceName string            `json:"name" yaml:"name"`
 	Labels      map[string]string `json:"labels" yaml:"labels"`
+	Protocol    string            `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Address     string            `json:"address,omitempty" yaml:"address,omitempty"`
 }
 
 type ForwardRaw struct {
@@ -36,12 +51,16 @@ type ForwardRaw struct {
 	Service     bool              `json:"-" yaml:"-"`
 	ServiceName string            `json:"name" yaml:"name"`
 	Labels      map[string]string `json:"labels" yaml:"labels"`
+	Protocol    string            `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Address     string            `json:"address,omitempty" yaml:"address,omitempty"`
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg for port forwards.
 // It supports the following options:
 // - int:int
 // - int:serviceName:int
+// Both can be prefixed by the local address to listen on, like '0.0.0.0:8080:8080' or '[::1]:8080:8080',
+// and followed by '/protocol', like '53:53/udp'.
 // Anything else will result in an error
 func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
@@ -50,15 +69,52 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return f.UnmarshalExtendedForm(unmarshal)
 	}
 
-	parts := strings.Split(raw, ":")
+	raw, err = ExpandEnv(raw)
+	if err != nil {
+		return err
+	}
+
+	ports := raw
+	if i := strings.LastIndex(raw, "/"); i != -1 {
+		ports = raw[:i]
+		f.Protocol = raw[i+1:]
+	}
+
+	address := ""
+	if strings.HasPrefix(ports, "[") {
+		i := strings.Index(ports, "]:")
+		if i == -1 {
+			return fmt.Errorf(malformedPortForward, raw)
+		}
+		address = ports[1:i]
+		ports = ports[i+2:]
+	}
+
+	parts := strings.Split(ports, ":")
+	if address == "" && len(parts) > 2 {
+		if _, err := strconv.Atoi(parts[0]); err != nil {
+			address = parts[0]
+			parts = parts[1:]
+		}
+	}
 	if len(parts) < 2 || len(parts) > 3 {
 		return fmt.Errorf(malformedPortForward, raw)
 	}
 
+	if address != "" {
+		if err := validateForwardAddress(address, raw); err != nil {
+			return err
+		}
+		f.Address = address
+	}
+
 	localPort, err := strconv.Atoi(parts[0])
 	if err != nil {
 		return fmt.Errorf("Cannot convert local port '%s' in port-forward '%s'", parts[0], raw)
 	}
+	if err := validatePortRange("local", localPort, raw); err != nil {
+		return err
+	}
 	f.Local = localPort
 
 	if len(parts) == 2 {
@@ -66,6 +122,9 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		if err != nil {
 			return fmt.Errorf(malformedPortForward, raw)
 		}
+		if err := validatePortRange("remote", p, raw); err != nil {
+			return err
+		}
 
 		f.Remote = p
 		return nil
@@ -77,22 +136,76 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err != nil {
 		return fmt.Errorf(malformedPortForward, raw)
 	}
+	if err := validatePortRange("remote", p, raw); err != nil {
+		return err
+	}
 
 	f.Remote = p
 	return nil
 }
 
+// validatePortRange checks that a port of a port-forward or a reverse is a valid TCP port
+func validatePortRange(kind string, port int, raw string) error {
+	if port < minPort || port > maxPort {
+		return fmt.Errorf("The %s port '%d' in '%s' is out of range: ports must be between %d and %d", kind, port, raw, minPort, maxPort)
+	}
+	return nil
+}
+
+// validateForwardAddress checks that the local address of a port-forward is an IP address
+func validateForwardAddress(address, raw string) error {
+	if net.ParseIP(address) == nil {
+		return fmt.Errorf("The address '%s' in port-forward '%s' is not valid: it must be an IP address", address, raw)
+	}
+	return nil
+}
+
+// GetLocalAddress returns the local address the port-forward listens on, iface if it doesn't define one
+func (f Forward) GetLocalAddress(iface string) string {
+	if f.Address != "" {
+		return f.Address
+	}
+	return iface
+}
+
+// GetDialAddress returns the address to connect to the local port of the port-forward, which listens on iface if it doesn't define an address
+func (f Forward) GetDialAddress(iface string) string {
+	host := f.GetLocalAddress(iface)
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = Localhost
+	}
+	return net.JoinHostPort(host, strconv.Itoa(f.Local))
+}
+
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (f Forward) MarshalYAML() (interface{}, error) {
 	return f.String(), nil
 }
 
 func (f Forward) String() string {
+	result := fmt.Sprintf("%d:%d", f.Local, f.Remote)
 	if f.Service {
-		return fmt.Sprintf("%d:%s:%d", f.Local, f.ServiceName, f.Remote)
+		result = fmt.Sprintf("%d:%s:%d", f.Local, f.ServiceName, f.Remote)
+	}
+	if f.Address != "" {
+		result = net.JoinHostPort(f.Address, result)
 	}
+	if f.Protocol != "" {
+		result = fmt.Sprintf("%s/%s", result, f.Protocol)
+	}
+	return result
+}
 
-	return fmt.Sprintf("%d:%d", f.Local, f.Remote)
+// validate checks that the protocol of the port forward is supported by kubernetes port-forward
+func (f *Forward) validate() error {
+	switch strings.ToLower(f.Protocol) {
+	case "", forwardProtocolTCP:
+		return nil
+	case forwardProtocolUDP:
+		return fmt.Errorf("port-forward '%s' uses UDP, but kubernetes port-forward only supports TCP. Run a TCP proxy sidecar like 'socat' next to your UDP service and forward its TCP port instead", f.String())
+	default:
+		return fmt.Errorf("port-forward '%s' has an unsupported protocol '%s': the only supported protocol is '%s'", f.String(), f.Protocol, forwardProtocolTCP)
+	}
 }
 
 func (f *Forward) less(c *Forward) bool {
@@ -120,13 +233,44 @@ func (f *Forward) UnmarshalExtendedForm(unmarshal func(interface{}) error) error
 	}
 	f.Local = rawForward.Local
 	f.Remote = rawForward.Remote
-	f.ServiceName = rawForward.ServiceName
+	f.ServiceName, err = ExpandEnv(rawForward.ServiceName)
+	if err != nil {
+		return err
+	}
 	f.Labels = rawForward.Labels
+	f.Protocol = rawForward.Protocol
+	f.Address, err = ExpandEnv(rawForward.Address)
+	if err != nil {
+		return err
+	}
 	if len(rawForward.Labels) != 0 || rawForward.ServiceName != "" {
 		f.Service = true
 	}
 	if f.Labels != nil && f.ServiceName != "" {
 		return fmt.Errorf("Can not use ServiceName and Labels to specify the service.\nUse either the service name or labels to get the service to expose.")
 	}
-	return nil
+	if f.Address != "" {
+		if err := validateForwardAddress(f.Address, f.String()); err != nil {
+			return err
+		}
+	}
+	if err := validatePortRange("local", f.Local, f.String()); err != nil {
+		return err
+	}
+	return validatePortRange("remote", f.Remote, f.String())
+}
+
+// getPrivilegedPortWarnings returns a warning for every port-forward that listens on a privileged local port
+func getPrivilegedPortWarnings(forwards []Forward, goos string) []string {
+	if goos == "windows" {
+		return nil
+	}
+
+	warnings := []string{}
+	for _, f := range forwards {
+		if f.Local <= maxPrivilegedPort {
+			warnings = append(warnings, fmt.Sprintf("port-forward '%s' listens on the privileged local port %d, which may require elevated privileges on your machine", f.String(), f.Local))
+		}
+	}
+	return warnings
 }
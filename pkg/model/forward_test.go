@@ -108,6 +108,81 @@ func TestForward_UnmarshalYAML(t *testing.T) {
 			data:      "8080:svc",
 			expectErr: true,
 		},
+		{
+			name:     "protocol",
+			data:     "5353:53/udp",
+			expected: Forward{Local: 5353, Remote: 53, Protocol: "udp"},
+		},
+		{
+			name:     "service-with-protocol",
+			data:     "8080:svc:5214/tcp",
+			expected: Forward{Local: 8080, Remote: 5214, Service: true, ServiceName: "svc", Protocol: "tcp"},
+		},
+		{
+			name:     "max-port",
+			data:     "65535:1",
+			expected: Forward{Local: 65535, Remote: 1},
+		},
+		{
+			name:      "local-port-out-of-range",
+			data:      "808080:8080",
+			expectErr: true,
+		},
+		{
+			name:      "local-port-zero",
+			data:      "0:8080",
+			expectErr: true,
+		},
+		{
+			name:      "remote-port-out-of-range",
+			data:      "8080:65536",
+			expectErr: true,
+		},
+		{
+			name:      "negative-remote-port",
+			data:      "8080:-1",
+			expectErr: true,
+		},
+		{
+			name:      "service-with-port-out-of-range",
+			data:      "8080:svc:808080",
+			expectErr: true,
+		},
+		{
+			name:      "extended-local-port-out-of-range",
+			data:      "localPort: 808080\nremotePort: 8080\nname: svc",
+			expectErr: true,
+		},
+		{
+			name:      "extended-remote-port-missing",
+			data:      "localPort: 8080\nname: svc",
+			expectErr: true,
+		},
+		{
+			name:     "address",
+			data:     "0.0.0.0:8080:9090",
+			expected: Forward{Local: 8080, Remote: 9090, Address: "0.0.0.0"},
+		},
+		{
+			name:     "address-with-service",
+			data:     "192.168.1.10:8080:svc:5214",
+			expected: Forward{Local: 8080, Remote: 5214, Service: true, ServiceName: "svc", Address: "192.168.1.10"},
+		},
+		{
+			name:      "invalid-address",
+			data:      "lan:8080:9090",
+			expectErr: true,
+		},
+		{
+			name:      "address-without-remote-port",
+			data:      "0.0.0.0:8080",
+			expectErr: true,
+		},
+		{
+			name:      "extended-invalid-address",
+			data:      "localPort: 8080\nremotePort: 9090\naddress: lan",
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -144,6 +219,66 @@ func TestForward_UnmarshalYAML(t *testing.T) {
 	}
 }
 
+func TestForward_IPv6Address(t *testing.T) {
+	var result Forward
+	if err := yaml.Unmarshal([]byte(`"[::1]:8080:9090"`), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Forward{Local: 8080, Remote: 9090, Address: "::1"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("didn't unmarshal correctly. Actual '%+v', Expected '%+v'", result, expected)
+	}
+	if result.String() != "[::1]:8080:9090" {
+		t.Errorf("wrong string: %s", result.String())
+	}
+	if result.GetDialAddress(Localhost) != "[::1]:8080" {
+		t.Errorf("wrong dial address: %s", result.GetDialAddress(Localhost))
+	}
+}
+
+func TestForward_GetDialAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        Forward
+		iface    string
+		expected string
+	}{
+		{
+			name:     "default",
+			f:        Forward{Local: 8080, Remote: 9090},
+			iface:    "",
+			expected: "localhost:8080",
+		},
+		{
+			name:     "interface",
+			f:        Forward{Local: 8080, Remote: 9090},
+			iface:    "192.168.1.10",
+			expected: "192.168.1.10:8080",
+		},
+		{
+			name:     "address",
+			f:        Forward{Local: 8080, Remote: 9090, Address: "192.168.1.20"},
+			iface:    "192.168.1.10",
+			expected: "192.168.1.20:8080",
+		},
+		{
+			name:     "all-interfaces",
+			f:        Forward{Local: 8080, Remote: 9090, Address: "0.0.0.0"},
+			iface:    Localhost,
+			expected: "localhost:8080",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.f.GetDialAddress(tt.iface); result != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestForward_less(t *testing.T) {
 	tests := []struct {
 		name string
@@ -224,3 +359,129 @@ func TestForwardExtended_MarshalYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestForward_validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifest  []byte
+		expectErr string
+	}{
+		{
+			name: "tcp",
+			manifest: []byte(`name: deployment
+sync:
+  - .:/app
+forward:
+  - 8080:8080
+  - 9090:9090/tcp
+  - localPort: 5432
+    remotePort: 5432
+    protocol: TCP`),
+		},
+		{
+			name: "udp",
+			manifest: []byte(`name: deployment
+sync:
+  - .:/app
+forward:
+  - 5353:53/udp`),
+			expectErr: "kubernetes port-forward only supports TCP",
+		},
+		{
+			name: "udp-extended",
+			manifest: []byte(`name: deployment
+sync:
+  - .:/app
+forward:
+  - localPort: 5353
+    remotePort: 53
+    name: dns
+    protocol: udp`),
+			expectErr: "kubernetes port-forward only supports TCP",
+		},
+		{
+			name: "unknown",
+			manifest: []byte(`name: deployment
+sync:
+  - .:/app
+forward:
+  - 8080:8080/sctp`),
+			expectErr: "unsupported protocol 'sctp'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := Read(tt.manifest)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = dev.Validate()
+			if tt.expectErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("didn't get the expected error")
+			}
+			if !strings.Contains(err.Error(), tt.expectErr) {
+				t.Errorf("expected error containing '%s', got '%s'", tt.expectErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestOverrideForwards(t *testing.T) {
+	dev := &Dev{
+		Forward: []Forward{
+			{Local: 8080, Remote: 8080},
+			{Local: 9229, Remote: 9229},
+		},
+	}
+
+	err := dev.OverrideForwards([]Forward{
+		{Local: 8080, Remote: 3000},
+		{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Forward{
+		{Local: 8080, Remote: 3000},
+		{Local: 9229, Remote: 9229},
+		{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+	}
+	if !reflect.DeepEqual(dev.Forward, expected) {
+		t.Errorf("got %+v, expected %+v", dev.Forward, expected)
+	}
+
+	if err := dev.OverrideForwards([]Forward{{Local: 53, Remote: 53, Protocol: forwardProtocolUDP}}); err == nil {
+		t.Error("didn't get an error for an udp forward")
+	}
+}
+
+func Test_getPrivilegedPortWarnings(t *testing.T) {
+	forwards := []Forward{
+		{Local: 80, Remote: 8080},
+		{Local: 1023, Remote: 1023},
+		{Local: 1024, Remote: 80},
+		{Local: 8080, Remote: 8080, Service: true, ServiceName: "svc"},
+	}
+
+	warnings := getPrivilegedPortWarnings(forwards, "linux")
+	expected := []string{
+		"port-forward '80:8080' listens on the privileged local port 80, which may require elevated privileges on your machine",
+		"port-forward '1023:1023' listens on the privileged local port 1023, which may require elevated privileges on your machine",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+
+	if warnings := getPrivilegedPortWarnings(forwards, "windows"); len(warnings) != 0 {
+		t.Errorf("expected no warnings on windows, got %v", warnings)
+	}
+}
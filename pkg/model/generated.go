@@ -0,0 +1,56 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// GeneratedMarker identifies the files generated by okteto. It is written in the first line of the file as a comment
+const GeneratedMarker = "Generated by 'okteto init'. Delete this line to keep this file on 'okteto down --remove-manifest-artifacts'"
+
+// WriteEnvMarker identifies the dotenv files written by 'okteto up --write-env'. It is written in the first line of the file as a comment
+const WriteEnvMarker = "Generated by 'okteto up --write-env'. It is overwritten by 'okteto up' and removed by 'okteto down'"
+
+// MarkAsGenerated returns the content with a first comment line containing GeneratedMarker
+func MarkAsGenerated(content []byte, commentPrefix string) []byte {
+	return append([]byte(fmt.Sprintf("%s %s\n", commentPrefix, GeneratedMarker)), content...)
+}
+
+// IsGeneratedFile returns true if the first line of the file contains GeneratedMarker
+func IsGeneratedFile(path string) (bool, error) {
+	return firstLineContains(path, GeneratedMarker)
+}
+
+// IsWriteEnvFile returns true if the first line of the file contains WriteEnvMarker
+func IsWriteEnvFile(path string) (bool, error) {
+	return firstLineContains(path, WriteEnvMarker)
+}
+
+func firstLineContains(path, marker string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return false, scanner.Err()
+	}
+	return strings.Contains(scanner.Text(), marker), nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// legacyDev represents a manifest of cnd, the predecessor of the okteto manifest
+type legacyDev struct {
+	Name    string          `yaml:"name,omitempty"`
+	Swap    legacySwap      `yaml:"swap,omitempty"`
+	Mount   legacyMount     `yaml:"mount,omitempty"`
+	Forward []legacyForward `yaml:"forward,omitempty"`
+}
+
+type legacySwap struct {
+	Deployment legacyDeployment `yaml:"deployment,omitempty"`
+}
+
+type legacyDeployment struct {
+	Name      string   `yaml:"name,omitempty"`
+	Container string   `yaml:"container,omitempty"`
+	Image     string   `yaml:"image,omitempty"`
+	Command   []string `yaml:"command,omitempty"`
+	Args      []string `yaml:"args,omitempty"`
+}
+
+type legacyMount struct {
+	Source string `yaml:"source,omitempty"`
+	Target string `yaml:"target,omitempty"`
+}
+
+type legacyForward struct {
+	Local  int `yaml:"local,omitempty"`
+	Remote int `yaml:"remote,omitempty"`
+}
+
+type legacyForwardRaw legacyForward
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// Legacy port forwards are either 'local:remote' or a map with the 'local' and 'remote' fields
+func (f *legacyForward) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw string
+	if err := unmarshal(&raw); err == nil {
+		forward := Forward{}
+		if err := forward.UnmarshalYAML(unmarshal); err != nil {
+			return err
+		}
+		f.Local = forward.Local
+		f.Remote = forward.Remote
+		return nil
+	}
+
+	var rawForward legacyForwardRaw
+	if err := unmarshal(&rawForward); err != nil {
+		return err
+	}
+	*f = legacyForward(rawForward)
+	return nil
+}
+
+// legacyFields are the fields of a legacy manifest that are converted to the okteto manifest
+var legacyFields = map[string][]string{
+	"name":    nil,
+	"swap":    {"deployment"},
+	"mount":   {"source", "target"},
+	"forward": nil,
+}
+
+var legacyDeploymentFields = []string{"name", "container", "image", "command", "args"}
+
+// IsLegacyManifest returns if the content of a manifest uses the legacy 'swap' and 'mount' schema of cnd
+func IsLegacyManifest(bytes []byte) bool {
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(bytes, &raw); err != nil {
+		return false
+	}
+	_, hasSwap := raw["swap"]
+	_, hasMount := raw["mount"]
+	return hasSwap || hasMount
+}
+
+// MigrateLegacyManifest converts a legacy manifest of cnd into an okteto manifest.
+// It returns a warning for every legacy field that can't be converted
+func MigrateLegacyManifest(bytes []byte) (*Dev, []string, error) {
+	legacy := &legacyDev{}
+	if err := yaml.Unmarshal(bytes, legacy); err != nil {
+		return nil, nil, fmt.Errorf("invalid legacy manifest: %s", err)
+	}
+
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(bytes, &raw); err != nil {
+		return nil, nil, fmt.Errorf("invalid legacy manifest: %s", err)
+	}
+	warnings := getLegacyWarnings(raw)
+
+	d := legacy.Swap.Deployment
+	dev := &Dev{
+		Name:      d.Name,
+		Container: d.Container,
+		Forward:   []Forward{},
+	}
+	if d.Image != "" {
+		dev.Image = &BuildInfo{Name: d.Image}
+	}
+	if len(d.Command) > 0 || len(d.Args) > 0 {
+		dev.Command = Command{Values: append(append([]string{}, d.Command...), d.Args...)}
+	}
+
+	switch {
+	case dev.Name == "":
+		dev.Name = legacy.Name
+	case legacy.Name != "" && legacy.Name != dev.Name:
+		warnings = append(warnings, fmt.Sprintf("'name: %s' was replaced by the name of the deployment '%s'", legacy.Name, dev.Name))
+	}
+
+	if legacy.Mount.Source != "" || legacy.Mount.Target != "" {
+		source := legacy.Mount.Source
+		if source == "" {
+			source = "."
+		}
+		dev.Sync = Sync{
+			RescanInterval: DefaultSyncthingRescanInterval,
+			Folders:        []SyncFolder{{LocalPath: source, RemotePath: legacy.Mount.Target}},
+		}
+	}
+
+	for _, f := range legacy.Forward {
+		dev.Forward = append(dev.Forward, Forward{Local: f.Local, Remote: f.Remote})
+	}
+
+	sort.Strings(warnings)
+	return dev, warnings, nil
+}
+
+func getLegacyWarnings(raw map[string]interface{}) []string {
+	warnings := []string{}
+	for key, value := range raw {
+		subfields, ok := legacyFields[key]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("'%s' is not supported by the okteto manifest and was ignored", key))
+			continue
+		}
+		for _, k := range getUnknownKeys(value, subfields) {
+			warnings = append(warnings, fmt.Sprintf("'%s.%s' is not supported by the okteto manifest and was ignored", key, k))
+		}
+	}
+
+	if swap, ok := raw["swap"].(map[interface{}]interface{}); ok {
+		for _, k := range getUnknownKeys(swap["deployment"], legacyDeploymentFields) {
+			warnings = append(warnings, fmt.Sprintf("'swap.deployment.%s' is not supported by the okteto manifest and was ignored", k))
+		}
+	}
+
+	return warnings
+}
+
+// getUnknownKeys returns the keys of value not included in known, if value is a map and known isn't nil
+func getUnknownKeys(value interface{}, known []string) []string {
+	m, ok := value.(map[interface{}]interface{})
+	if !ok || known == nil {
+		return nil
+	}
+
+	result := []string{}
+	for k := range m {
+		key := fmt.Sprintf("%v", k)
+		found := false
+		for _, f := range known {
+			if f == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, key)
+		}
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestIsLegacyManifest(t *testing.T) {
+	var tests = []struct {
+		name     string
+		manifest string
+		expected bool
+	}{
+		{
+			name:     "legacy",
+			manifest: "swap:\n  deployment:\n    name: api\nmount:\n  source: .\n  target: /app\n",
+			expected: true,
+		},
+		{
+			name:     "okteto",
+			manifest: "name: api\nsync:\n  - .:/app\n",
+			expected: false,
+		},
+		{
+			name:     "invalid",
+			manifest: "swap: [",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsLegacyManifest([]byte(tt.manifest)); result != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMigrateLegacyManifest(t *testing.T) {
+	manifest := []byte(`name: my-app
+swap:
+  deployment:
+    name: api
+    container: app
+    image: okteto/node:11
+    command: ["yarn", "start"]
+    args: ["--inspect"]
+    file: deployment.yml
+  service:
+    file: service.yml
+mount:
+  source: src
+  target: /usr/src/app
+forward:
+  - local: 3000
+    remote: 3000
+  - 9229:9229
+scripts:
+  test: yarn test
+`)
+
+	dev, warnings, err := MigrateLegacyManifest(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Name != "api" {
+		t.Errorf("wrong name: %s", dev.Name)
+	}
+	if dev.Container != "app" {
+		t.Errorf("wrong container: %s", dev.Container)
+	}
+	if dev.Image.Name != "okteto/node:11" {
+		t.Errorf("wrong image: %s", dev.Image.Name)
+	}
+	if !reflect.DeepEqual(dev.Command.Values, []string{"yarn", "start", "--inspect"}) {
+		t.Errorf("wrong command: %v", dev.Command.Values)
+	}
+	expectedSync := []SyncFolder{{LocalPath: "src", RemotePath: "/usr/src/app"}}
+	if !reflect.DeepEqual(dev.Sync.Folders, expectedSync) {
+		t.Errorf("wrong sync folders: %v", dev.Sync.Folders)
+	}
+	expectedForward := []Forward{{Local: 3000, Remote: 3000}, {Local: 9229, Remote: 9229}}
+	if !reflect.DeepEqual(dev.Forward, expectedForward) {
+		t.Errorf("wrong forward: %v", dev.Forward)
+	}
+
+	expectedWarnings := []string{
+		"'name: my-app' was replaced by the name of the deployment 'api'",
+		"'scripts' is not supported by the okteto manifest and was ignored",
+		"'swap.deployment.file' is not supported by the okteto manifest and was ignored",
+		"'swap.service' is not supported by the okteto manifest and was ignored",
+	}
+	if !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Errorf("wrong warnings:\n%s", strings.Join(warnings, "\n"))
+	}
+
+	migrated, err := yaml.Marshal(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Read(migrated)
+	if err != nil {
+		t.Fatalf("the migrated manifest is not valid: %s\n%s", err, string(migrated))
+	}
+	if result.Name != "api" || result.Image.Name != "okteto/node:11" || len(result.Forward) != 2 || len(result.Sync.Folders) != 1 {
+		t.Errorf("wrong migrated manifest:\n%s", string(migrated))
+	}
+}
+
+func TestReadLegacyManifest(t *testing.T) {
+	_, err := Read([]byte("swap:\n  deployment:\n    name: api\nmount:\n  source: .\n  target: /app\n"))
+	if err == nil {
+		t.Fatal("expected an error reading a legacy manifest")
+	}
+	if !strings.Contains(err.Error(), "okteto migrate") {
+		t.Errorf("expected a hint to run 'okteto migrate', got '%s'", err)
+	}
+}
@@ -14,15 +14,15 @@
 package model
 
 import (
-	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/okteto/okteto/pkg/log"
 )
 
 // GetAvailablePort returns a random port that's available
 func GetAvailablePort(iface string) (int, error) {
-	address, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:0", iface))
+	address, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(iface, "0"))
 	if err != nil {
 		return 0, err
 	}
@@ -39,7 +39,7 @@ func GetAvailablePort(iface string) (int, error) {
 
 // IsPortAvailable returns true if the port is already taken
 func IsPortAvailable(iface string, port int) bool {
-	address := fmt.Sprintf("%s:%d", iface, port)
+	address := net.JoinHostPort(iface, strconv.Itoa(port))
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		log.Infof("port %s is taken: %s", address, err)
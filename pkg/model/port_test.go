@@ -51,3 +51,24 @@ func TestIsPortAvailable(t *testing.T) {
 		t.Fatalf("port %d was available", p)
 	}
 }
+
+func TestIsPortAvailableIPv6(t *testing.T) {
+	l, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 is not available: %s", err)
+	}
+	defer l.Close()
+
+	p := l.Addr().(*net.TCPAddr).Port
+	if IsPortAvailable("::1", p) {
+		t.Fatalf("port %d was available", p)
+	}
+
+	p, err = GetAvailablePort("::1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsPortAvailable("::1", p) {
+		t.Fatalf("port %d wasn't available", p)
+	}
+}
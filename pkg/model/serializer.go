@@ -22,7 +22,6 @@ import (
 	"time"
 
 	"github.com/kballard/go-shellquote"
-	"github.com/okteto/okteto/pkg/log"
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 )
@@ -38,12 +37,22 @@ type buildInfoRaw struct {
 }
 
 type syncRaw struct {
-	Compression    bool         `json:"compression" yaml:"compression"`
-	Verbose        bool         `json:"verbose" yaml:"verbose"`
-	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
-	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
-	LocalPath      string
-	RemotePath     string
+	Compression       bool         `json:"compression" yaml:"compression"`
+	Verbose           bool         `json:"verbose" yaml:"verbose"`
+	RescanInterval    int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	ReconnectInterval int          `json:"reconnectIntervalS,omitempty" yaml:"reconnectIntervalS,omitempty"`
+	UseGitignore      bool         `json:"useGitignore,omitempty" yaml:"useGitignore,omitempty"`
+	Mode              string       `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Folders           []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	LocalPath         string
+	RemotePath        string
+}
+
+type externalVolumeRaw struct {
+	Name      string `yaml:"name"`
+	SubPath   string `yaml:"subpath,omitempty"`
+	MountPath string `yaml:"mountpath"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
 }
 
 type storageResourceRaw struct {
@@ -60,8 +69,9 @@ type probesRaw struct {
 
 // lifecycleRaw represents the lifecycle info for serialization
 type lifecycleRaw struct {
-	PostStart bool `json:"postStart,omitempty" yaml:"postStart,omitempty"`
-	PostStop  bool `json:"postStop,omitempty" yaml:"postStop,omitempty"`
+	PostStart bool    `json:"postStart,omitempty" yaml:"postStart,omitempty"`
+	PostStop  bool    `json:"postStop,omitempty" yaml:"postStop,omitempty"`
+	PreStop   Command `json:"preStop,omitempty" yaml:"preStop,omitempty"`
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
@@ -185,6 +195,7 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err == nil {
 		sync.Verbose = true
 		sync.RescanInterval = DefaultSyncthingRescanInterval
+		sync.ReconnectInterval = DefaultSyncthingReconnectionInterval
 		sync.Folders = rawFolders
 		return nil
 	}
@@ -199,13 +210,16 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sync.Compression = rawSync.Compression
 	sync.Verbose = rawSync.Verbose
 	sync.RescanInterval = rawSync.RescanInterval
+	sync.ReconnectInterval = rawSync.ReconnectInterval
+	sync.UseGitignore = rawSync.UseGitignore
+	sync.Mode = rawSync.Mode
 	sync.Folders = rawSync.Folders
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && !sync.UseGitignore && sync.RescanInterval == DefaultSyncthingRescanInterval && (sync.ReconnectInterval == 0 || sync.ReconnectInterval == DefaultSyncthingReconnectionInterval) && (sync.Mode == "" || sync.Mode == SyncModeSendReceive) {
 		return sync.Folders, nil
 	}
 	return syncRaw(sync), nil
@@ -365,6 +379,14 @@ func (f *Reverse) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return fmt.Errorf("Cannot convert local port '%s' in reverse '%s'", parts[1], raw)
 	}
 
+	if err := validatePortRange("remote", remotePort, raw); err != nil {
+		return err
+	}
+
+	if err := validatePortRange("local", localPort, raw); err != nil {
+		return err
+	}
+
 	f.Local = localPort
 	f.Remote = remotePort
 	return nil
@@ -419,7 +441,6 @@ func (v *Volume) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	parts := strings.SplitN(raw, ":", 2)
 	if len(parts) == 2 {
-		log.Yellow("The syntax '%s' is deprecated in the 'volumes' field. Use the field 'sync' instead (%s)", raw, syncFieldDocsURL)
 		v.LocalPath, err = ExpandEnv(parts[0])
 		if err != nil {
 			return err
@@ -467,7 +488,18 @@ func (v *ExternalVolume) UnmarshalYAML(unmarshal func(interface{}) error) error
 	var raw string
 	err := unmarshal(&raw)
 	if err != nil {
-		return err
+		var rawVolume externalVolumeRaw
+		if err := unmarshal(&rawVolume); err != nil {
+			return err
+		}
+		if rawVolume.Name == "" || rawVolume.MountPath == "" {
+			return fmt.Errorf("external volume must define 'name' and 'mountpath'")
+		}
+		v.Name = rawVolume.Name
+		v.SubPath = rawVolume.SubPath
+		v.MountPath = rawVolume.MountPath
+		v.ReadOnly = rawVolume.ReadOnly
+		return nil
 	}
 
 	parts := strings.SplitN(raw, ":", 3)
@@ -487,6 +519,9 @@ func (v *ExternalVolume) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (v ExternalVolume) MarshalYAML() (interface{}, error) {
+	if v.ReadOnly {
+		return externalVolumeRaw(v), nil
+	}
 	if v.SubPath == "" {
 		return v.Name + ":" + v.MountPath, nil
 	}
@@ -542,12 +577,13 @@ func (l *Lifecycle) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	l.PostStart = lifecycleRaw.PostStart
 	l.PostStop = lifecycleRaw.PostStop
+	l.PreStop = lifecycleRaw.PreStop
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (l Lifecycle) MarshalYAML() (interface{}, error) {
-	if l.PostStart && l.PostStop {
+	if l.PostStart && l.PostStop && len(l.PreStop.Values) == 0 {
 		return true, nil
 	}
 	return lifecycleRaw(l), nil
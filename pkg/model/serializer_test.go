@@ -53,6 +53,16 @@ func TestReverseMashalling(t *testing.T) {
 			data:      "8080:svc",
 			expectErr: true,
 		},
+		{
+			name:      "remote-port-out-of-range",
+			data:      "808080:8080",
+			expectErr: true,
+		},
+		{
+			name:      "local-port-out-of-range",
+			data:      "8080:0",
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -331,6 +341,11 @@ func TestLifecycleMashalling(t *testing.T) {
 			lifecycle: Lifecycle{PostStart: true, PostStop: true},
 			expected:  "true\n",
 		},
+		{
+			name:      "pre-stop",
+			lifecycle: Lifecycle{PostStart: true, PostStop: true, PreStop: Command{Values: []string{"sh", "-c", "kill -TERM 1 && sleep 5"}}},
+			expected:  "postStart: true\npostStop: true\npreStop:\n- sh\n- -c\n- kill -TERM 1 && sleep 5\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -481,6 +496,57 @@ func TestVolumeMashalling(t *testing.T) {
 	}
 }
 
+func TestExternalVolumeMashalling(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected ExternalVolume
+	}{
+		{
+			"name-mountpath",
+			[]byte("pvc:/data"),
+			ExternalVolume{Name: "pvc", MountPath: "/data"},
+		},
+		{
+			"name-subpath-mountpath",
+			[]byte("pvc:sub:/data"),
+			ExternalVolume{Name: "pvc", SubPath: "sub", MountPath: "/data"},
+		},
+		{
+			"read-only",
+			[]byte("name: pvc\nsubpath: sub\nmountpath: /data\nreadOnly: true"),
+			ExternalVolume{Name: "pvc", SubPath: "sub", MountPath: "/data", ReadOnly: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v ExternalVolume
+			if err := yaml.Unmarshal(tt.data, &v); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(v, tt.expected) {
+				t.Errorf("didn't unmarshal correctly. Actual %+v, Expected %+v", v, tt.expected)
+			}
+
+			b, err := yaml.Marshal(&v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result ExternalVolume
+			if err := yaml.Unmarshal(b, &result); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("didn't marshal correctly. Actual %+v, Expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestDevMarshalling(t *testing.T) {
 	tests := []struct {
 		name     string
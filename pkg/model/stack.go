@@ -68,6 +68,7 @@ type Service struct {
 
 	Public    bool            `yaml:"public,omitempty"`
 	Replicas  int32           `yaml:"replicas,omitempty"`
+	MinReady  int32           `yaml:"minReady,omitempty"`
 	Resources *StackResources `yaml:"resources,omitempty"`
 
 	VolumeMounts []StackVolume `yaml:"-"`
@@ -339,6 +340,10 @@ func (s *Stack) validate() error {
 			return fmt.Errorf(fmt.Sprintf("Invalid service '%s': image cannot be empty", name))
 		}
 
+		if svc.MinReady < 0 || svc.MinReady > svc.Replicas {
+			return fmt.Errorf("Invalid service '%s': 'minReady' must be between 0 and the number of replicas (%d)", name, svc.Replicas)
+		}
+
 		for _, v := range svc.VolumeMounts {
 			if strings.HasPrefix(v.LocalPath, "/") {
 				s.Warnings.VolumeMountWarnings = append(s.Warnings.VolumeMountWarnings, fmt.Sprintf("[%s]: volume '%s:%s' will be ignored. You can synchronize code to your containers using 'okteto up'. More information available here: https://okteto.com/docs/reference/cli/index.html#up", name, v.LocalPath, v.RemotePath))
@@ -82,6 +82,7 @@ type ServiceRaw struct {
 
 	Public    bool            `yaml:"public,omitempty"`
 	Replicas  *int32          `yaml:"replicas"`
+	MinReady  *int32          `yaml:"minReady,omitempty"`
 	Resources *StackResources `yaml:"resources,omitempty"`
 
 	BlkioConfig       *WarningType `yaml:"blkio_config,omitempty"`
@@ -341,6 +342,10 @@ func (serviceRaw *ServiceRaw) ToService(svcName string, stack *Stack) (*Service,
 	if err != nil {
 		return nil, err
 	}
+	svc.MinReady = svc.Replicas
+	if serviceRaw.MinReady != nil {
+		svc.MinReady = *serviceRaw.MinReady
+	}
 	svc.Image, err = ExpandEnv(serviceRaw.Image)
 	if err != nil {
 		return nil, err
@@ -405,6 +405,24 @@ func TestStack_validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "min-ready-greater-than-replicas",
+			stack: &Stack{
+				Name: "name",
+				Services: map[string]*Service{
+					"app": {Image: "test", Replicas: 2, MinReady: 3},
+				},
+			},
+		},
+		{
+			name: "negative-min-ready",
+			stack: &Stack{
+				Name: "name",
+				Services: map[string]*Service{
+					"app": {Image: "test", Replicas: 2, MinReady: -1},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -480,3 +498,25 @@ func TestStack_readImageContext(t *testing.T) {
 		})
 	}
 }
+
+func Test_ReadStackMinReady(t *testing.T) {
+	manifest := []byte(`name: app
+services:
+  api:
+    image: okteto/api
+    replicas: 3
+    minReady: 1
+  worker:
+    image: okteto/worker
+    replicas: 2`)
+	s, err := ReadStack(manifest, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Services["api"].MinReady != 1 {
+		t.Errorf("expected minReady 1 for 'api', got %d", s.Services["api"].MinReady)
+	}
+	if s.Services["worker"].MinReady != 2 {
+		t.Errorf("expected minReady to default to replicas for 'worker', got %d", s.Services["worker"].MinReady)
+	}
+}
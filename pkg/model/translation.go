@@ -20,40 +20,49 @@ import (
 
 // Translation represents the information for translating a deployment
 type Translation struct {
-	Interactive bool                      `json:"interactive"`
-	Name        string                    `json:"name"`
-	Version     string                    `json:"version"`
-	Deployment  *appsv1.Deployment        `json:"-"`
-	Annotations Annotations               `json:"annotations,omitempty"`
-	Tolerations []apiv1.Toleration        `json:"tolerations,omitempty"`
-	Replicas    int32                     `json:"replicas"`
-	Strategy    appsv1.DeploymentStrategy `json:"strategy"`
-	Rules       []*TranslationRule        `json:"rules"`
+	Interactive        bool                      `json:"interactive"`
+	Name               string                    `json:"name"`
+	Version            string                    `json:"version"`
+	Deployment         *appsv1.Deployment        `json:"-"`
+	Annotations        Annotations               `json:"annotations,omitempty"`
+	PodLabels          Labels                    `json:"podLabels,omitempty"`
+	PrevPodLabels      Labels                    `json:"prevPodLabels,omitempty"`
+	PodAnnotations     Annotations               `json:"podAnnotations,omitempty"`
+	PrevPodAnnotations Annotations               `json:"prevPodAnnotations,omitempty"`
+	PullSecret         string                    `json:"pullSecret,omitempty"`
+	Tolerations        []apiv1.Toleration        `json:"tolerations,omitempty"`
+	Replicas           int32                     `json:"replicas"`
+	DevReplicas        *int32                    `json:"-"`
+	Strategy           appsv1.DeploymentStrategy `json:"strategy"`
+	Rules              []*TranslationRule        `json:"rules"`
 }
 
 // TranslationRule represents how to apply a container translation in a deployment
 type TranslationRule struct {
-	Marker            string               `json:"marker"`
-	OktetoBinImageTag string               `json:"oktetoBinImageTag"`
-	Node              string               `json:"node,omitempty"`
-	Container         string               `json:"container,omitempty"`
-	Image             string               `json:"image,omitempty"`
-	ImagePullPolicy   apiv1.PullPolicy     `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
-	Environment       Environment          `json:"environment,omitempty"`
-	Secrets           []Secret             `json:"secrets,omitempty"`
-	Command           []string             `json:"command,omitempty"`
-	Args              []string             `json:"args,omitempty"`
-	WorkDir           string               `json:"workdir"`
-	Healthchecks      bool                 `json:"healthchecks" yaml:"healthchecks"`
-	PersistentVolume  bool                 `json:"persistentVolume" yaml:"persistentVolume"`
-	Volumes           []VolumeMount        `json:"volumes,omitempty"`
-	SecurityContext   *SecurityContext     `json:"securityContext,omitempty"`
-	ServiceAccount    string               `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
-	Resources         ResourceRequirements `json:"resources,omitempty"`
-	InitContainer     InitContainer        `json:"initContainers,omitempty"`
-	Probes            *Probes              `json:"probes" yaml:"probes"`
-	Lifecycle         *Lifecycle           `json:"lifecycle" yaml:"lifecycle"`
-	Docker            DinDContainer        `json:"docker" yaml:"docker"`
+	Marker               string                `json:"marker"`
+	OktetoBinImageTag    string                `json:"oktetoBinImageTag"`
+	Node                 string                `json:"node,omitempty"`
+	Container            string                `json:"container,omitempty"`
+	Image                string                `json:"image,omitempty"`
+	ImagePullPolicy      apiv1.PullPolicy      `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	Environment          Environment           `json:"environment,omitempty"`
+	Secrets              []Secret              `json:"secrets,omitempty"`
+	Command              []string              `json:"command,omitempty"`
+	Args                 []string              `json:"args,omitempty"`
+	WorkDir              string                `json:"workdir"`
+	Healthchecks         bool                  `json:"healthchecks" yaml:"healthchecks"`
+	PersistentVolume     bool                  `json:"persistentVolume" yaml:"persistentVolume"`
+	Volumes              []VolumeMount         `json:"volumes,omitempty"`
+	SecurityContext      *SecurityContext      `json:"securityContext,omitempty"`
+	ServiceAccount       string                `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+	ServiceAccountTokens []ServiceAccountToken `json:"serviceAccountTokens,omitempty" yaml:"serviceAccountTokens,omitempty"`
+	Resources            ResourceRequirements  `json:"resources,omitempty"`
+	InitContainer        InitContainer         `json:"initContainers,omitempty"`
+	MountFromImage       *MountFromImage       `json:"mountFromImage,omitempty"`
+	Probes               *Probes               `json:"probes" yaml:"probes"`
+	Lifecycle            *Lifecycle            `json:"lifecycle" yaml:"lifecycle"`
+	Docker               DinDContainer         `json:"docker" yaml:"docker"`
+	Sidecars             []Sidecar             `json:"sidecars,omitempty" yaml:"sidecars,omitempty"`
 }
 
 // IsMainDevContainer returns true if the translation rule applies to the main dev container of the okteto manifest
@@ -66,6 +75,7 @@ type VolumeMount struct {
 	Name      string `json:"name,omitempty"`
 	MountPath string `json:"mountpath,omitempty"`
 	SubPath   string `json:"subpath,omitempty"`
+	ReadOnly  bool   `json:"readonly,omitempty"`
 }
 
 // IsSyncthing returns the volume mount is for syncthing
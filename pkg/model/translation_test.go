@@ -14,6 +14,7 @@
 package model
 
 import (
+	"os"
 	"path"
 	"reflect"
 	"testing"
@@ -384,3 +385,61 @@ func TestSSHServerPortTranslationRule(t *testing.T) {
 		}
 	}
 }
+
+func TestDevToTranslationRuleStartArgs(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+sync:
+  - .:/app
+startArgs:
+  - --debug
+  - -p
+  - "2223"`)
+
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := dev.ToTranslationRule(dev, true)
+	expected := []string{"-r", "-e", "-v", "--debug", "-p", "2223"}
+	if !reflect.DeepEqual(rule.Args, expected) {
+		t.Errorf("expected args %v, got %v", expected, rule.Args)
+	}
+
+	os.Setenv("OKTETO_EXECUTE_SSH", "false")
+	defer os.Unsetenv("OKTETO_EXECUTE_SSH")
+	rule = dev.ToTranslationRule(dev, false)
+	expected = []string{"-v", "--debug", "-p", "2223"}
+	if !reflect.DeepEqual(rule.Args, expected) {
+		t.Errorf("expected args %v in non-remote mode, got %v", expected, rule.Args)
+	}
+}
+
+func Test_validateStartArgs(t *testing.T) {
+	var tests = []struct {
+		name      string
+		args      []string
+		expectErr bool
+	}{
+		{name: "empty", args: nil},
+		{name: "custom-flags", args: []string{"--debug", "-p", "2223"}},
+		{name: "reserved-remote", args: []string{"-r"}, expectErr: true},
+		{name: "reserved-combined", args: []string{"-pv"}, expectErr: true},
+		{name: "reserved-secret", args: []string{"-s", "a:b"}, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStartArgs(tt.args)
+			if tt.expectErr && err == nil {
+				t.Errorf("expected error for %v", tt.args)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error for %v: %s", tt.args, err)
+			}
+		})
+	}
+}
@@ -15,13 +15,19 @@ package model
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
 )
 
+var validInitContainerExcludeRegex = regexp.MustCompile(`^[a-zA-Z0-9._\-/*?]+$`)
+
+var validInitContainerRefreshRegex = regexp.MustCompile(`^/[a-zA-Z0-9._\-/]*$`)
+
 func (dev *Dev) translateDeprecatedVolumeFields() error {
 	if dev.Workdir == "" && len(dev.Sync.Folders) == 0 {
 		dev.Workdir = "/okteto"
@@ -40,6 +46,17 @@ func (dev *Dev) translateDeprecatedVolumeFields() error {
 	return nil
 }
 
+func (dev *Dev) getDeprecatedVolumeWarnings() []string {
+	warnings := []string{}
+	for _, v := range dev.Volumes {
+		if v.LocalPath == "" {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("The syntax '%s:%s' is deprecated in the 'volumes' field. Use the field 'sync' instead (%s)", v.LocalPath, v.RemotePath, syncFieldDocsURL))
+	}
+	return warnings
+}
+
 func (dev *Dev) translateDeprecatedWorkdir(main *Dev) error {
 	if dev.Workdir == "" || len(dev.Sync.Folders) > 0 {
 		return nil
@@ -288,6 +305,47 @@ func (dev *Dev) validateVolumes(main *Dev) error {
 	return dev.validateServiceSyncFolders(main)
 }
 
+func (dev *Dev) validateMountFromImage() error {
+	if dev.MountFromImage == nil {
+		return nil
+	}
+	if dev.MountFromImage.Image == "" {
+		return fmt.Errorf("'mountFromImage.image' cannot be empty")
+	}
+	if !validImageReferenceRegex.MatchString(dev.MountFromImage.Image) {
+		return fmt.Errorf("'mountFromImage.image' is not a valid image reference: '%s'", dev.MountFromImage.Image)
+	}
+	if !strings.HasPrefix(dev.MountFromImage.Path, "/") {
+		return fmt.Errorf("'mountFromImage.path' must be an absolute path")
+	}
+	if !dev.PersistentVolumeEnabled() {
+		return fmt.Errorf("'mountFromImage' requires persistent volume to be enabled")
+	}
+	for _, v := range dev.Volumes {
+		if path.Clean(v.RemotePath) == path.Clean(dev.MountFromImage.Path) {
+			return nil
+		}
+	}
+	return fmt.Errorf("'mountFromImage.path' must be one of the paths defined in the 'volumes' field")
+}
+
+// validateInitContainerExclude checks that the exclusions of the volume initialization are relative paths that can be passed to 'find -path'
+func validateInitContainerExclude(patterns []string) error {
+	for _, p := range patterns {
+		if !validInitContainerExcludeRegex.MatchString(p) {
+			return fmt.Errorf("'initContainer.exclude' contains the invalid pattern '%s': only letters, numbers and the characters '._-/*?' are allowed", p)
+		}
+		if strings.HasPrefix(p, "/") {
+			return fmt.Errorf("'initContainer.exclude' contains the invalid pattern '%s': it must be relative to the volume path", p)
+		}
+		clean := path.Clean(p)
+		if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("'initContainer.exclude' contains the invalid pattern '%s': it must be a path inside the volume", p)
+		}
+	}
+	return nil
+}
+
 func (dev *Dev) validateExternalVolumes() error {
 	for _, v := range dev.ExternalVolumes {
 		if !strings.HasPrefix(v.MountPath, "/") {
@@ -296,6 +354,67 @@ func (dev *Dev) validateExternalVolumes() error {
 		if v.MountPath == "/" {
 			return fmt.Errorf("external volume '%s' mount path '/' is not supported", v.Name)
 		}
+		if !v.ReadOnly {
+			continue
+		}
+		for _, sync := range dev.Sync.Folders {
+			if v.MountPath == sync.RemotePath || strings.HasPrefix(v.MountPath, strings.TrimSuffix(sync.RemotePath, "/")+"/") {
+				return fmt.Errorf("external volume '%s' can't be read-only: it is mounted on the synchronized folder '%s'", v.Name, sync.RemotePath)
+			}
+		}
+	}
+	return nil
+}
+
+// validateInitContainerRefresh checks that the paths refreshed by the volume initialization are inside a persistent volume
+func (dev *Dev) validateInitContainerRefresh() error {
+	if len(dev.InitContainer.Refresh) == 0 {
+		return nil
+	}
+	if err := validateInitContainerRefreshPaths(dev.InitContainer.Refresh); err != nil {
+		return err
+	}
+	if !dev.PersistentVolumeEnabled() {
+		return fmt.Errorf("'initContainer.refresh' requires persistent volume to be enabled")
+	}
+
+	mountPaths := []string{}
+	for _, f := range dev.Sync.Folders {
+		mountPaths = append(mountPaths, f.RemotePath)
+	}
+	for _, v := range dev.Volumes {
+		mountPaths = append(mountPaths, v.RemotePath)
+	}
+
+	for _, p := range dev.InitContainer.Refresh {
+		inside := false
+		for _, m := range mountPaths {
+			if isSubPath(path.Clean(p), path.Clean(m)) {
+				inside = true
+				break
+			}
+		}
+		if !inside {
+			return fmt.Errorf("'initContainer.refresh' contains the path '%s', which is not inside the 'sync' or 'volumes' paths", p)
+		}
+	}
+	return nil
+}
+
+// validateInitContainerRefreshPaths checks that the paths refreshed by the volume initialization are absolute paths that can be passed to 'cp'
+func validateInitContainerRefreshPaths(paths []string) error {
+	for _, p := range paths {
+		if !validInitContainerRefreshRegex.MatchString(p) {
+			return fmt.Errorf("'initContainer.refresh' contains the invalid path '%s': it must be an absolute path with only letters, numbers and the characters '._-/'", p)
+		}
+		if path.Clean(p) == "/" {
+			return fmt.Errorf("'initContainer.refresh' cannot contain the root path")
+		}
 	}
 	return nil
 }
+
+// isSubPath returns true if p is parent or a path inside parent. Both paths must be clean
+func isSubPath(p, parent string) bool {
+	return p == parent || strings.HasPrefix(p, strings.TrimSuffix(parent, "/")+"/")
+}
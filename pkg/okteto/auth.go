@@ -154,6 +154,7 @@ func authUser(ctx context.Context, client *graphql.Client, code string) (*u, err
 		}}`, code)
 
 	req := graphql.NewRequest(q)
+	req.Header.Set("User-Agent", getUserAgent())
 	if err := client.Run(ctx, req, &user); err != nil {
 		return nil, err
 	}
@@ -16,11 +16,15 @@ package okteto
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/machinebox/graphql"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
 
@@ -28,6 +32,13 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
+const (
+	// apiTimeoutEnvVar overrides the timeout of the requests to the okteto API
+	apiTimeoutEnvVar = "OKTETO_API_TIMEOUT"
+
+	defaultAPITimeout = 60 * time.Second
+)
+
 func getClient(oktetoURL string) (*graphql.Client, error) {
 
 	u, err := parseOktetoURL(oktetoURL)
@@ -35,35 +46,96 @@ func getClient(oktetoURL string) (*graphql.Client, error) {
 		return nil, err
 	}
 
-	graphqlClient := graphql.NewClient(u)
+	graphqlClient := graphql.NewClient(u, graphql.WithHTTPClient(getHTTPClient()))
 	return graphqlClient, nil
 }
 
+func getHTTPClient() *http.Client {
+	return &http.Client{Timeout: getAPITimeout()}
+}
+
+func getAPITimeout() time.Duration {
+	v := os.Getenv(apiTimeoutEnvVar)
+	if v == "" {
+		return defaultAPITimeout
+	}
+
+	t, err := time.ParseDuration(v)
+	if err != nil || t <= 0 {
+		log.Infof("invalid value '%s' for %s, using the default timeout of %s", v, apiTimeoutEnvVar, defaultAPITimeout)
+		return defaultAPITimeout
+	}
+	return t
+}
+
+func getUserAgent() string {
+	version := config.VersionString
+	if version == "" {
+		version = "unknown"
+	}
+	return fmt.Sprintf("okteto/%s (%s; %s)", version, runtime.GOOS, runtime.GOARCH)
+}
+
+// parseOktetoURL returns the graphql endpoint of an okteto URL
 func parseOktetoURL(u string) (string, error) {
 	if u == "" {
 		return "", fmt.Errorf("the okteto URL is not set")
 	}
 
-	parsed, err := url.Parse(u)
+	oktetoURL, err := GetOktetoURL(u)
 	if err != nil {
 		return "", err
 	}
 
-	if parsed.Scheme == "" {
-		parsed.Scheme = "https"
-		parsed.Host = parsed.Path
+	parsed, err := url.Parse(oktetoURL)
+	if err != nil {
+		return "", err
 	}
 
 	parsed.Path = "graphql"
 	return parsed.String(), nil
 }
 
+// GetOktetoURL returns the okteto URL to use. The given URL has precedence over the OKTETO_URL env var, and cloud.okteto.com is used when none of them are set
+func GetOktetoURL(u string) (string, error) {
+	if u == "" {
+		u = os.Getenv("OKTETO_URL")
+	}
+	if u == "" {
+		return CloudURL, nil
+	}
+
+	if !strings.Contains(u, "://") {
+		u = fmt.Sprintf("https://%s", u)
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", fmt.Errorf("'%s' is not a valid okteto URL", u)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("'%s' is not a valid okteto URL: the scheme must be 'http' or 'https'", u)
+	}
+
+	if parsed.Host == "" {
+		return "", fmt.Errorf("'%s' is not a valid okteto URL: the host is empty", u)
+	}
+
+	return strings.TrimRight(parsed.String(), "/"), nil
+}
+
 func getRequest(q, token string) *graphql.Request {
 	req := graphql.NewRequest(q)
-	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
+	setRequestHeaders(req, token)
 	return req
 }
 
+func setRequestHeaders(req *graphql.Request, token string) {
+	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("User-Agent", getUserAgent())
+}
+
 func query(ctx context.Context, query string, result interface{}) error {
 	t, err := GetToken()
 	if err != nil {
@@ -91,7 +163,7 @@ func queryWithRequest(ctx context.Context, req *graphql.Request, result interfac
 		log.Infof("couldn't get token: %s", err)
 		return errors.ErrNotLogged
 	}
-	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", t.Token))
+	setRequestHeaders(req, t.Token)
 
 	c, err := getClient(t.URL)
 	if err != nil {
@@ -14,9 +14,17 @@
 package okteto
 
 import (
+	"context"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
 
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -90,6 +98,62 @@ func TestSetKubeConfig(t *testing.T) {
 	}
 }
 
+func TestSetKubeConfigContent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	c := &Credential{
+		Server:      "https://kubernetes.cloud.okteto.com",
+		Certificate: "-----BEGIN CERTIFICATE-----\nMIIC\n-----END CERTIFICATE-----\n",
+		Token:       "secret-token",
+		Namespace:   "cindy",
+	}
+
+	kubeConfigFile := filepath.Join(dir, "okteto", "kubeconfig.yml")
+	if err := SetKubeConfig(c, kubeConfigFile, c.Namespace, "123-123-123", "cloud_okteto_com", true); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	cfg, err := clientcmd.LoadFromFile(kubeConfigFile)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if cfg.CurrentContext != "cloud_okteto_com" {
+		t.Errorf("current context was not cloud_okteto_com, it was %s", cfg.CurrentContext)
+	}
+
+	cluster, ok := cfg.Clusters["cloud_okteto_com"]
+	if !ok {
+		t.Fatalf("the cluster wasn't written: %+v", cfg.Clusters)
+	}
+	if cluster.Server != c.Server {
+		t.Errorf("expected server %s, got %s", c.Server, cluster.Server)
+	}
+	if string(cluster.CertificateAuthorityData) != c.Certificate {
+		t.Errorf("expected certificate %s, got %s", c.Certificate, string(cluster.CertificateAuthorityData))
+	}
+
+	user, ok := cfg.AuthInfos["123-123-123"]
+	if !ok {
+		t.Fatalf("the user wasn't written: %+v", cfg.AuthInfos)
+	}
+	if user.Token != c.Token {
+		t.Errorf("expected token %s, got %s", c.Token, user.Token)
+	}
+
+	kubeContext, ok := cfg.Contexts["cloud_okteto_com"]
+	if !ok {
+		t.Fatalf("the context wasn't written: %+v", cfg.Contexts)
+	}
+	if kubeContext.Cluster != "cloud_okteto_com" || kubeContext.AuthInfo != "123-123-123" || kubeContext.Namespace != "cindy" {
+		t.Errorf("wrong context: %+v", kubeContext)
+	}
+}
+
 func TestInDevContainer(t *testing.T) {
 	v := os.Getenv("OKTETO_NAME")
 	os.Setenv("OKTETO_NAME", "")
@@ -151,3 +215,142 @@ func Test_parseOktetoURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOktetoURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		u           string
+		env         string
+		want        string
+		wantAPI     string
+		wantContext string
+		wantErr     bool
+	}{
+		{
+			name:        "default",
+			want:        CloudURL,
+			wantAPI:     "https://cloud.okteto.com/graphql",
+			wantContext: "cloud_okteto_com",
+		},
+		{
+			name:        "env",
+			env:         "https://okteto.example.com",
+			want:        "https://okteto.example.com",
+			wantAPI:     "https://okteto.example.com/graphql",
+			wantContext: "okteto_example_com",
+		},
+		{
+			name:        "flag-overrides-env",
+			u:           "https://okteto.flag.com/",
+			env:         "https://okteto.example.com",
+			want:        "https://okteto.flag.com",
+			wantAPI:     "https://okteto.flag.com/graphql",
+			wantContext: "okteto_flag_com",
+		},
+		{
+			name:        "no-schema",
+			env:         "okteto.example.com",
+			want:        "https://okteto.example.com",
+			wantAPI:     "https://okteto.example.com/graphql",
+			wantContext: "okteto_example_com",
+		},
+		{
+			name:        "ip",
+			u:           "https://192.168.0.1",
+			want:        "https://192.168.0.1",
+			wantAPI:     "https://192.168.0.1/graphql",
+			wantContext: "192_168_0_1",
+		},
+		{
+			name:    "bad-schema",
+			u:       "ftp://okteto.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "no-host",
+			u:       "https://",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("OKTETO_URL", tt.env)
+			defer os.Unsetenv("OKTETO_URL")
+
+			got, err := GetOktetoURL(tt.u)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetOktetoURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetOktetoURL() = %v, want %v", got, tt.want)
+			}
+
+			api, err := parseOktetoURL(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if api != tt.wantAPI {
+				t.Errorf("parseOktetoURL() = %v, want %v", api, tt.wantAPI)
+			}
+
+			if c := urlToContext(got); c != tt.wantContext {
+				t.Errorf("urlToContext() = %v, want %v", c, tt.wantContext)
+			}
+		})
+	}
+}
+
+func Test_getClientUserAgent(t *testing.T) {
+	v := config.VersionString
+	config.VersionString = "1.2.3"
+	defer func() {
+		config.VersionString = v
+	}()
+
+	var userAgent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer ts.Close()
+
+	c, err := getClient(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result interface{}
+	if err := c.Run(context.Background(), getRequest("query{user{id}}", "token"), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(userAgent, "okteto/1.2.3 (") {
+		t.Errorf("wrong user agent: '%s'", userAgent)
+	}
+}
+
+func Test_getHTTPClientTimeout(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "default", value: "", want: defaultAPITimeout},
+		{name: "env", value: "5s", want: 5 * time.Second},
+		{name: "invalid", value: "five", want: defaultAPITimeout},
+		{name: "negative", value: "-1s", want: defaultAPITimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(apiTimeoutEnvVar, tt.value)
+			defer os.Unsetenv(apiTimeoutEnvVar)
+
+			if got := getHTTPClient().Timeout; got != tt.want {
+				t.Errorf("expected timeout %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -57,7 +57,11 @@ func GetCredentials(ctx context.Context) (*Credential, error) {
 
 // GetClusterContext returns the k8s context names given an okteto URL
 func GetClusterContext() string {
-	u, _ := url.Parse(GetURL())
+	return urlToContext(GetURL())
+}
+
+func urlToContext(oktetoURL string) string {
+	u, _ := url.Parse(oktetoURL)
 	return strings.ReplaceAll(u.Host, ".", "_")
 }
 
@@ -56,6 +56,15 @@ func GetImageTag(image, service, namespace, oktetoRegistryURL string) string {
 	return fmt.Sprintf("%s:okteto", imageWithoutTag)
 }
 
+// ExpandOktetoDevImage expands the okteto.dev shorthand of an image to the okteto registry of the namespace
+func ExpandOktetoDevImage(image, namespace, oktetoRegistryURL string) string {
+	prefix := fmt.Sprintf("%s/", okteto.DevRegistry)
+	if oktetoRegistryURL == "" || !strings.HasPrefix(image, prefix) {
+		return image
+	}
+	return fmt.Sprintf("%s/%s/%s", oktetoRegistryURL, namespace, strings.TrimPrefix(image, prefix))
+}
+
 // GetDevImageTag returns the image tag to build and push
 func GetDevImageTag(dev *model.Dev, imageTag, imageFromDeployment, oktetoRegistryURL string) string {
 	if imageTag != "" && imageTag != model.DefaultImage {
@@ -139,6 +139,60 @@ func Test_GetImageTag(t *testing.T) {
 	}
 }
 
+func Test_ExpandOktetoDevImage(t *testing.T) {
+	var tests = []struct {
+		name              string
+		image             string
+		namespace         string
+		oktetoRegistryURL string
+		expected          string
+	}{
+		{
+			name:              "okteto-dev",
+			image:             "okteto.dev/api:1.0",
+			namespace:         "cindy",
+			oktetoRegistryURL: "registry.cloud.okteto.net",
+			expected:          "registry.cloud.okteto.net/cindy/api:1.0",
+		},
+		{
+			name:              "okteto-dev-with-digest",
+			image:             "okteto.dev/api@sha256:abc",
+			namespace:         "cindy",
+			oktetoRegistryURL: "registry.cloud.okteto.net",
+			expected:          "registry.cloud.okteto.net/cindy/api@sha256:abc",
+		},
+		{
+			name:              "docker-hub",
+			image:             "okteto/api:1.0",
+			namespace:         "cindy",
+			oktetoRegistryURL: "registry.cloud.okteto.net",
+			expected:          "okteto/api:1.0",
+		},
+		{
+			name:              "similar-registry",
+			image:             "okteto.dev.example.com/api:1.0",
+			namespace:         "cindy",
+			oktetoRegistryURL: "registry.cloud.okteto.net",
+			expected:          "okteto.dev.example.com/api:1.0",
+		},
+		{
+			name:              "not-logged",
+			image:             "okteto.dev/api:1.0",
+			namespace:         "cindy",
+			oktetoRegistryURL: "",
+			expected:          "okteto.dev/api:1.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExpandOktetoDevImage(tt.image, tt.namespace, tt.oktetoRegistryURL)
+			if tt.expected != result {
+				t.Errorf("Test '%s': expected %s got %s", tt.name, tt.expected, result)
+			}
+		})
+	}
+}
+
 func Test_GetDevImageTag(t *testing.T) {
 	var tests = []struct {
 		name                string
@@ -119,8 +119,7 @@ func ExpandOktetoDevRegistry(ctx context.Context, namespace, tag string) (string
 		return "", fmt.Errorf("cannot use the okteto.dev container registry: unable to get okteto registry url: %s", err)
 	}
 
-	tag = strings.Replace(tag, okteto.DevRegistry, fmt.Sprintf("%s/%s", oktetoRegistryURL, namespace), 1)
-	return tag, nil
+	return ExpandOktetoDevImage(tag, namespace, oktetoRegistryURL), nil
 }
 
 // SplitRegistryAndImage returns image tag and the registry to push the image
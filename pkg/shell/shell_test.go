@@ -0,0 +1,53 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shell
+
+import "testing"
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "single",
+			args:     []string{"/app"},
+			expected: "'/app'",
+		},
+		{
+			name:     "several",
+			args:     []string{"echo", "hello world"},
+			expected: "'echo' 'hello world'",
+		},
+		{
+			name:     "metacharacters",
+			args:     []string{"$(rm -rf /); `id` *"},
+			expected: "'$(rm -rf /); `id` *'",
+		},
+		{
+			name:     "single-quote",
+			args:     []string{"it's"},
+			expected: `'it'\''s'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Quote(tt.args...); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -16,7 +16,9 @@ package ssh
 import (
 	"context"
 	"fmt"
+	"net"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/okteto/okteto/pkg/errors"
@@ -52,7 +54,7 @@ func NewForwardManager(ctx context.Context, sshAddr, localInterface, remoteInter
 	}
 }
 
-func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
+func (fm *ForwardManager) canAdd(localAddress string, localPort int, checkAvailable bool) error {
 	if _, ok := fm.reverses[localPort]; ok {
 		return fmt.Errorf("port %d is listed multiple times, please check your reverse forwards configuration", localPort)
 	}
@@ -65,12 +67,12 @@ func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
 		return nil
 	}
 
-	if !model.IsPortAvailable(fm.localInterface, localPort) {
+	if !model.IsPortAvailable(localAddress, localPort) {
 		if localPort <= 1024 {
 			os := runtime.GOOS
 			switch os {
 			case "darwin":
-				if fm.localInterface == model.Localhost {
+				if localAddress == model.Localhost {
 					return fmt.Errorf("local port %d is privileged. Define 'interface: 0.0.0.0' in your okteto manifest and try again", localPort)
 				}
 			case "linux":
@@ -86,12 +88,13 @@ func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
 // Add initializes a remote forward
 func (fm *ForwardManager) Add(f model.Forward) error {
 
-	if err := fm.canAdd(f.Local, true); err != nil {
+	localAddress := f.GetLocalAddress(fm.localInterface)
+	if err := fm.canAdd(localAddress, f.Local, true); err != nil {
 		return err
 	}
 
 	fm.forwards[f.Local] = &forward{
-		localAddress:  fmt.Sprintf("%s:%d", fm.localInterface, f.Local),
+		localAddress:  net.JoinHostPort(localAddress, strconv.Itoa(f.Local)),
 		remoteAddress: fmt.Sprintf("%s:%d", fm.remoteInterface, f.Remote),
 	}
 
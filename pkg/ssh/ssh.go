@@ -31,6 +31,11 @@ func AddEntry(name, iface string, port int) error {
 	return add(getSSHConfigPath(), buildHostname(name), iface, port)
 }
 
+// GetEntry returns the entry added to the user's sshconfig by AddEntry
+func GetEntry(name, iface string, port int) string {
+	return newEntry(buildHostname(name), iface, port).String()
+}
+
 func add(path, name, iface string, port int) error {
 	cfg, err := getConfig(path)
 	if err != nil {
@@ -39,6 +44,11 @@ func add(path, name, iface string, port int) error {
 
 	_ = removeHost(cfg, name)
 
+	cfg.hosts = append(cfg.hosts, newEntry(name, iface, port))
+	return save(cfg, path)
+}
+
+func newEntry(name, iface string, port int) *host {
 	_, privateKey := getKeyPaths()
 
 	host := newHost([]string{name}, []string{"entry generated by okteto"})
@@ -51,9 +61,7 @@ func add(path, name, iface string, port int) error {
 		newParam(userKnownHostsFileKeyword, []string{"/dev/null"}, nil),
 		newParam(identityFile, []string{"\"" + privateKey + "\""}, nil),
 	}
-
-	cfg.hosts = append(cfg.hosts, host)
-	return save(cfg, path)
+	return host
 }
 
 // RemoveEntry removes the entry to the user's sshconfig if found
@@ -14,6 +14,7 @@
 package ssh
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -258,6 +259,37 @@ func TestGetPort(t *testing.T) {
 
 }
 
+func TestGetEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := os.Setenv("OKTETO_FOLDER", dir); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Unsetenv("OKTETO_FOLDER")
+
+	entry := GetEntry("web", model.Localhost, 22100)
+	expected := fmt.Sprintf(`# entry generated by okteto
+Host web.okteto
+  ForwardAgent yes
+  PubkeyAcceptedKeyTypes +ssh-rsa
+  HostName %s
+  Port 22100
+  StrictHostKeyChecking no
+  UserKnownHostsFile /dev/null
+  IdentityFile "%s"
+`, model.Localhost, filepath.Join(dir, privateKeyFile))
+
+	if entry != expected {
+		t.Errorf("got entry:\n%s\nexpected:\n%s", entry, expected)
+	}
+}
+
 func Test_getSSHConfigPath(t *testing.T) {
 	ssh := getSSHConfigPath()
 	parts := strings.Split(ssh, string(os.PathSeparator))
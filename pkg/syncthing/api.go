@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"path"
 	"strings"
@@ -43,6 +44,24 @@ func NewAPIClient() *http.Client {
 	}
 }
 
+// newSocketAPIClient returns a syncthing api client that calls the local syncthing api on a unix socket
+func newSocketAPIClient(socket string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dial := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if addr == fmt.Sprintf("%s:80", socketHost) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		}
+		return dial(ctx, network, addr)
+	}
+
+	return &http.Client{
+		Timeout:   60 * time.Second,
+		Transport: &addAPIKeyTransport{transport},
+	}
+}
+
 // APICall calls the syncthing API and returns the parsed json or an error
 func (s *Syncthing) APICall(ctx context.Context, url, method string, code int, params map[string]string, local bool, body []byte, readBody bool, maxRetries int) ([]byte, error) {
 	retries := 0
@@ -75,7 +94,7 @@ func (s *Syncthing) APICall(ctx context.Context, url, method string, code int, p
 func (s *Syncthing) callWithRetry(ctx context.Context, url, method string, code int, params map[string]string, local bool, body []byte, readBody bool) ([]byte, error) {
 	var urlPath string
 	if local {
-		urlPath = path.Join(s.GUIAddress, url)
+		urlPath = path.Join(s.getLocalGUIHost(), url)
 		s.Client.Timeout = 5 * time.Second
 	} else {
 		urlPath = path.Join(s.RemoteGUIAddress, url)
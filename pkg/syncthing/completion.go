@@ -172,3 +172,58 @@ func (wfc *waitForCompletion) isCompleted() bool {
 	}
 	return true
 }
+
+// WaitForIdle rescans the local folders and waits until the remote device doesn't need any local change
+func (s *Syncthing) WaitForIdle(ctx context.Context) error {
+	for _, folder := range s.Folders {
+		params := map[string]string{"folder": GetFolderName(folder)}
+		if _, err := s.APICall(ctx, "rest/db/scan", "POST", 200, params, true, nil, false, 3); err != nil {
+			log.Infof("error posting 'rest/db/scan' syncthing API: %s", err)
+		}
+	}
+
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		idle, err := s.isIdle(ctx)
+		if ctx.Err() != nil {
+			log.Info("call to syncthing.WaitForIdle canceled")
+			return ctx.Err()
+		}
+		if err != nil && err != errors.ErrBusySyncthing {
+			return err
+		}
+		if idle {
+			log.Info("syncthing is idle")
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			log.Info("call to syncthing.WaitForIdle canceled")
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Syncthing) isIdle(ctx context.Context) (bool, error) {
+	for _, folder := range s.Folders {
+		status, err := s.GetStatus(ctx, folder, true)
+		if err != nil {
+			return false, err
+		}
+		if status.State != "idle" {
+			log.Infof("syncthing folder '%s' is '%s'", folder.LocalPath, status.State)
+			return false, nil
+		}
+	}
+
+	completion, err := s.GetCompletion(ctx, true, DefaultRemoteDeviceID)
+	if err != nil {
+		return false, err
+	}
+	log.Infof("syncthing pending changes: needBytes %d, needItems %d, needDeletes %d", completion.NeedBytes, completion.NeedItems, completion.NeedDeletes)
+	return completion.NeedBytes == 0 && completion.NeedItems == 0 && completion.NeedDeletes == 0, nil
+}
@@ -14,7 +14,14 @@
 package syncthing
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func Test_needsDatabaseReset(t *testing.T) {
@@ -279,3 +286,54 @@ func Test_isCompleted(t *testing.T) {
 		})
 	}
 }
+
+func newIdleTestServer(t *testing.T, pendingCalls int32) (*Syncthing, *int32) {
+	t.Helper()
+	var completionCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/db/scan":
+		case "/rest/db/status":
+			fmt.Fprint(w, `{"state": "idle"}`)
+		case "/rest/db/completion":
+			needBytes := 0
+			if atomic.AddInt32(&completionCalls, 1) <= pendingCalls {
+				needBytes = 1024
+			}
+			fmt.Fprintf(w, `{"needBytes": %d, "needItems": %d}`, needBytes, needBytes/1024)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	s := &Syncthing{
+		GUIAddress: strings.TrimPrefix(server.URL, "http://"),
+		Client:     NewAPIClient(),
+		Folders:    []*Folder{{Name: "1", LocalPath: "/local", RemotePath: "/app"}},
+	}
+	return s, &completionCalls
+}
+
+func TestWaitForIdle(t *testing.T) {
+	s, completionCalls := newIdleTestServer(t, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.WaitForIdle(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if calls := atomic.LoadInt32(completionCalls); calls != 3 {
+		t.Errorf("got %d completion calls, expected 3", calls)
+	}
+}
+
+func TestWaitForIdleTimeout(t *testing.T) {
+	s, _ := newIdleTestServer(t, 1000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.WaitForIdle(ctx); err != context.DeadlineExceeded {
+		t.Errorf("got error '%v', expected '%s'", err, context.DeadlineExceeded)
+	}
+}
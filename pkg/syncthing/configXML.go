@@ -67,7 +67,7 @@ const configXML = `<configuration version="32">
     <localAnnounceEnabled>false</localAnnounceEnabled>
     <maxSendKbps>0</maxSendKbps>
     <maxRecvKbps>0</maxRecvKbps>
-    <reconnectionIntervalS>1</reconnectionIntervalS>
+    <reconnectionIntervalS>{{ .ReconnectInterval }}</reconnectionIntervalS>
     <relaysEnabled>false</relaysEnabled>
     <startBrowser>false</startBrowser>
     <natEnabled>false</natEnabled>
@@ -14,6 +14,7 @@
 package syncthing
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -22,9 +23,11 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	getter "github.com/hashicorp/go-getter"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 )
@@ -32,9 +35,18 @@ import (
 const (
 	syncthingVersion       = "1.18.0"
 	syncthingVersionEnvVar = "OKTETO_SYNCTHING_VERSION"
+	installLockFile        = "syncthing.lock"
 )
 
 var (
+	errInstallLocked = errors.New("syncthing is being installed by another process")
+
+	// installLockRetryInterval is how often a process waiting for the install lock checks it again
+	installLockRetryInterval = 500 * time.Millisecond
+
+	// installLockTimeout is how old a lock file has to be to consider that its owner died while installing
+	installLockTimeout = 5 * time.Minute
+
 	versionRegex       = regexp.MustCompile(`syncthing v(\d+\.\d+\.\d+)(-rc\.[0-9])?.*`)
 	downloadURLFormats = map[string]string{
 		"linux":       "https://github.com/syncthing/syncthing/releases/download/v%[1]s/syncthing-linux-amd64-v%[1]s.tar.gz",
@@ -46,6 +58,70 @@ var (
 	}
 )
 
+// InstallWithLock installs syncthing locally while holding a lock file in the okteto home.
+// If another okteto process is already installing syncthing, it waits for it to finish and reuses the installed binary
+func InstallWithLock(p getter.ProgressTracker) error {
+	lockPath := filepath.Join(config.GetOktetoHome(), installLockFile)
+	return installWithLock(lockPath, func() error { return Install(p) }, ShouldUpgrade)
+}
+
+func installWithLock(lockPath string, install func() error, shouldInstall func() bool) error {
+	waiting := false
+	for {
+		unlock, err := lockInstall(lockPath)
+		if err == nil {
+			defer unlock()
+			if !shouldInstall() {
+				log.Infof("syncthing is already installed")
+				return nil
+			}
+			return install()
+		}
+
+		if err != errInstallLocked {
+			return err
+		}
+
+		if !waiting {
+			log.Infof("waiting for another okteto process to install syncthing")
+			waiting = true
+		}
+		time.Sleep(installLockRetryInterval)
+	}
+}
+
+// lockInstall atomically creates the lock file, returning errInstallLocked if another process holds it
+func lockInstall(lockPath string) (func(), error) {
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file %s: %s", lockPath, err)
+		}
+
+		info, err := os.Stat(lockPath)
+		if err == nil && time.Since(info.ModTime()) > installLockTimeout {
+			log.Infof("removing stale lock file %s", lockPath)
+			if err := os.Remove(lockPath); err != nil {
+				log.Infof("failed to remove stale lock file %s: %s", lockPath, err)
+			}
+		}
+		return nil, errInstallLocked
+	}
+
+	if _, err := fmt.Fprintf(f, "%d", os.Getpid()); err != nil {
+		log.Infof("failed to write pid to lock file %s: %s", lockPath, err)
+	}
+	if err := f.Close(); err != nil {
+		log.Infof("failed to close lock file %s: %s", lockPath, err)
+	}
+
+	return func() {
+		if err := os.Remove(lockPath); err != nil {
+			log.Infof("failed to remove lock file %s: %s", lockPath, err)
+		}
+	}, nil
+}
+
 // Install installs syncthing locally
 func Install(p getter.ProgressTracker) error {
 	log.Infof("installing syncthing for %s/%s", runtime.GOOS, runtime.GOARCH)
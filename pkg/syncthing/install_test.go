@@ -15,10 +15,15 @@ package syncthing
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 )
@@ -213,3 +218,83 @@ func TestGetMinimumVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_installWithLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(interval time.Duration) { installLockRetryInterval = interval }(installLockRetryInterval)
+	installLockRetryInterval = 10 * time.Millisecond
+	lockPath := filepath.Join(dir, installLockFile)
+
+	var installs int32
+	install := func() error {
+		atomic.AddInt32(&installs, 1)
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}
+	shouldInstall := func() bool {
+		return atomic.LoadInt32(&installs) == 0
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- installWithLock(lockPath, install, shouldInstall)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if installs != 1 {
+		t.Errorf("expected 1 install, got %d", installs)
+	}
+
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("lock file wasn't removed: %v", err)
+	}
+}
+
+func Test_lockInstallStale(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lockPath := filepath.Join(dir, installLockFile)
+	if err := ioutil.WriteFile(lockPath, []byte("1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := lockInstall(lockPath); err != errInstallLocked {
+		t.Fatalf("expected errInstallLocked, got %v", err)
+	}
+
+	old := time.Now().Add(-2 * installLockTimeout)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := lockInstall(lockPath); err != errInstallLocked {
+		t.Fatalf("expected errInstallLocked, got %v", err)
+	}
+
+	unlock, err := lockInstall(lockPath)
+	if err != nil {
+		t.Fatalf("expected the stale lock to be removed, got %v", err)
+	}
+	unlock()
+}
@@ -51,6 +51,7 @@ const (
 	keyFile    = "key.pem"
 	configFile = "config.xml"
 	logFile    = "syncthing.log"
+	infoFile   = "syncthing.info"
 
 	// DefaultRemoteDeviceID remote syncthing device ID
 	DefaultRemoteDeviceID = "ATOPHFJ-VPVLDFY-QVZDCF2-OQQ7IOW-OG4DIXF-OA7RWU3-ZYA4S22-SI4XVAU"
@@ -60,43 +61,55 @@ const (
 	// DefaultFileWatcherDelay how much to wait before starting a sync after a file change
 	DefaultFileWatcherDelay = 5
 
-	// ClusterPort is the port used by syncthing in the cluster
-	ClusterPort = 22000
+	// guiSocketEnvVar binds the local syncthing GUI to a unix socket instead of a TCP port
+	guiSocketEnvVar  = "OKTETO_SYNCTHING_GUI_SOCKET"
+	guiSocketFile    = "gui.sock"
+	unixSocketScheme = "unix://"
 
-	// GUIPort is the port used by syncthing in the cluster for the http endpoint
-	GUIPort = 8384
+	// socketHost is the host used in the requests to the local syncthing GUI when it listens on a unix socket
+	socketHost = "syncthing.sock"
+
+	// maxSocketPathLength is the maximum length of a unix socket path supported by all the platforms
+	maxSocketPathLength = 104
+
+	// minPingAttempts, initialPingBackoff and maxPingBackoff configure the retries of WaitForPing
+	minPingAttempts    = 5
+	initialPingBackoff = 200 * time.Millisecond
+	maxPingBackoff     = 3 * time.Second
 )
 
 // Syncthing represents the local syncthing process.
 type Syncthing struct {
-	APIKey           string        `yaml:"apikey"`
-	GUIPassword      string        `yaml:"password"`
-	GUIPasswordHash  string        `yaml:"-"`
-	binPath          string        `yaml:"-"`
-	Client           *http.Client  `yaml:"-"`
-	cmd              *exec.Cmd     `yaml:"-"`
-	Folders          []*Folder     `yaml:"folders"`
-	FileWatcherDelay int           `yaml:"-"`
-	ForceSendOnly    bool          `yaml:"-"`
-	ResetDatabase    bool          `yaml:"-"`
-	GUIAddress       string        `yaml:"local"`
-	Home             string        `yaml:"-"`
-	LogPath          string        `yaml:"-"`
-	ListenAddress    string        `yaml:"-"`
-	RemoteAddress    string        `yaml:"-"`
-	RemoteDeviceID   string        `yaml:"-"`
-	RemoteGUIAddress string        `yaml:"remote"`
-	RemoteGUIPort    int           `yaml:"-"`
-	RemotePort       int           `yaml:"-"`
-	LocalGUIPort     int           `yaml:"-"`
-	LocalPort        int           `yaml:"-"`
-	Type             string        `yaml:"-"`
-	IgnoreDelete     bool          `yaml:"-"`
-	Verbose          bool          `yaml:"-"`
-	pid              int           `yaml:"-"`
-	RescanInterval   string        `yaml:"-"`
-	Compression      string        `yaml:"-"`
-	timeout          time.Duration `yaml:"-"`
+	APIKey            string        `yaml:"apikey"`
+	GUIPassword       string        `yaml:"password"`
+	GUIPasswordHash   string        `yaml:"-"`
+	binPath           string        `yaml:"-"`
+	Client            *http.Client  `yaml:"-"`
+	cmd               *exec.Cmd     `yaml:"-"`
+	Folders           []*Folder     `yaml:"folders"`
+	FileWatcherDelay  int           `yaml:"-"`
+	ForceSendOnly     bool          `yaml:"-"`
+	ResetDatabase     bool          `yaml:"-"`
+	GUIAddress        string        `yaml:"local"`
+	Home              string        `yaml:"-"`
+	LogPath           string        `yaml:"-"`
+	ListenAddress     string        `yaml:"-"`
+	RemoteAddress     string        `yaml:"-"`
+	RemoteDeviceID    string        `yaml:"-"`
+	RemoteGUIAddress  string        `yaml:"remote"`
+	Pod               string        `yaml:"pod,omitempty"`
+	RemoteGUIPort     int           `yaml:"-"`
+	RemotePort        int           `yaml:"-"`
+	LocalGUIPort      int           `yaml:"-"`
+	LocalPort         int           `yaml:"-"`
+	Type              string        `yaml:"-"`
+	IgnoreDelete      bool          `yaml:"-"`
+	Verbose           bool          `yaml:"-"`
+	pid               int           `yaml:"-"`
+	RescanInterval    string        `yaml:"-"`
+	ReconnectInterval string        `yaml:"-"`
+	Compression       string        `yaml:"-"`
+	timeout           time.Duration `yaml:"-"`
 }
 
 //Folder represents a sync folder
@@ -141,6 +154,13 @@ type ItemEvent struct {
 // Connections represents syncthing connections.
 type Connections struct {
 	Connections map[string]Connection `json:"connections"`
+	Total       ConnectionsTotal      `json:"total"`
+}
+
+// ConnectionsTotal represents the bytes transferred by all the syncthing connections.
+type ConnectionsTotal struct {
+	InBytesTotal  int64 `json:"inBytesTotal"`
+	OutBytesTotal int64 `json:"outBytesTotal"`
 }
 
 // Connection represents syncthing connection.
@@ -166,9 +186,19 @@ func New(dev *model.Dev) (*Syncthing, error) {
 		return nil, err
 	}
 
-	guiPort, err := model.GetAvailablePort(dev.Interface)
-	if err != nil {
-		return nil, err
+	home := config.GetDevHome(dev)
+	client := NewAPIClient()
+	guiAddress := ""
+	guiPort := 0
+	if socket, ok := getGUISocket(home, runtime.GOOS); ok {
+		guiAddress = unixSocketScheme + socket
+		client = newSocketAPIClient(socket)
+	} else {
+		guiPort, err = model.GetAvailablePort(dev.Interface)
+		if err != nil {
+			return nil, err
+		}
+		guiAddress = fmt.Sprintf("%s:%d", dev.Interface, guiPort)
 	}
 
 	listenPort, err := model.GetAvailablePort(dev.Interface)
@@ -176,42 +206,38 @@ func New(dev *model.Dev) (*Syncthing, error) {
 		return nil, err
 	}
 
-	pwd := uuid.New().String()
-	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), 0)
-	if err != nil {
-		log.Infof("couldn't hash the password %s", err)
-		hash = []byte("")
-	}
+	pwd, hash := generateGUIPassword()
 
 	compression := "metadata"
 	if dev.Sync.Compression {
 		compression = "always"
 	}
 	s := &Syncthing{
-		APIKey:           "cnd",
-		GUIPassword:      pwd,
-		GUIPasswordHash:  string(hash),
-		binPath:          fullPath,
-		Client:           NewAPIClient(),
-		FileWatcherDelay: DefaultFileWatcherDelay,
-		GUIAddress:       fmt.Sprintf("%s:%d", dev.Interface, guiPort),
-		Home:             config.GetDeploymentHome(dev.Namespace, dev.Name),
-		LogPath:          GetLogFile(dev.Namespace, dev.Name),
-		ListenAddress:    fmt.Sprintf("%s:%d", dev.Interface, listenPort),
-		RemoteAddress:    fmt.Sprintf("tcp://%s:%d", dev.Interface, remotePort),
-		RemoteDeviceID:   DefaultRemoteDeviceID,
-		RemoteGUIAddress: fmt.Sprintf("%s:%d", dev.Interface, remoteGUIPort),
-		LocalGUIPort:     guiPort,
-		LocalPort:        listenPort,
-		RemoteGUIPort:    remoteGUIPort,
-		RemotePort:       remotePort,
-		Type:             "sendonly",
-		IgnoreDelete:     true,
-		Verbose:          dev.Sync.Verbose,
-		Folders:          []*Folder{},
-		RescanInterval:   strconv.Itoa(dev.Sync.RescanInterval),
-		Compression:      compression,
-		timeout:          time.Duration(dev.Timeout.Default),
+		APIKey:            "cnd",
+		GUIPassword:       pwd,
+		GUIPasswordHash:   hash,
+		binPath:           fullPath,
+		Client:            client,
+		FileWatcherDelay:  DefaultFileWatcherDelay,
+		GUIAddress:        guiAddress,
+		Home:              home,
+		LogPath:           GetLogFile(dev),
+		ListenAddress:     fmt.Sprintf("%s:%d", dev.Interface, listenPort),
+		RemoteAddress:     fmt.Sprintf("tcp://%s:%d", dev.Interface, remotePort),
+		RemoteDeviceID:    DefaultRemoteDeviceID,
+		RemoteGUIAddress:  fmt.Sprintf("%s:%d", dev.Interface, remoteGUIPort),
+		LocalGUIPort:      guiPort,
+		LocalPort:         listenPort,
+		RemoteGUIPort:     remoteGUIPort,
+		RemotePort:        remotePort,
+		Type:              "sendonly",
+		IgnoreDelete:      true,
+		Verbose:           dev.Sync.Verbose,
+		Folders:           []*Folder{},
+		RescanInterval:    strconv.Itoa(dev.Sync.RescanInterval),
+		ReconnectInterval: strconv.Itoa(dev.Sync.ReconnectInterval),
+		Compression:       compression,
+		timeout:           time.Duration(dev.Timeout.Default),
 	}
 	index := 1
 	for _, sync := range dev.Sync.Folders {
@@ -235,6 +261,59 @@ func New(dev *model.Dev) (*Syncthing, error) {
 	return s, nil
 }
 
+// getGUISocket returns the unix socket for the local syncthing GUI when it's enabled and supported by the platform
+func getGUISocket(home, goos string) (string, bool) {
+	if goos == "windows" {
+		return "", false
+	}
+
+	v := os.Getenv(guiSocketEnvVar)
+	if v == "" {
+		return "", false
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Infof("invalid value '%s' for %s: %s", v, guiSocketEnvVar, err)
+		return "", false
+	}
+	if !enabled {
+		return "", false
+	}
+
+	socket := filepath.Join(home, guiSocketFile)
+	if len(socket) > maxSocketPathLength {
+		log.Infof("unix socket path '%s' is too long, using a TCP port for the syncthing GUI", socket)
+		return "", false
+	}
+	return socket, true
+}
+
+// GetGUIURL returns the url of a syncthing GUI address. Unix socket addresses already include their scheme
+func GetGUIURL(address string) string {
+	if strings.HasPrefix(address, unixSocketScheme) {
+		return address
+	}
+	return "http://" + address
+}
+
+// getLocalGUIHost returns the host of the requests to the local syncthing GUI
+func (s *Syncthing) getLocalGUIHost() string {
+	if strings.HasPrefix(s.GUIAddress, unixSocketScheme) {
+		return socketHost
+	}
+	return s.GUIAddress
+}
+
+func generateGUIPassword() (string, string) {
+	pwd := uuid.New().String()
+	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), 0)
+	if err != nil {
+		log.Infof("couldn't hash the password %s", err)
+		hash = []byte("")
+	}
+	return pwd, string(hash)
+}
+
 func (s *Syncthing) initConfig() error {
 	if err := os.MkdirAll(s.Home, 0700); err != nil {
 		return fmt.Errorf("failed to create %s: %s", s.Home, err)
@@ -309,31 +388,42 @@ func (s *Syncthing) Run(ctx context.Context) error {
 	return nil
 }
 
-//WaitForPing waits for syncthing to be ready
+// WaitForPing waits for syncthing to be ready, retrying with an exponential backoff.
+// It gives up once the timeout is exceeded and syncthing has been pinged at least minPingAttempts times
 func (s *Syncthing) WaitForPing(ctx context.Context, local bool) error {
-	ticker := time.NewTicker(300 * time.Millisecond)
 	to := time.Now().Add(s.timeout)
+	backoff := initialPingBackoff
 
 	log.Infof("waiting for syncthing local=%t to be ready", local)
-	for retries := 0; ; retries++ {
-		select {
-		case <-ticker.C:
-			if s.Ping(ctx, local) {
-				return nil
-			}
-			if retries%5 == 0 {
-				log.Infof("syncthing local=%t is not ready yet", local)
-			}
+	for attempt := 1; ; attempt++ {
+		if s.Ping(ctx, local) {
+			return nil
+		}
 
-			if time.Now().After(to) && retries > 10 {
-				return fmt.Errorf("syncthing local=%t didn't respond after %s", local, s.timeout.String())
-			}
+		if attempt >= minPingAttempts && time.Now().After(to) {
+			return fmt.Errorf("syncthing local=%t didn't respond after %s", local, s.timeout.String())
+		}
 
+		log.Debugf("syncthing local=%t is not ready yet (attempt %d), retrying in %s", local, attempt, backoff)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
 		case <-ctx.Done():
+			timer.Stop()
 			log.Infof("syncthing.WaitForPing cancelled local=%t", local)
 			return ctx.Err()
 		}
+		backoff = nextPingBackoff(backoff)
+	}
+}
+
+// nextPingBackoff doubles the backoff between pings, up to maxPingBackoff
+func nextPingBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > maxPingBackoff {
+		return maxPingBackoff
 	}
+	return backoff
 }
 
 //Ping checks if syncthing is available
@@ -465,6 +555,19 @@ func (s *Syncthing) waitForFolderScanning(ctx context.Context, folder *Folder, l
 	}
 }
 
+// GetTransferredBytes returns the bytes received and sent by the local syncthing since it started
+func (s *Syncthing) GetTransferredBytes(ctx context.Context) (int64, int64, error) {
+	connections := &Connections{}
+	body, err := s.APICall(ctx, "rest/system/connections", "GET", 200, nil, true, nil, true, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := json.Unmarshal(body, connections); err != nil {
+		return 0, 0, err
+	}
+	return connections.Total.InBytesTotal, connections.Total.OutBytesTotal, nil
+}
+
 // GetCompletion returns the syncthing completion
 func (s *Syncthing) GetCompletion(ctx context.Context, local bool, device string) (*Completion, error) {
 	params := map[string]string{"device": device}
@@ -720,7 +823,7 @@ func (s *Syncthing) SaveConfig(dev *model.Dev) error {
 		return err
 	}
 
-	syncthingInfoFile := getInfoFile(dev.Namespace, dev.Name)
+	syncthingInfoFile := getInfoFile(dev)
 	if err := ioutil.WriteFile(syncthingInfoFile, marshalled, 0600); err != nil {
 		return fmt.Errorf("failed to write syncthing info file: %w", err)
 	}
@@ -730,7 +833,7 @@ func (s *Syncthing) SaveConfig(dev *model.Dev) error {
 
 // Load loads the syncthing object from the dev home folder
 func Load(dev *model.Dev) (*Syncthing, error) {
-	syncthingInfoFile := getInfoFile(dev.Namespace, dev.Name)
+	syncthingInfoFile := getInfoFile(dev)
 	b, err := ioutil.ReadFile(syncthingInfoFile)
 	if err != nil {
 		return nil, err
@@ -743,6 +846,10 @@ func Load(dev *model.Dev) (*Syncthing, error) {
 		return nil, err
 	}
 
+	if strings.HasPrefix(s.GUIAddress, unixSocketScheme) {
+		s.Client = newSocketAPIClient(strings.TrimPrefix(s.GUIAddress, unixSocketScheme))
+	}
+
 	return s, nil
 }
 
@@ -763,7 +870,7 @@ func RemoveFolder(dev *model.Dev) error {
 		return nil
 	}
 
-	if err := os.RemoveAll(s.Home); err != nil {
+	if err := removeHome(s.Home); err != nil {
 		log.Infof("failed to remote syncthing home directory at %s: %s", s.Home, err)
 		return nil
 	}
@@ -789,6 +896,34 @@ func RemoveFolder(dev *model.Dev) error {
 	return nil
 }
 
+// removeHome deletes a syncthing home, except the syncthing homes of containers nested in it
+func removeHome(home string) error {
+	entries, err := ioutil.ReadDir(home)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, e := range entries {
+		p := filepath.Join(home, e.Name())
+		if e.IsDir() && model.FileExists(filepath.Join(p, configFile)) {
+			log.Infof("keeping syncthing home %s", p)
+			continue
+		}
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+	}
+
+	empty, err := isDirEmpty(home)
+	if err != nil || !empty {
+		return err
+	}
+	return os.Remove(home)
+}
+
 func isDirEmpty(path string) (bool, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -823,11 +958,11 @@ func GetFolderName(folder *Folder) string {
 	return fmt.Sprintf("okteto-%s", folder.Name)
 }
 
-func getInfoFile(namespace, name string) string {
-	return filepath.Join(config.GetDeploymentHome(namespace, name), "syncthing.info")
+func getInfoFile(dev *model.Dev) string {
+	return filepath.Join(config.GetDevHome(dev), infoFile)
 }
 
 // GetLogFile returns the path to the syncthing log file
-func GetLogFile(namespace, name string) string {
-	return filepath.Join(config.GetDeploymentHome(namespace, name), "syncthing.log")
+func GetLogFile(dev *model.Dev) string {
+	return filepath.Join(config.GetDevHome(dev), logFile)
 }
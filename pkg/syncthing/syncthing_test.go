@@ -14,10 +14,22 @@
 package syncthing
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestGetFiles(t *testing.T) {
@@ -32,16 +44,310 @@ func TestGetFiles(t *testing.T) {
 	}()
 
 	os.Setenv("OKTETO_FOLDER", dir)
-	log := GetLogFile("test", "application")
+	dev := &model.Dev{Namespace: "test", Name: "application"}
+	log := GetLogFile(dev)
 	expected := filepath.Join(dir, "test", "application", "syncthing.log")
 
 	if log != expected {
 		t.Errorf("got %s, expected %s", log, expected)
 	}
 
-	info := getInfoFile("test", "application")
+	info := getInfoFile(dev)
 	expected = filepath.Join(dir, "test", "application", "syncthing.info")
 	if info != expected {
 		t.Errorf("got %s, expected %s", info, expected)
 	}
 }
+
+func TestGetInfoFileContainers(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("OKTETO_FOLDER")
+	}()
+
+	os.Setenv("OKTETO_FOLDER", dir)
+	api := &model.Dev{Namespace: "test", Name: "application", ManifestContainer: "api"}
+	worker := &model.Dev{Namespace: "test", Name: "application", ManifestContainer: "worker"}
+
+	if getInfoFile(api) == getInfoFile(worker) {
+		t.Errorf("devs with different containers share the syncthing info file %s", getInfoFile(api))
+	}
+}
+
+func Test_removeHome(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	containerHome := filepath.Join(dir, "api")
+	index := filepath.Join(dir, "index-v0.14.0.db")
+	for _, d := range []string{containerHome, index} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{filepath.Join(dir, configFile), filepath.Join(dir, infoFile), filepath.Join(containerHome, configFile)} {
+		if err := ioutil.WriteFile(f, []byte(""), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := removeHome(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(containerHome, configFile)); err != nil {
+		t.Errorf("the syncthing home of the container was removed: %s", err)
+	}
+	for _, p := range []string{index, filepath.Join(dir, configFile), filepath.Join(dir, infoFile)} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%s wasn't removed", p)
+		}
+	}
+
+	if err := removeHome(containerHome); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(containerHome); !os.IsNotExist(err) {
+		t.Errorf("%s wasn't removed", containerHome)
+	}
+}
+
+func TestUpdateConfigReconnectInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &Syncthing{
+		Home:              dir,
+		Type:              "sendonly",
+		RescanInterval:    "300",
+		ReconnectInterval: "10",
+		Folders:           []*Folder{{Name: "1", LocalPath: dir, RemotePath: "/app"}},
+	}
+	if err := s.UpdateConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, configFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "<reconnectionIntervalS>10</reconnectionIntervalS>") {
+		t.Errorf("config.xml doesn't contain the custom reconnection interval:\n%s", string(b))
+	}
+}
+
+func TestUpdateConfigGUIPassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &Syncthing{
+		Home:              dir,
+		Type:              "sendonly",
+		RescanInterval:    "300",
+		ReconnectInterval: "10",
+		Folders:           []*Folder{{Name: "1", LocalPath: dir, RemotePath: "/app"}},
+	}
+	s.GUIPassword, s.GUIPasswordHash = generateGUIPassword()
+	previousPassword := s.GUIPassword
+	previousHash := s.GUIPasswordHash
+
+	s.GUIPassword, s.GUIPasswordHash = generateGUIPassword()
+	if s.GUIPassword == previousPassword {
+		t.Errorf("the password wasn't regenerated")
+	}
+	if s.GUIPasswordHash == previousHash {
+		t.Errorf("the password hash wasn't regenerated")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(s.GUIPasswordHash), []byte(s.GUIPassword)); err != nil {
+		t.Errorf("the password hash doesn't match the password: %s", err)
+	}
+
+	if err := s.UpdateConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, configFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), s.GUIPasswordHash) {
+		t.Errorf("config.xml doesn't contain the new password hash:\n%s", string(b))
+	}
+	if strings.Contains(string(b), previousHash) {
+		t.Errorf("config.xml still contains the previous password hash")
+	}
+}
+
+func TestGetGUISocket(t *testing.T) {
+	home := "/home/okteto/.okteto/ns/dev/.syncthing"
+	var tests = []struct {
+		name     string
+		home     string
+		goos     string
+		env      string
+		expected string
+	}{
+		{name: "disabled", home: home, goos: "linux", env: "", expected: ""},
+		{name: "false", home: home, goos: "linux", env: "false", expected: ""},
+		{name: "invalid", home: home, goos: "linux", env: "yes-please", expected: ""},
+		{name: "linux", home: home, goos: "linux", env: "true", expected: filepath.Join(home, guiSocketFile)},
+		{name: "darwin", home: home, goos: "darwin", env: "1", expected: filepath.Join(home, guiSocketFile)},
+		{name: "windows", home: home, goos: "windows", env: "true", expected: ""},
+		{name: "too-long", home: "/" + strings.Repeat("a", maxSocketPathLength), goos: "linux", env: "true", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(guiSocketEnvVar, tt.env)
+			defer os.Unsetenv(guiSocketEnvVar)
+
+			socket, ok := getGUISocket(tt.home, tt.goos)
+			if socket != tt.expected {
+				t.Errorf("got socket '%s', expected '%s'", socket, tt.expected)
+			}
+			if ok != (tt.expected != "") {
+				t.Errorf("got enabled %t for socket '%s'", ok, socket)
+			}
+		})
+	}
+}
+
+func TestLocalGUIOverSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the syncthing GUI doesn't listen on unix sockets on windows")
+	}
+
+	dir, err := ioutil.TempDir("", "st")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, guiSocketFile)
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/db/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"state": "idle"}`)
+	}))
+	server.Listener = l
+	server.Start()
+	defer server.Close()
+
+	s := &Syncthing{
+		GUIAddress: unixSocketScheme + socket,
+		Client:     newSocketAPIClient(socket),
+	}
+	if host := s.getLocalGUIHost(); host != socketHost {
+		t.Errorf("got local host '%s', expected '%s'", host, socketHost)
+	}
+
+	status, err := s.GetStatus(context.Background(), &Folder{Name: "1"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != "idle" {
+		t.Errorf("got state '%s', expected 'idle'", status.State)
+	}
+}
+
+func newPingTestServer(t *testing.T, failures int32) (*Syncthing, *int32) {
+	var pings int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/system/ping" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if atomic.AddInt32(&pings, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"ping": "pong"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	s := &Syncthing{
+		GUIAddress: strings.TrimPrefix(server.URL, "http://"),
+		Client:     NewAPIClient(),
+	}
+	return s, &pings
+}
+
+func TestWaitForPing(t *testing.T) {
+	s, pings := newPingTestServer(t, 3)
+	if err := s.WaitForPing(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	if p := atomic.LoadInt32(pings); p != 4 {
+		t.Errorf("got %d pings, expected 4", p)
+	}
+}
+
+func TestWaitForPingMinAttempts(t *testing.T) {
+	s, pings := newPingTestServer(t, 1000)
+	if err := s.WaitForPing(context.Background(), true); err == nil {
+		t.Fatal("didn't get the expected error")
+	}
+	if p := atomic.LoadInt32(pings); p != minPingAttempts {
+		t.Errorf("got %d pings, expected %d", p, minPingAttempts)
+	}
+}
+
+func TestWaitForPingCancelled(t *testing.T) {
+	s, _ := newPingTestServer(t, 1000)
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := s.WaitForPing(ctx, true); err != context.DeadlineExceeded {
+		t.Errorf("got error '%v', expected '%s'", err, context.DeadlineExceeded)
+	}
+}
+
+func Test_nextPingBackoff(t *testing.T) {
+	backoffs := []time.Duration{}
+	backoff := initialPingBackoff
+	for i := 0; i < 6; i++ {
+		backoffs = append(backoffs, backoff)
+		backoff = nextPingBackoff(backoff)
+	}
+
+	expected := []time.Duration{200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, 1600 * time.Millisecond, 3 * time.Second, 3 * time.Second}
+	for i := range expected {
+		if backoffs[i] != expected[i] {
+			t.Errorf("got backoffs %v, expected %v", backoffs, expected)
+			break
+		}
+	}
+}
+
+func TestGetGUIURL(t *testing.T) {
+	var tests = []struct {
+		address  string
+		expected string
+	}{
+		{address: "localhost:8384", expected: "http://localhost:8384"},
+		{address: "unix:///home/okteto/.okteto/ns/dev/gui.sock", expected: "unix:///home/okteto/.okteto/ns/dev/gui.sock"},
+	}
+
+	for _, tt := range tests {
+		if result := GetGUIURL(tt.address); result != tt.expected {
+			t.Errorf("'%s': expected '%s', got '%s'", tt.address, tt.expected, result)
+		}
+	}
+}